@@ -29,8 +29,15 @@ type RemediationStrategySpec struct {
 	Canaries []string `json:"canaries,omitempty"`
 	//kubebuilder:validation:Minimum=1
 	MaxConcurrency int `json:"maxConcurrency"`
-	//+kubebuilder:default=240
+	// Timeout of the whole upgrade in minutes. Defaults to the defaultTimeout of the operator settings of
+	// the namespace, or 240, recorded in status.computedTimeout.
 	Timeout int `json:"timeout,omitempty"`
+	// ParallelWaves remediates together the consecutive managed policies with the same
+	// ran.openshift.io/ztp-deploy-wave annotation. A cluster moves to the policies of the next wave only
+	// once it is compliant with all the policies of its current wave. The policies are remediated one
+	// at a time when false.
+	//+kubebuilder:default=false
+	ParallelWaves bool `json:"parallelWaves,omitempty"`
 }
 
 // BlockingCR defines the Upgrade CRs that block the current CR from running if not completed
@@ -41,8 +48,9 @@ type BlockingCR struct {
 
 // Actions defines the actions to be done either before or after the managedPolicies are remediated
 type Actions struct {
-	BeforeEnable    BeforeEnable    `json:"beforeEnable,omitempty"`
-	AfterCompletion AfterCompletion `json:"afterCompletion,omitempty"`
+	BeforeEnable           BeforeEnable           `json:"beforeEnable,omitempty"`
+	AfterClusterCompletion AfterClusterCompletion `json:"afterClusterCompletion,omitempty"`
+	AfterCompletion        AfterCompletion        `json:"afterCompletion,omitempty"`
 }
 
 // BeforeEnable defines the actions to be done before starting upgrade
@@ -57,6 +65,16 @@ type BeforeEnable struct {
 	DeleteClusterLabels map[string]string `json:"deleteClusterLabels,omitempty"`
 }
 
+// AfterClusterCompletion defines the actions to be done on each cluster as soon as it completes its remediation
+type AfterClusterCompletion struct {
+	// This field defines a map of key/value pairs that identify the cluster labels
+	// to be added to a cluster once it is compliant with all the managed policies.
+	AddClusterLabels map[string]string `json:"addClusterLabels,omitempty"`
+	// This field defines a map of key/value pairs that identify the cluster labels
+	// to be deleted for a cluster once it is compliant with all the managed policies.
+	DeleteClusterLabels map[string]string `json:"deleteClusterLabels,omitempty"`
+}
+
 // AfterCompletion defines the actions to be done after upgrade is completed
 type AfterCompletion struct {
 	// This field defines a map of key/value pairs that identify the cluster labels
@@ -81,6 +99,89 @@ var BatchTimeoutAction = struct {
 	Abort:    "Abort",
 }
 
+// ClusterStateStorage selections
+var ClusterStateStorage = struct {
+	Inline     string
+	ConfigMaps string
+}{
+	Inline:     "Inline",
+	ConfigMaps: "ConfigMaps",
+}
+
+// LockedClusterAction selections
+var LockedClusterAction = struct {
+	Wait string
+	Skip string
+}{
+	Wait: "Wait",
+	Skip: "Skip",
+}
+
+// ConditionTypes define the types of the ClusterGroupUpgrade status conditions.
+// Ready is deprecated and kept for compatibility, the other conditions should be used instead.
+var ConditionTypes = struct {
+	ClustersSelected    string
+	Validated           string
+	PrecachingSucceeded string
+	BackupSucceeded     string
+	Progressing         string
+	Succeeded           string
+	Failed              string
+	Ready               string
+}{
+	ClustersSelected:    "ClustersSelected",
+	Validated:           "Validated",
+	PrecachingSucceeded: "PrecachingSucceeded",
+	BackupSucceeded:     "BackupSucceeded",
+	Progressing:         "Progressing",
+	Succeeded:           "Succeeded",
+	Failed:              "Failed",
+	Ready:               "Ready",
+}
+
+// ConditionReasons define the reasons of the ClusterGroupUpgrade status conditions
+var ConditionReasons = struct {
+	ClusterSelectionCompleted  string
+	ClusterNotFound            string
+	ValidationCompleted        string
+	InvalidCanaries            string
+	InvalidPreCachingConfig    string
+	NotAllManagedPoliciesExist string
+	PrecachingInProgress       string
+	PrecachingCompleted        string
+	PrecachingFailed           string
+	BackupInProgress           string
+	BackupCompleted            string
+	BackupFailed               string
+	NotEnabled                 string
+	NotStarted                 string
+	Blocked                    string
+	ValidationFailed           string
+	InProgress                 string
+	Completed                  string
+	TimedOut                   string
+}{
+	ClusterSelectionCompleted:  "ClusterSelectionCompleted",
+	ClusterNotFound:            "ClusterNotFound",
+	ValidationCompleted:        "ValidationCompleted",
+	InvalidCanaries:            "InvalidCanaries",
+	InvalidPreCachingConfig:    "InvalidPreCachingConfig",
+	NotAllManagedPoliciesExist: "NotAllManagedPoliciesExist",
+	PrecachingInProgress:       "PrecachingInProgress",
+	PrecachingCompleted:        "PrecachingCompleted",
+	PrecachingFailed:           "PrecachingFailed",
+	BackupInProgress:           "BackupInProgress",
+	BackupCompleted:            "BackupCompleted",
+	BackupFailed:               "BackupFailed",
+	NotEnabled:                 "NotEnabled",
+	NotStarted:                 "NotStarted",
+	Blocked:                    "Blocked",
+	ValidationFailed:           "ValidationFailed",
+	InProgress:                 "InProgress",
+	Completed:                  "Completed",
+	TimedOut:                   "TimedOut",
+}
+
 // OperatorUpgradeSpec defines the configuration of an operator upgrade
 type OperatorUpgradeSpec struct {
 	Channel   string `json:"channel,omitempty"`
@@ -104,6 +205,9 @@ type ClusterGroupUpgradeSpec struct {
 	//+kubebuilder:default=false
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="PreCaching",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:bool"}
 	PreCaching bool `json:"preCaching,omitempty"`
+	// This field configures the pre-caching jobs started when preCaching is true
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="PreCachingConfig",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	PreCachingConfig *PreCachingConfig `json:"preCachingConfig,omitempty"`
 	// This field determines when the upgrade starts. While false, the upgrade doesn't start. The policies,
 	// placement rules and placement bindings are created, but clusters are not added to the placement rule.
 	// Once set to true, the clusters start being upgraded, one batch at a time.
@@ -160,13 +264,32 @@ type ClusterGroupUpgradeSpec struct {
 	//   - Abort
 	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="BatchTimeoutAction",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
 	BatchTimeoutAction string `json:"batchTimeoutAction,omitempty"`
+	// The Locked Cluster Action controls what happens when a cluster of the current batch is being remediated
+	// by another ClusterGroupUpgrade. The default value is `Wait`.
+	// The possible values are:
+	//   - Wait: the cluster is remediated once the other ClusterGroupUpgrade releases it
+	//   - Skip: the cluster is left out of this ClusterGroupUpgrade
+	//+kubebuilder:validation:Enum=Wait;Skip
+	//+kubebuilder:default=Wait
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="LockedClusterAction",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	LockedClusterAction string `json:"lockedClusterAction,omitempty"`
+	// This field defines the order in which ClusterGroupUpgrades obtain slots when the operator is
+	// configured with a fleet-wide limit on concurrent remediations, pre-caching or backup jobs.
+	// Higher values are served first. ClusterGroupUpgrades with the same priority are served in
+	// creation order. The default value is 0.
+	//+kubebuilder:default=0
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Priority",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
+	Priority int `json:"priority,omitempty"`
 }
 
 // ClusterRemediationProgress stores the remediation progress of a cluster
 type ClusterRemediationProgress struct {
-	// State should be one of the following: NotStarted, InProgress, Completed
+	// State should be one of the following: NotStarted, InProgress, Completed, Skipped
 	State       string `json:"state,omitempty"`
 	PolicyIndex *int   `json:"policyIndex,omitempty"`
+	// LockedBy holds the namespace/name of the ClusterGroupUpgrade remediating the cluster
+	// when the cluster could not be locked for this one
+	LockedBy string `json:"lockedBy,omitempty"`
 }
 
 // ClusterRemediationProgress possible states
@@ -174,6 +297,7 @@ const (
 	NotStarted = "NotStarted"
 	InProgress = "InProgress"
 	Completed  = "Completed"
+	Skipped    = "Skipped"
 )
 
 // UpgradeStatus defines the observed state of the upgrade
@@ -184,12 +308,68 @@ type UpgradeStatus struct {
 	CurrentBatchStartedAt metav1.Time `json:"currentBatchStartedAt,omitempty"`
 
 	CurrentBatchRemediationProgress map[string]*ClusterRemediationProgress `json:"currentBatchRemediationProgress,omitempty"`
+	// SkippedClusters holds the clusters left out of the upgrade because they were locked by another
+	// ClusterGroupUpgrade, together with the namespace/name of the lock holder
+	SkippedClusters map[string]string `json:"skippedClusters,omitempty"`
 }
 
 // ManagedPolicyForUpgrade defines the observed state of a Policy
 type ManagedPolicyForUpgrade struct {
 	Name      string `json:"name,omitempty"`
 	Namespace string `json:"namespace,omitempty"`
+	// Wave is the ran.openshift.io/ztp-deploy-wave annotation of the policy, when the policies of a wave are
+	// remediated together
+	Wave *int `json:"wave,omitempty"`
+}
+
+// PreCachingConfig defines how the pre-caching jobs are run on the clusters
+type PreCachingConfig struct {
+	// MaxConcurrency is the maximum number of clusters of the ClusterGroupUpgrade pre-caching at the same
+	// time. The other clusters are queued until a job ends. 0 means no limit.
+	//+kubebuilder:validation:Minimum=0
+	MaxConcurrency int `json:"maxConcurrency,omitempty"`
+	// PullRateLimit is the maximum number of image pulls started per minute by the pre-caching job of each
+	// cluster. 0 means no limit.
+	//+kubebuilder:validation:Minimum=0
+	PullRateLimit int `json:"pullRateLimit,omitempty"`
+	// Retry defines how the clusters failing to pre-cache are retried. By default they are not.
+	Retry *PreCachingRetryPolicy `json:"retry,omitempty"`
+	// Standalone only pre-caches the software spec set below on the clusters: the managed policies are neither
+	// read nor remediated. The ClusterGroupUpgrade succeeds once all the clusters are pre-cached, and fails
+	// once the pre-caching of a cluster failed and is not retried anymore. The actions after completion are
+	// not taken. Requires preCaching.
+	Standalone bool `json:"standalone,omitempty"`
+	// PlatformImage is the release image to pre-cache. It takes precedence over the one found in the managed
+	// policies and in the overrides ConfigMap.
+	PlatformImage string `json:"platformImage,omitempty"`
+	// OperatorsIndexes are the operator index images to pre-cache from. They take precedence over the ones
+	// found in the managed policies and in the overrides ConfigMap.
+	OperatorsIndexes []string `json:"operatorsIndexes,omitempty"`
+	// OperatorsPackagesAndChannels are the <package>:<channel> of the operators to pre-cache. They take
+	// precedence over the ones found in the managed policies and in the overrides ConfigMap.
+	OperatorsPackagesAndChannels []string `json:"operatorsPackagesAndChannels,omitempty"`
+	// AdditionalImages are images pre-cached in addition to the release and operator images, e.g. the
+	// workload images of the CNFs
+	AdditionalImages []string `json:"additionalImages,omitempty"`
+	// ExcludePrecachePatterns are extended regular expressions excluding the matching images from the pre-caching.
+	// The release images are matched by both their name in the release and their pull spec.
+	ExcludePrecachePatterns []string `json:"excludePrecachePatterns,omitempty"`
+	// SpaceRequired is the disk space the spoke must have available to pre-cache, e.g. 40Gi
+	SpaceRequired string `json:"spaceRequired,omitempty"`
+	// PrecachedBy names a standalone ClusterGroupUpgrade of the same namespace. The clusters it pre-cached
+	// successfully with the software spec they need for this ClusterGroupUpgrade skip its pre-caching.
+	PrecachedBy string `json:"precachedBy,omitempty"`
+}
+
+// PreCachingRetryPolicy defines how the clusters failing to pre-cache are retried
+type PreCachingRetryPolicy struct {
+	// Attempts is the maximum number of pre-caching attempts on a cluster, including the first one
+	//+kubebuilder:validation:Minimum=1
+	//+kubebuilder:default=1
+	Attempts int `json:"attempts,omitempty"`
+	// Backoff is the time waited before retrying a cluster after its first failure, doubled after each
+	// further failure. The default value is 5m.
+	Backoff *metav1.Duration `json:"backoff,omitempty"`
 }
 
 // PrecachingSpec defines the pre-caching software spec derived from policies
@@ -197,6 +377,12 @@ type PrecachingSpec struct {
 	PlatformImage                string   `json:"platformImage,omitempty"`
 	OperatorsIndexes             []string `json:"operatorsIndexes,omitempty"`
 	OperatorsPackagesAndChannels []string `json:"operatorsPackagesAndChannels,omitempty"`
+	// OperatorsInstalledCSVs are the <package>:<csv> entries of the operators installed on the cluster. The
+	// bundles on the upgrade path from the installed CSV to the channel head are pre-cached.
+	OperatorsInstalledCSVs  []string `json:"operatorsInstalledCSVs,omitempty"`
+	AdditionalImages        []string `json:"additionalImages,omitempty"`
+	ExcludePrecachePatterns []string `json:"excludePrecachePatterns,omitempty"`
+	SpaceRequired           string   `json:"spaceRequired,omitempty"`
 }
 
 // PrecachingStatus defines the observed pre-caching status
@@ -204,6 +390,57 @@ type PrecachingStatus struct {
 	Spec     *PrecachingSpec   `json:"spec,omitempty"`
 	Status   map[string]string `json:"status,omitempty"`
 	Clusters []string          `json:"clusters,omitempty"`
+	// Counts is the number of clusters per pre-caching state
+	Counts map[string]int `json:"counts,omitempty"`
+	// Attempts records the pre-caching attempts per cluster
+	Attempts map[string]*PrecachingAttempts `json:"attempts,omitempty"`
+	// RetryTrigger is the value of the ran.openshift.io/precache-retry annotation the failed clusters
+	// were last retried for
+	RetryTrigger string `json:"retryTrigger,omitempty"`
+	// Summaries is the progress of the pre-caching job per cluster, as published by the job on the cluster
+	Summaries map[string]*PrecachingSummary `json:"summaries,omitempty"`
+	// Specs are the distinct software specs found in the child policies of the clusters, by name, when the
+	// clusters don't all pre-cache the same software. Spec is the one of all the clusters otherwise.
+	Specs map[string]*PrecachingSpec `json:"specs,omitempty"`
+	// ClusterSpecs is the name of the spec in Specs each cluster pre-caches
+	ClusterSpecs map[string]string `json:"clusterSpecs,omitempty"`
+}
+
+// PrecachingAttempts records the pre-caching attempts on a cluster
+type PrecachingAttempts struct {
+	// Count is the number of pre-caching attempts started on the cluster
+	Count int `json:"count,omitempty"`
+	// LastFailureTime is the time the last attempt failed
+	LastFailureTime *metav1.Time `json:"lastFailureTime,omitempty"`
+}
+
+// PrecachingSummary is the progress of the pre-caching job of a cluster
+type PrecachingSummary struct {
+	// Total is the number of images to pre-cache
+	Total int `json:"total,omitempty"`
+	// Pulled is the number of images pulled so far
+	Pulled int `json:"pulled,omitempty"`
+	// Skipped is the number of images already present on the cluster
+	Skipped int `json:"skipped,omitempty"`
+	// Failed is the number of images that could not be pulled
+	Failed int `json:"failed,omitempty"`
+	// Bytes is the size of the images pulled
+	Bytes int64 `json:"bytes,omitempty"`
+	// Failures lists the first images that could not be pulled, with the reason
+	Failures []PrecachingImageFailure `json:"failures,omitempty"`
+	// Error is the reason the job failed before pulling the images
+	Error string `json:"error,omitempty"`
+	// SpaceAvailable is the disk space in bytes available on the cluster before pulling the images
+	SpaceAvailable int64 `json:"spaceAvailable,omitempty"`
+	// SpaceRequired is the disk space in bytes required to pull the images, either set in the pre-caching
+	// config or estimated from the images missing on the cluster
+	SpaceRequired int64 `json:"spaceRequired,omitempty"`
+}
+
+// PrecachingImageFailure is an image that could not be pulled
+type PrecachingImageFailure struct {
+	Image  string `json:"image"`
+	Reason string `json:"reason,omitempty"`
 }
 
 // BackupStatus defines the observed backup status
@@ -219,6 +456,28 @@ type PolicyContent struct {
 	Namespace *string `json:"namespace,omitempty"`
 }
 
+// ClusterStateConfigMap references a ConfigMap holding part of the per-cluster state of a ClusterGroupUpgrade
+type ClusterStateConfigMap struct {
+	Name string `json:"name"`
+	// ResourceVersion of the ConfigMap when the status was written, used to detect a stale read
+	ResourceVersion string `json:"resourceVersion,omitempty"`
+}
+
+// ClusterStatesStatus summarizes the per-cluster state of the ClusterGroupUpgrade when it is stored in
+// ConfigMaps owned by the ClusterGroupUpgrade. The remediation plan, the safe resource names and the
+// clusters and states of pre-caching and backup are then left out of the status.
+type ClusterStatesStatus struct {
+	ConfigMaps []ClusterStateConfigMap `json:"configMaps,omitempty"`
+	// Clusters is the number of clusters in the remediation plan
+	Clusters int `json:"clusters,omitempty"`
+	// Batches is the number of batches in the remediation plan
+	Batches int `json:"batches,omitempty"`
+	// Precaching counts the clusters per pre-caching state
+	Precaching map[string]int `json:"precaching,omitempty"`
+	// Backup counts the clusters per backup state
+	Backup map[string]int `json:"backup,omitempty"`
+}
+
 // ClusterGroupUpgradeStatus defines the observed state of ClusterGroupUpgrade
 type ClusterGroupUpgradeStatus struct {
 	// INSERT ADDITIONAL STATUS FIELD - define observed state of cluster
@@ -229,6 +488,8 @@ type ClusterGroupUpgradeStatus struct {
 	PlacementRules []string `json:"placementRules,omitempty"`
 	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Copied Policies"
 	CopiedPolicies []string `json:"copiedPolicies,omitempty"`
+	// Conditions of the ClusterGroupUpgrade: ClustersSelected, Validated, PrecachingSucceeded, BackupSucceeded,
+	// Progressing, Succeeded and Failed. The Ready condition is deprecated and kept for compatibility.
 	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Conditions"
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Remediation Plan"
@@ -253,12 +514,23 @@ type ClusterGroupUpgradeStatus struct {
 	Backup *BackupStatus `json:"backup,omitempty"`
 	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Computed Maximum Concurrency"
 	ComputedMaxConcurrency int `json:"computedMaxConcurrency,omitempty"`
+	// ComputedTimeout is the timeout of the upgrade in minutes: the timeout of the spec, or the defaultTimeout of
+	// the operator settings of the namespace when the ClusterGroupUpgrade was first reconciled
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Computed Timeout"
+	ComputedTimeout int `json:"computedTimeout,omitempty"`
+	// ClusterStates is set when the per-cluster state is stored in ConfigMaps instead of the status
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Cluster States"
+	ClusterStates *ClusterStatesStatus `json:"clusterStates,omitempty"`
 }
 
+// +genclient
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
 //+kubebuilder:resource:path=clustergroupupgrades,shortName=cgu
-//+kubebuilder:printcolumn:name="Upgrade State",type="string",JSONPath=".status.conditions[-1:].reason"
+//+kubebuilder:storageversion
+//+kubebuilder:printcolumn:name="State",type="string",JSONPath=`.status.conditions[?(@.type=="Progressing")].reason`
+//+kubebuilder:printcolumn:name="Succeeded",type="string",JSONPath=`.status.conditions[?(@.type=="Succeeded")].status`
+//+kubebuilder:printcolumn:name="Failed",type="string",JSONPath=`.status.conditions[?(@.type=="Failed")].status`
 //+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
 // ClusterGroupUpgrade is the Schema for the ClusterGroupUpgrades API
@@ -0,0 +1,236 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NotificationSink defines an HTTP endpoint notified when a ClusterGroupUpgrade changes state
+type NotificationSink struct {
+	// Name identifies the sink in the operator logs
+	Name string `json:"name"`
+	// URL of the endpoint. A JSON document describing the ClusterGroupUpgrade state change is POSTed to it.
+	//+kubebuilder:validation:Pattern=`^https?://`
+	URL string `json:"url"`
+	// Reasons restricts the notifications to the given Ready condition reasons, e.g. UpgradeCompleted.
+	// All the state changes are notified if empty.
+	Reasons []string `json:"reasons,omitempty"`
+}
+
+// OperatorSettings defines the operator settings that can be overridden per namespace
+type OperatorSettings struct {
+	// PrecacheImage is the pre-caching workload image pull spec. Defaults to the PRECACHE_IMG environment variable.
+	PrecacheImage string `json:"precacheImage,omitempty"`
+	// RecoveryImage is the backup workload image pull spec. Defaults to the RECOVERY_IMG environment variable.
+	RecoveryImage string `json:"recoveryImage,omitempty"`
+	// PlatformImage is the OCP release image pull spec to pre-cache instead of the one found in the policies
+	PlatformImage string `json:"platformImage,omitempty"`
+	// OperatorsIndexes is the list of OLM index images to pre-cache instead of the ones found in the policies
+	OperatorsIndexes []string `json:"operatorsIndexes,omitempty"`
+	// OperatorsPackagesAndChannels is the list of <package:channel> entries to pre-cache instead of the ones
+	// found in the policies
+	OperatorsPackagesAndChannels []string `json:"operatorsPackagesAndChannels,omitempty"`
+	// DefaultTimeout is the timeout in minutes of the ClusterGroupUpgrades created by the operator and of the
+	// ClusterGroupUpgrades that don't set spec.remediationStrategy.timeout. Defaults to 240.
+	//+kubebuilder:validation:Minimum=1
+	DefaultTimeout int `json:"defaultTimeout,omitempty"`
+	// DefaultBatchTimeoutAction is used for the ClusterGroupUpgrades that don't set spec.batchTimeoutAction
+	//+kubebuilder:validation:Enum=Continue;Abort
+	DefaultBatchTimeoutAction string `json:"defaultBatchTimeoutAction,omitempty"`
+	// PrecacheJob customizes the pre-caching job run on the spoke clusters
+	PrecacheJob *JobSettings `json:"precacheJob,omitempty"`
+	// BackupJob customizes the backup job run on the spoke clusters
+	BackupJob *JobSettings `json:"backupJob,omitempty"`
+	// NotificationSinks are notified when a ClusterGroupUpgrade changes state
+	NotificationSinks []NotificationSink `json:"notificationSinks,omitempty"`
+}
+
+// JobSettings defines the settings of a job run by the operator on the spoke clusters
+type JobSettings struct {
+	// Resources are the compute resources of the job container
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+	// PriorityClassName of the job pod. The pre-caching job defaults to system-cluster-critical.
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+	// NodeSelector of the job pod
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// Tolerations of the job pod
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+	// Env is added to the environment of the job container, e.g. MAX_PULL_THREADS for the pre-caching job
+	Env []corev1.EnvVar `json:"env,omitempty"`
+	// ActiveDeadlineSeconds of the job. Defaults to the timeout of the ClusterGroupUpgrade.
+	//+kubebuilder:validation:Minimum=1
+	ActiveDeadlineSeconds *int64 `json:"activeDeadlineSeconds,omitempty"`
+	// ClusterRoleRules are the rules of the ClusterRole created on the spoke clusters and bound to the service
+	// account of the job. Defaults to the rules the job needs: using the privileged SecurityContextConstraints,
+	// and reading the MachineConfigs for the backup job.
+	ClusterRoleRules []rbacv1.PolicyRule `json:"clusterRoleRules,omitempty"`
+	// ClusterAdmin binds cluster-admin to the service account of the job instead of its ClusterRole.
+	// Can't be set along with clusterRoleRules.
+	ClusterAdmin bool `json:"clusterAdmin,omitempty"`
+}
+
+// RequeueIntervals defines how long the operator waits before checking on a ClusterGroupUpgrade again
+type RequeueIntervals struct {
+	// Short is used while pre-caching or backup jobs are starting. The default value is 30s.
+	Short *metav1.Duration `json:"short,omitempty"`
+	// Medium is used while waiting for blocking CRs, capacity or policy compliance. The default value is 1m.
+	Medium *metav1.Duration `json:"medium,omitempty"`
+	// Long is used while waiting for the ClusterGroupUpgrade to be enabled. The default value is 5m.
+	Long *metav1.Duration `json:"long,omitempty"`
+}
+
+// ConcurrencyLimits defines the fleet-wide concurrency limits. They take precedence over the operator flags.
+// A value of 0 means no limit.
+type ConcurrencyLimits struct {
+	//+kubebuilder:validation:Minimum=0
+	MaxConcurrentRemediations *int `json:"maxConcurrentRemediations,omitempty"`
+	//+kubebuilder:validation:Minimum=0
+	MaxConcurrentPrecaching *int `json:"maxConcurrentPrecaching,omitempty"`
+	//+kubebuilder:validation:Minimum=0
+	MaxConcurrentBackups *int `json:"maxConcurrentBackups,omitempty"`
+}
+
+// ZTPSettings defines how the managedclusterForCGU controller handles the clusters deployed with ZTP
+type ZTPSettings struct {
+	// Namespace of the ClusterGroupUpgrades created for the ZTP clusters, created if missing.
+	// The default value is ztp-install.
+	Namespace string `json:"namespace,omitempty"`
+	// RunningLabel is added to a cluster while its ZTP ClusterGroupUpgrade runs. The default value is ztp-running.
+	RunningLabel string `json:"runningLabel,omitempty"`
+	// DoneLabel is added to a cluster once its ZTP ClusterGroupUpgrade completes. No ZTP ClusterGroupUpgrade is
+	// created for the clusters with this label. The default value is ztp-done.
+	DoneLabel string `json:"doneLabel,omitempty"`
+	// Timeout is the timeout in minutes of the ZTP ClusterGroupUpgrades. Defaults to the defaultTimeout of the
+	// settings of the namespace.
+	//+kubebuilder:validation:Minimum=1
+	Timeout int `json:"timeout,omitempty"`
+	// BatchTimeoutAction is the batchTimeoutAction of the ZTP ClusterGroupUpgrades. Defaults to the
+	// defaultBatchTimeoutAction of the settings of the namespace.
+	//+kubebuilder:validation:Enum=Continue;Abort
+	BatchTimeoutAction string `json:"batchTimeoutAction,omitempty"`
+	// Backup enables the backup of the clusters in the ZTP ClusterGroupUpgrades
+	Backup bool `json:"backup,omitempty"`
+	// PreCaching enables the pre-caching of the clusters in the ZTP ClusterGroupUpgrades
+	PreCaching bool `json:"preCaching,omitempty"`
+	// Actions are added to the ones setting the running and done labels of the ZTP ClusterGroupUpgrades
+	Actions *Actions `json:"actions,omitempty"`
+	// ClusterSelector restricts the ZTP ClusterGroupUpgrades to the matching ManagedClusters.
+	// All the clusters are selected by default.
+	ClusterSelector *metav1.LabelSelector `json:"clusterSelector,omitempty"`
+	// Day2Remediation creates a follow-up ClusterGroupUpgrade for a cluster with the ztp-done label when one of
+	// its child policies with a ztp-deploy-wave annotation turns NonCompliant. The default value is false.
+	Day2Remediation bool `json:"day2Remediation,omitempty"`
+	// ParallelWaves remediates together the policies of a ZTP ClusterGroupUpgrade sharing a ztp-deploy-wave.
+	// The default value is false.
+	ParallelWaves bool `json:"parallelWaves,omitempty"`
+	// Aggregation groups the clusters becoming ready at about the same time with the same ZTP policies into
+	// shared ClusterGroupUpgrades. Each cluster has its own ClusterGroupUpgrade when not set.
+	Aggregation *ZTPAggregation `json:"aggregation,omitempty"`
+}
+
+// ZTPAggregation defines how the ZTP clusters are grouped into shared ClusterGroupUpgrades
+type ZTPAggregation struct {
+	// SettlingTime is how long the ready clusters with the same wave-ordered policies and settings are collected,
+	// from the first one of the group, before their ClusterGroupUpgrade is created. The default value is 1m.
+	SettlingTime *metav1.Duration `json:"settlingTime,omitempty"`
+	// MaxConcurrency is the maxConcurrency of the shared ClusterGroupUpgrades. The default value is 10.
+	//+kubebuilder:validation:Minimum=1
+	MaxConcurrency int `json:"maxConcurrency,omitempty"`
+}
+
+// ConfigMapReference references a ConfigMap
+type ConfigMapReference struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+}
+
+// UpdateGraphSettings defines how the operator reads the OCP update graph to find the release image of the
+// version set in a ClusterVersion policy
+type UpdateGraphSettings struct {
+	// CABundle references a ConfigMap holding the PEM encoded CA certificates trusted for the update graph
+	// upstreams, under the ca-bundle.crt key. The system CA certificates are trusted if not set.
+	CABundle *ConfigMapReference `json:"caBundle,omitempty"`
+	// Proxy is the URL of the proxy the update graph is requested through. Defaults to the HTTPS_PROXY,
+	// HTTP_PROXY and NO_PROXY environment variables of the operator.
+	//+kubebuilder:validation:Pattern=`^https?://`
+	Proxy string `json:"proxy,omitempty"`
+	// Timeout of the update graph requests. The default value is 30s.
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+	// CacheTTL is how long the update graph of an upstream and channel is reused before being requested again.
+	// A value of 0 disables the cache. The default value is 10m.
+	CacheTTL *metav1.Duration `json:"cacheTTL,omitempty"`
+	// Offline references a ConfigMap holding the update graph of each channel under the name of the channel,
+	// for the disconnected hubs. The upstreams are not requested when set.
+	Offline *ConfigMapReference `json:"offline,omitempty"`
+}
+
+// NamespaceOverrides defines the operator settings used for the ClusterGroupUpgrades of a namespace
+type NamespaceOverrides struct {
+	Namespace        string `json:"namespace"`
+	OperatorSettings `json:",inline"`
+}
+
+// ClusterGroupUpgradeOperatorConfigSpec defines the desired operator configuration
+type ClusterGroupUpgradeOperatorConfigSpec struct {
+	OperatorSettings `json:",inline"`
+	RequeueIntervals *RequeueIntervals  `json:"requeueIntervals,omitempty"`
+	Concurrency      *ConcurrencyLimits `json:"concurrency,omitempty"`
+	// NamespaceOverrides are applied on top of the cluster-wide settings for the ClusterGroupUpgrades
+	// of the given namespaces. The cluster-group-upgrade-overrides ConfigMap of a namespace, if present,
+	// still takes precedence over both.
+	NamespaceOverrides []NamespaceOverrides `json:"namespaceOverrides,omitempty"`
+	// ClusterStateStorage selects where the per-cluster state of the ClusterGroupUpgrades is stored. Inline
+	// keeps it in the ClusterGroupUpgrade status. ConfigMaps moves it to ConfigMaps owned by the
+	// ClusterGroupUpgrade, for fleets large enough to approach the object size limit. The default value is Inline.
+	//+kubebuilder:validation:Enum=Inline;ConfigMaps
+	ClusterStateStorage string `json:"clusterStateStorage,omitempty"`
+	ZTP                 *ZTPSettings `json:"ztp,omitempty"`
+	// UpdateGraph configures the requests to the OCP update graph
+	UpdateGraph *UpdateGraphSettings `json:"updateGraph,omitempty"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +genclient:noStatus
+//+kubebuilder:object:root=true
+//+kubebuilder:resource:path=clustergroupupgradeoperatorconfigs,scope=Cluster
+
+// ClusterGroupUpgradeOperatorConfig is the Schema for the operator configuration API.
+// Only the object named "cluster" is used.
+//+operator-sdk:csv:customresourcedefinitions:displayName="Cluster Group Upgrade Operator Config"
+type ClusterGroupUpgradeOperatorConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec ClusterGroupUpgradeOperatorConfigSpec `json:"spec,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ClusterGroupUpgradeOperatorConfigList contains a list of ClusterGroupUpgradeOperatorConfig
+type ClusterGroupUpgradeOperatorConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterGroupUpgradeOperatorConfig `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterGroupUpgradeOperatorConfig{}, &ClusterGroupUpgradeOperatorConfigList{})
+}
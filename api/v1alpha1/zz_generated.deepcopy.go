@@ -22,6 +22,8 @@ limitations under the License.
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
@@ -30,6 +32,7 @@ import (
 func (in *Actions) DeepCopyInto(out *Actions) {
 	*out = *in
 	in.BeforeEnable.DeepCopyInto(&out.BeforeEnable)
+	in.AfterClusterCompletion.DeepCopyInto(&out.AfterClusterCompletion)
 	in.AfterCompletion.DeepCopyInto(&out.AfterCompletion)
 }
 
@@ -43,6 +46,35 @@ func (in *Actions) DeepCopy() *Actions {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AfterClusterCompletion) DeepCopyInto(out *AfterClusterCompletion) {
+	*out = *in
+	if in.AddClusterLabels != nil {
+		in, out := &in.AddClusterLabels, &out.AddClusterLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.DeleteClusterLabels != nil {
+		in, out := &in.DeleteClusterLabels, &out.DeleteClusterLabels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AfterClusterCompletion.
+func (in *AfterClusterCompletion) DeepCopy() *AfterClusterCompletion {
+	if in == nil {
+		return nil
+	}
+	out := new(AfterClusterCompletion)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AfterCompletion) DeepCopyInto(out *AfterCompletion) {
 	*out = *in
@@ -207,9 +239,115 @@ func (in *ClusterGroupUpgradeList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterGroupUpgradeOperatorConfig) DeepCopyInto(out *ClusterGroupUpgradeOperatorConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterGroupUpgradeOperatorConfig.
+func (in *ClusterGroupUpgradeOperatorConfig) DeepCopy() *ClusterGroupUpgradeOperatorConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterGroupUpgradeOperatorConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterGroupUpgradeOperatorConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterGroupUpgradeOperatorConfigList) DeepCopyInto(out *ClusterGroupUpgradeOperatorConfigList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterGroupUpgradeOperatorConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterGroupUpgradeOperatorConfigList.
+func (in *ClusterGroupUpgradeOperatorConfigList) DeepCopy() *ClusterGroupUpgradeOperatorConfigList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterGroupUpgradeOperatorConfigList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterGroupUpgradeOperatorConfigList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterGroupUpgradeOperatorConfigSpec) DeepCopyInto(out *ClusterGroupUpgradeOperatorConfigSpec) {
+	*out = *in
+	in.OperatorSettings.DeepCopyInto(&out.OperatorSettings)
+	if in.RequeueIntervals != nil {
+		in, out := &in.RequeueIntervals, &out.RequeueIntervals
+		*out = new(RequeueIntervals)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Concurrency != nil {
+		in, out := &in.Concurrency, &out.Concurrency
+		*out = new(ConcurrencyLimits)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NamespaceOverrides != nil {
+		in, out := &in.NamespaceOverrides, &out.NamespaceOverrides
+		*out = make([]NamespaceOverrides, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ZTP != nil {
+		in, out := &in.ZTP, &out.ZTP
+		*out = new(ZTPSettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UpdateGraph != nil {
+		in, out := &in.UpdateGraph, &out.UpdateGraph
+		*out = new(UpdateGraphSettings)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterGroupUpgradeOperatorConfigSpec.
+func (in *ClusterGroupUpgradeOperatorConfigSpec) DeepCopy() *ClusterGroupUpgradeOperatorConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterGroupUpgradeOperatorConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterGroupUpgradeSpec) DeepCopyInto(out *ClusterGroupUpgradeSpec) {
 	*out = *in
+	if in.PreCachingConfig != nil {
+		in, out := &in.PreCachingConfig, &out.PreCachingConfig
+		*out = new(PreCachingConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Enable != nil {
 		in, out := &in.Enable, &out.Enable
 		*out = new(bool)
@@ -313,7 +451,9 @@ func (in *ClusterGroupUpgradeStatus) DeepCopyInto(out *ClusterGroupUpgradeStatus
 	if in.ManagedPoliciesForUpgrade != nil {
 		in, out := &in.ManagedPoliciesForUpgrade, &out.ManagedPoliciesForUpgrade
 		*out = make([]ManagedPolicyForUpgrade, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.ManagedPoliciesCompliantBeforeUpgrade != nil {
 		in, out := &in.ManagedPoliciesCompliantBeforeUpgrade, &out.ManagedPoliciesCompliantBeforeUpgrade
@@ -338,6 +478,11 @@ func (in *ClusterGroupUpgradeStatus) DeepCopyInto(out *ClusterGroupUpgradeStatus
 		*out = new(BackupStatus)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.ClusterStates != nil {
+		in, out := &in.ClusterStates, &out.ClusterStates
+		*out = new(ClusterStatesStatus)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterGroupUpgradeStatus.
@@ -370,9 +515,161 @@ func (in *ClusterRemediationProgress) DeepCopy() *ClusterRemediationProgress {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterStateConfigMap) DeepCopyInto(out *ClusterStateConfigMap) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStateConfigMap.
+func (in *ClusterStateConfigMap) DeepCopy() *ClusterStateConfigMap {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterStateConfigMap)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterStatesStatus) DeepCopyInto(out *ClusterStatesStatus) {
+	*out = *in
+	if in.ConfigMaps != nil {
+		in, out := &in.ConfigMaps, &out.ConfigMaps
+		*out = make([]ClusterStateConfigMap, len(*in))
+		copy(*out, *in)
+	}
+	if in.Precaching != nil {
+		in, out := &in.Precaching, &out.Precaching
+		*out = make(map[string]int, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Backup != nil {
+		in, out := &in.Backup, &out.Backup
+		*out = make(map[string]int, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStatesStatus.
+func (in *ClusterStatesStatus) DeepCopy() *ClusterStatesStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterStatesStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConcurrencyLimits) DeepCopyInto(out *ConcurrencyLimits) {
+	*out = *in
+	if in.MaxConcurrentRemediations != nil {
+		in, out := &in.MaxConcurrentRemediations, &out.MaxConcurrentRemediations
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxConcurrentPrecaching != nil {
+		in, out := &in.MaxConcurrentPrecaching, &out.MaxConcurrentPrecaching
+		*out = new(int)
+		**out = **in
+	}
+	if in.MaxConcurrentBackups != nil {
+		in, out := &in.MaxConcurrentBackups, &out.MaxConcurrentBackups
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConcurrencyLimits.
+func (in *ConcurrencyLimits) DeepCopy() *ConcurrencyLimits {
+	if in == nil {
+		return nil
+	}
+	out := new(ConcurrencyLimits)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConfigMapReference) DeepCopyInto(out *ConfigMapReference) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConfigMapReference.
+func (in *ConfigMapReference) DeepCopy() *ConfigMapReference {
+	if in == nil {
+		return nil
+	}
+	out := new(ConfigMapReference)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JobSettings) DeepCopyInto(out *JobSettings) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]corev1.EnvVar, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ActiveDeadlineSeconds != nil {
+		in, out := &in.ActiveDeadlineSeconds, &out.ActiveDeadlineSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ClusterRoleRules != nil {
+		in, out := &in.ClusterRoleRules, &out.ClusterRoleRules
+		*out = make([]rbacv1.PolicyRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JobSettings.
+func (in *JobSettings) DeepCopy() *JobSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(JobSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ManagedPolicyForUpgrade) DeepCopyInto(out *ManagedPolicyForUpgrade) {
 	*out = *in
+	if in.Wave != nil {
+		in, out := &in.Wave, &out.Wave
+		*out = new(int)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ManagedPolicyForUpgrade.
@@ -385,6 +682,84 @@ func (in *ManagedPolicyForUpgrade) DeepCopy() *ManagedPolicyForUpgrade {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NamespaceOverrides) DeepCopyInto(out *NamespaceOverrides) {
+	*out = *in
+	in.OperatorSettings.DeepCopyInto(&out.OperatorSettings)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NamespaceOverrides.
+func (in *NamespaceOverrides) DeepCopy() *NamespaceOverrides {
+	if in == nil {
+		return nil
+	}
+	out := new(NamespaceOverrides)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotificationSink) DeepCopyInto(out *NotificationSink) {
+	*out = *in
+	if in.Reasons != nil {
+		in, out := &in.Reasons, &out.Reasons
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationSink.
+func (in *NotificationSink) DeepCopy() *NotificationSink {
+	if in == nil {
+		return nil
+	}
+	out := new(NotificationSink)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OperatorSettings) DeepCopyInto(out *OperatorSettings) {
+	*out = *in
+	if in.OperatorsIndexes != nil {
+		in, out := &in.OperatorsIndexes, &out.OperatorsIndexes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.OperatorsPackagesAndChannels != nil {
+		in, out := &in.OperatorsPackagesAndChannels, &out.OperatorsPackagesAndChannels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PrecacheJob != nil {
+		in, out := &in.PrecacheJob, &out.PrecacheJob
+		*out = new(JobSettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BackupJob != nil {
+		in, out := &in.BackupJob, &out.BackupJob
+		*out = new(JobSettings)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NotificationSinks != nil {
+		in, out := &in.NotificationSinks, &out.NotificationSinks
+		*out = make([]NotificationSink, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OperatorSettings.
+func (in *OperatorSettings) DeepCopy() *OperatorSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(OperatorSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OperatorUpgradeSpec) DeepCopyInto(out *OperatorUpgradeSpec) {
 	*out = *in
@@ -420,6 +795,100 @@ func (in *PolicyContent) DeepCopy() *PolicyContent {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreCachingConfig) DeepCopyInto(out *PreCachingConfig) {
+	*out = *in
+	if in.Retry != nil {
+		in, out := &in.Retry, &out.Retry
+		*out = new(PreCachingRetryPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OperatorsIndexes != nil {
+		in, out := &in.OperatorsIndexes, &out.OperatorsIndexes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.OperatorsPackagesAndChannels != nil {
+		in, out := &in.OperatorsPackagesAndChannels, &out.OperatorsPackagesAndChannels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AdditionalImages != nil {
+		in, out := &in.AdditionalImages, &out.AdditionalImages
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludePrecachePatterns != nil {
+		in, out := &in.ExcludePrecachePatterns, &out.ExcludePrecachePatterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreCachingConfig.
+func (in *PreCachingConfig) DeepCopy() *PreCachingConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PreCachingConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PreCachingRetryPolicy) DeepCopyInto(out *PreCachingRetryPolicy) {
+	*out = *in
+	if in.Backoff != nil {
+		in, out := &in.Backoff, &out.Backoff
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PreCachingRetryPolicy.
+func (in *PreCachingRetryPolicy) DeepCopy() *PreCachingRetryPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(PreCachingRetryPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrecachingAttempts) DeepCopyInto(out *PrecachingAttempts) {
+	*out = *in
+	if in.LastFailureTime != nil {
+		in, out := &in.LastFailureTime, &out.LastFailureTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrecachingAttempts.
+func (in *PrecachingAttempts) DeepCopy() *PrecachingAttempts {
+	if in == nil {
+		return nil
+	}
+	out := new(PrecachingAttempts)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrecachingImageFailure) DeepCopyInto(out *PrecachingImageFailure) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrecachingImageFailure.
+func (in *PrecachingImageFailure) DeepCopy() *PrecachingImageFailure {
+	if in == nil {
+		return nil
+	}
+	out := new(PrecachingImageFailure)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PrecachingSpec) DeepCopyInto(out *PrecachingSpec) {
 	*out = *in
@@ -433,6 +902,21 @@ func (in *PrecachingSpec) DeepCopyInto(out *PrecachingSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.OperatorsInstalledCSVs != nil {
+		in, out := &in.OperatorsInstalledCSVs, &out.OperatorsInstalledCSVs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AdditionalImages != nil {
+		in, out := &in.AdditionalImages, &out.AdditionalImages
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExcludePrecachePatterns != nil {
+		in, out := &in.ExcludePrecachePatterns, &out.ExcludePrecachePatterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrecachingSpec.
@@ -465,6 +949,65 @@ func (in *PrecachingStatus) DeepCopyInto(out *PrecachingStatus) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.Counts != nil {
+		in, out := &in.Counts, &out.Counts
+		*out = make(map[string]int, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Attempts != nil {
+		in, out := &in.Attempts, &out.Attempts
+		*out = make(map[string]*PrecachingAttempts, len(*in))
+		for key, val := range *in {
+			var outVal *PrecachingAttempts
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = new(PrecachingAttempts)
+				(*in).DeepCopyInto(*out)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.Summaries != nil {
+		in, out := &in.Summaries, &out.Summaries
+		*out = make(map[string]*PrecachingSummary, len(*in))
+		for key, val := range *in {
+			var outVal *PrecachingSummary
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = new(PrecachingSummary)
+				(*in).DeepCopyInto(*out)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.Specs != nil {
+		in, out := &in.Specs, &out.Specs
+		*out = make(map[string]*PrecachingSpec, len(*in))
+		for key, val := range *in {
+			var outVal *PrecachingSpec
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				in, out := &val, &outVal
+				*out = new(PrecachingSpec)
+				(*in).DeepCopyInto(*out)
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.ClusterSpecs != nil {
+		in, out := &in.ClusterSpecs, &out.ClusterSpecs
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrecachingStatus.
@@ -477,6 +1020,26 @@ func (in *PrecachingStatus) DeepCopy() *PrecachingStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PrecachingSummary) DeepCopyInto(out *PrecachingSummary) {
+	*out = *in
+	if in.Failures != nil {
+		in, out := &in.Failures, &out.Failures
+		*out = make([]PrecachingImageFailure, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PrecachingSummary.
+func (in *PrecachingSummary) DeepCopy() *PrecachingSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(PrecachingSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RemediationStrategySpec) DeepCopyInto(out *RemediationStrategySpec) {
 	*out = *in
@@ -497,6 +1060,71 @@ func (in *RemediationStrategySpec) DeepCopy() *RemediationStrategySpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RequeueIntervals) DeepCopyInto(out *RequeueIntervals) {
+	*out = *in
+	if in.Short != nil {
+		in, out := &in.Short, &out.Short
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Medium != nil {
+		in, out := &in.Medium, &out.Medium
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Long != nil {
+		in, out := &in.Long, &out.Long
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RequeueIntervals.
+func (in *RequeueIntervals) DeepCopy() *RequeueIntervals {
+	if in == nil {
+		return nil
+	}
+	out := new(RequeueIntervals)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpdateGraphSettings) DeepCopyInto(out *UpdateGraphSettings) {
+	*out = *in
+	if in.CABundle != nil {
+		in, out := &in.CABundle, &out.CABundle
+		*out = new(ConfigMapReference)
+		**out = **in
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.CacheTTL != nil {
+		in, out := &in.CacheTTL, &out.CacheTTL
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Offline != nil {
+		in, out := &in.Offline, &out.Offline
+		*out = new(ConfigMapReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpdateGraphSettings.
+func (in *UpdateGraphSettings) DeepCopy() *UpdateGraphSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(UpdateGraphSettings)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UpgradeStatus) DeepCopyInto(out *UpgradeStatus) {
 	*out = *in
@@ -518,6 +1146,13 @@ func (in *UpgradeStatus) DeepCopyInto(out *UpgradeStatus) {
 			(*out)[key] = outVal
 		}
 	}
+	if in.SkippedClusters != nil {
+		in, out := &in.SkippedClusters, &out.SkippedClusters
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpgradeStatus.
@@ -529,3 +1164,53 @@ func (in *UpgradeStatus) DeepCopy() *UpgradeStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZTPAggregation) DeepCopyInto(out *ZTPAggregation) {
+	*out = *in
+	if in.SettlingTime != nil {
+		in, out := &in.SettlingTime, &out.SettlingTime
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZTPAggregation.
+func (in *ZTPAggregation) DeepCopy() *ZTPAggregation {
+	if in == nil {
+		return nil
+	}
+	out := new(ZTPAggregation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ZTPSettings) DeepCopyInto(out *ZTPSettings) {
+	*out = *in
+	if in.Actions != nil {
+		in, out := &in.Actions, &out.Actions
+		*out = new(Actions)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ClusterSelector != nil {
+		in, out := &in.ClusterSelector, &out.ClusterSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Aggregation != nil {
+		in, out := &in.Aggregation, &out.Aggregation
+		*out = new(ZTPAggregation)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ZTPSettings.
+func (in *ZTPSettings) DeepCopy() *ZTPSettings {
+	if in == nil {
+		return nil
+	}
+	out := new(ZTPSettings)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -0,0 +1,562 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+
+	"github.com/openshift-kni/cluster-group-upgrades-operator/api/v1alpha1"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+)
+
+// ConversionDataAnnotation holds the v1alpha1 fields that can't be represented in v1beta1,
+// so that converting an object back to v1alpha1 is lossless
+const ConversionDataAnnotation = "ran.openshift.io/v1alpha1-conversion-data"
+
+// conversionData holds the v1alpha1 fields without a v1beta1 equivalent
+type conversionData struct {
+	// ClusterSelector is deprecated in v1alpha1 and removed in v1beta1
+	ClusterSelector []string `json:"clusterSelector,omitempty"`
+	// ManagedPoliciesContent holds the entries of v1alpha1 status.managedPoliciesContent
+	// that are not a valid JSON list of policy contents
+	ManagedPoliciesContent map[string]string `json:"managedPoliciesContent,omitempty"`
+	// ManagedPoliciesWithoutNs holds the policies of v1alpha1 status.managedPoliciesNs with an empty
+	// namespace, which v1beta1 can't tell from the policies that are not listed
+	ManagedPoliciesWithoutNs []string `json:"managedPoliciesWithoutNs,omitempty"`
+}
+
+// ConvertTo converts this ClusterGroupUpgrade to the hub version (v1alpha1)
+func (src *ClusterGroupUpgrade) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1alpha1.ClusterGroupUpgrade)
+
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	data := conversionData{}
+	if raw, ok := dst.Annotations[ConversionDataAnnotation]; ok {
+		if err := json.Unmarshal([]byte(raw), &data); err != nil {
+			return err
+		}
+		delete(dst.Annotations, ConversionDataAnnotation)
+		if len(dst.Annotations) == 0 {
+			dst.Annotations = nil
+		}
+	}
+
+	dst.Spec = v1alpha1.ClusterGroupUpgradeSpec{
+		Backup:                src.Spec.Backup,
+		PreCaching:            src.Spec.PreCaching,
+		Enable:                src.Spec.Enable,
+		Clusters:              src.Spec.Clusters,
+		ClusterSelector:       data.ClusterSelector,
+		ClusterLabelSelectors: src.Spec.ClusterLabelSelectors,
+		ManagedPolicies:       src.Spec.ManagedPolicies,
+		Actions: v1alpha1.Actions{
+			BeforeEnable: v1alpha1.BeforeEnable{
+				AddClusterLabels:    src.Spec.Actions.BeforeEnable.AddClusterLabels,
+				DeleteClusterLabels: src.Spec.Actions.BeforeEnable.DeleteClusterLabels,
+			},
+			AfterClusterCompletion: v1alpha1.AfterClusterCompletion{
+				AddClusterLabels:    src.Spec.Actions.AfterClusterCompletion.AddClusterLabels,
+				DeleteClusterLabels: src.Spec.Actions.AfterClusterCompletion.DeleteClusterLabels,
+			},
+			AfterCompletion: v1alpha1.AfterCompletion{
+				AddClusterLabels:    src.Spec.Actions.AfterCompletion.AddClusterLabels,
+				DeleteClusterLabels: src.Spec.Actions.AfterCompletion.DeleteClusterLabels,
+				DeleteObjects:       src.Spec.Actions.AfterCompletion.DeleteObjects,
+			},
+		},
+		BatchTimeoutAction:  src.Spec.BatchTimeoutAction,
+		LockedClusterAction: src.Spec.LockedClusterAction,
+		Priority:            src.Spec.Priority,
+	}
+	if src.Spec.RemediationStrategy != nil {
+		dst.Spec.RemediationStrategy = &v1alpha1.RemediationStrategySpec{
+			Canaries:       src.Spec.RemediationStrategy.Canaries,
+			MaxConcurrency: src.Spec.RemediationStrategy.MaxConcurrency,
+			Timeout:        src.Spec.RemediationStrategy.Timeout,
+			ParallelWaves:  src.Spec.RemediationStrategy.ParallelWaves,
+		}
+	}
+	if src.Spec.PreCachingConfig != nil {
+		dst.Spec.PreCachingConfig = &v1alpha1.PreCachingConfig{
+			MaxConcurrency:               src.Spec.PreCachingConfig.MaxConcurrency,
+			PullRateLimit:                src.Spec.PreCachingConfig.PullRateLimit,
+			Standalone:                   src.Spec.PreCachingConfig.Standalone,
+			PlatformImage:                src.Spec.PreCachingConfig.PlatformImage,
+			OperatorsIndexes:             src.Spec.PreCachingConfig.OperatorsIndexes,
+			OperatorsPackagesAndChannels: src.Spec.PreCachingConfig.OperatorsPackagesAndChannels,
+			AdditionalImages:             src.Spec.PreCachingConfig.AdditionalImages,
+			ExcludePrecachePatterns:      src.Spec.PreCachingConfig.ExcludePrecachePatterns,
+			SpaceRequired:                src.Spec.PreCachingConfig.SpaceRequired,
+			PrecachedBy:                  src.Spec.PreCachingConfig.PrecachedBy,
+		}
+		if src.Spec.PreCachingConfig.Retry != nil {
+			retry := v1alpha1.PreCachingRetryPolicy(*src.Spec.PreCachingConfig.Retry)
+			dst.Spec.PreCachingConfig.Retry = &retry
+		}
+	}
+	for _, blockingCR := range src.Spec.BlockingCRs {
+		dst.Spec.BlockingCRs = append(dst.Spec.BlockingCRs, v1alpha1.BlockingCR(blockingCR))
+	}
+
+	dst.Status = v1alpha1.ClusterGroupUpgradeStatus{
+		PlacementBindings:                     src.Status.PlacementBindings,
+		PlacementRules:                        src.Status.PlacementRules,
+		CopiedPolicies:                        src.Status.CopiedPolicies,
+		Conditions:                            src.Status.Conditions,
+		RemediationPlan:                       src.Status.RemediationPlan,
+		ManagedPoliciesCompliantBeforeUpgrade: src.Status.ManagedPoliciesCompliantBeforeUpgrade,
+		ComputedMaxConcurrency:                src.Status.ComputedMaxConcurrency,
+		ComputedTimeout:                       src.Status.ComputedTimeout,
+		Status: v1alpha1.UpgradeStatus{
+			StartedAt:             src.Status.Status.StartedAt,
+			CompletedAt:           src.Status.Status.CompletedAt,
+			CurrentBatch:          src.Status.Status.CurrentBatch,
+			CurrentBatchStartedAt: src.Status.Status.CurrentBatchStartedAt,
+		},
+	}
+
+	for _, policy := range src.Status.ManagedPolicies {
+		if policy.Namespace != "" {
+			if dst.Status.ManagedPoliciesNs == nil {
+				dst.Status.ManagedPoliciesNs = make(map[string]string)
+			}
+			dst.Status.ManagedPoliciesNs[policy.Name] = policy.Namespace
+		}
+		if len(policy.Content) != 0 {
+			content := make([]v1alpha1.PolicyContent, 0, len(policy.Content))
+			for _, policyContent := range policy.Content {
+				content = append(content, v1alpha1.PolicyContent(policyContent))
+			}
+			p, err := json.Marshal(content)
+			if err != nil {
+				return err
+			}
+			if dst.Status.ManagedPoliciesContent == nil {
+				dst.Status.ManagedPoliciesContent = make(map[string]string)
+			}
+			dst.Status.ManagedPoliciesContent[policy.Name] = string(p)
+		}
+	}
+	for _, name := range data.ManagedPoliciesWithoutNs {
+		if dst.Status.ManagedPoliciesNs == nil {
+			dst.Status.ManagedPoliciesNs = make(map[string]string)
+		}
+		dst.Status.ManagedPoliciesNs[name] = ""
+	}
+	for name, content := range data.ManagedPoliciesContent {
+		if dst.Status.ManagedPoliciesContent == nil {
+			dst.Status.ManagedPoliciesContent = make(map[string]string)
+		}
+		dst.Status.ManagedPoliciesContent[name] = content
+	}
+
+	for _, safeName := range src.Status.SafeResourceNames {
+		if dst.Status.SafeResourceNames == nil {
+			dst.Status.SafeResourceNames = make(map[string]string)
+		}
+		dst.Status.SafeResourceNames[safeName.Name] = safeName.SafeName
+	}
+	for _, policy := range src.Status.ManagedPoliciesForUpgrade {
+		dst.Status.ManagedPoliciesForUpgrade = append(dst.Status.ManagedPoliciesForUpgrade,
+			v1alpha1.ManagedPolicyForUpgrade{Name: policy.Name, Namespace: policy.Namespace, Wave: policy.Wave})
+	}
+
+	for _, progress := range src.Status.Status.CurrentBatchRemediationProgress {
+		if dst.Status.Status.CurrentBatchRemediationProgress == nil {
+			dst.Status.Status.CurrentBatchRemediationProgress = make(map[string]*v1alpha1.ClusterRemediationProgress)
+		}
+		dst.Status.Status.CurrentBatchRemediationProgress[progress.Name] = &v1alpha1.ClusterRemediationProgress{
+			State:       progress.State,
+			PolicyIndex: progress.PolicyIndex,
+			LockedBy:    progress.LockedBy,
+		}
+	}
+	for _, skipped := range src.Status.Status.SkippedClusters {
+		if dst.Status.Status.SkippedClusters == nil {
+			dst.Status.Status.SkippedClusters = make(map[string]string)
+		}
+		dst.Status.Status.SkippedClusters[skipped.Name] = skipped.LockedBy
+	}
+
+	if src.Status.Precaching != nil {
+		dst.Status.Precaching = &v1alpha1.PrecachingStatus{
+			Status:       clusterStatesToMap(src.Status.Precaching.Status),
+			Clusters:     src.Status.Precaching.Clusters,
+			Counts:       src.Status.Precaching.Counts,
+			RetryTrigger: src.Status.Precaching.RetryTrigger,
+		}
+		if src.Status.Precaching.Spec != nil {
+			spec := v1alpha1.PrecachingSpec(*src.Status.Precaching.Spec)
+			dst.Status.Precaching.Spec = &spec
+		}
+		for _, attempts := range src.Status.Precaching.Attempts {
+			if dst.Status.Precaching.Attempts == nil {
+				dst.Status.Precaching.Attempts = make(map[string]*v1alpha1.PrecachingAttempts)
+			}
+			dst.Status.Precaching.Attempts[attempts.Name] = &v1alpha1.PrecachingAttempts{
+				Count:           attempts.Count,
+				LastFailureTime: attempts.LastFailureTime,
+			}
+		}
+		for _, summary := range src.Status.Precaching.Summaries {
+			if dst.Status.Precaching.Summaries == nil {
+				dst.Status.Precaching.Summaries = make(map[string]*v1alpha1.PrecachingSummary)
+			}
+			dstSummary := &v1alpha1.PrecachingSummary{
+				Total:          summary.Total,
+				Pulled:         summary.Pulled,
+				Skipped:        summary.Skipped,
+				Failed:         summary.Failed,
+				Bytes:          summary.Bytes,
+				Error:          summary.Error,
+				SpaceAvailable: summary.SpaceAvailable,
+				SpaceRequired:  summary.SpaceRequired,
+			}
+			for _, failure := range summary.Failures {
+				dstSummary.Failures = append(dstSummary.Failures, v1alpha1.PrecachingImageFailure(failure))
+			}
+			dst.Status.Precaching.Summaries[summary.Name] = dstSummary
+		}
+		for _, spec := range src.Status.Precaching.Specs {
+			if dst.Status.Precaching.Specs == nil {
+				dst.Status.Precaching.Specs = make(map[string]*v1alpha1.PrecachingSpec)
+			}
+			dstSpec := v1alpha1.PrecachingSpec(spec.Spec)
+			dst.Status.Precaching.Specs[spec.Name] = &dstSpec
+		}
+		for _, clusterSpec := range src.Status.Precaching.ClusterSpecs {
+			if dst.Status.Precaching.ClusterSpecs == nil {
+				dst.Status.Precaching.ClusterSpecs = make(map[string]string)
+			}
+			dst.Status.Precaching.ClusterSpecs[clusterSpec.Name] = clusterSpec.Spec
+		}
+	}
+	if src.Status.Backup != nil {
+		dst.Status.Backup = &v1alpha1.BackupStatus{
+			Status:   clusterStatesToMap(src.Status.Backup.Status),
+			Clusters: src.Status.Backup.Clusters,
+		}
+	}
+	if src.Status.ClusterStates != nil {
+		dst.Status.ClusterStates = &v1alpha1.ClusterStatesStatus{
+			Clusters:   src.Status.ClusterStates.Clusters,
+			Batches:    src.Status.ClusterStates.Batches,
+			Precaching: src.Status.ClusterStates.Precaching,
+			Backup:     src.Status.ClusterStates.Backup,
+		}
+		for _, configMap := range src.Status.ClusterStates.ConfigMaps {
+			dst.Status.ClusterStates.ConfigMaps = append(dst.Status.ClusterStates.ConfigMaps,
+				v1alpha1.ClusterStateConfigMap(configMap))
+		}
+	}
+	return nil
+}
+
+// ConvertFrom converts from the hub version (v1alpha1) to this version
+func (dst *ClusterGroupUpgrade) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1alpha1.ClusterGroupUpgrade)
+
+	dst.ObjectMeta = *src.ObjectMeta.DeepCopy()
+	data := conversionData{ClusterSelector: src.Spec.ClusterSelector}
+
+	dst.Spec = ClusterGroupUpgradeSpec{
+		Backup:                src.Spec.Backup,
+		PreCaching:            src.Spec.PreCaching,
+		Enable:                src.Spec.Enable,
+		Clusters:              src.Spec.Clusters,
+		ClusterLabelSelectors: src.Spec.ClusterLabelSelectors,
+		ManagedPolicies:       src.Spec.ManagedPolicies,
+		Actions: Actions{
+			BeforeEnable: ClusterLabelActions{
+				AddClusterLabels:    src.Spec.Actions.BeforeEnable.AddClusterLabels,
+				DeleteClusterLabels: src.Spec.Actions.BeforeEnable.DeleteClusterLabels,
+			},
+			AfterClusterCompletion: ClusterLabelActions{
+				AddClusterLabels:    src.Spec.Actions.AfterClusterCompletion.AddClusterLabels,
+				DeleteClusterLabels: src.Spec.Actions.AfterClusterCompletion.DeleteClusterLabels,
+			},
+			AfterCompletion: AfterCompletion{
+				ClusterLabelActions: ClusterLabelActions{
+					AddClusterLabels:    src.Spec.Actions.AfterCompletion.AddClusterLabels,
+					DeleteClusterLabels: src.Spec.Actions.AfterCompletion.DeleteClusterLabels,
+				},
+				DeleteObjects: src.Spec.Actions.AfterCompletion.DeleteObjects,
+			},
+		},
+		BatchTimeoutAction:  src.Spec.BatchTimeoutAction,
+		LockedClusterAction: src.Spec.LockedClusterAction,
+		Priority:            src.Spec.Priority,
+	}
+	if src.Spec.RemediationStrategy != nil {
+		dst.Spec.RemediationStrategy = &RemediationStrategySpec{
+			Canaries:       src.Spec.RemediationStrategy.Canaries,
+			MaxConcurrency: src.Spec.RemediationStrategy.MaxConcurrency,
+			Timeout:        src.Spec.RemediationStrategy.Timeout,
+			ParallelWaves:  src.Spec.RemediationStrategy.ParallelWaves,
+		}
+	}
+	if src.Spec.PreCachingConfig != nil {
+		dst.Spec.PreCachingConfig = &PreCachingConfig{
+			MaxConcurrency:               src.Spec.PreCachingConfig.MaxConcurrency,
+			PullRateLimit:                src.Spec.PreCachingConfig.PullRateLimit,
+			Standalone:                   src.Spec.PreCachingConfig.Standalone,
+			PlatformImage:                src.Spec.PreCachingConfig.PlatformImage,
+			OperatorsIndexes:             src.Spec.PreCachingConfig.OperatorsIndexes,
+			OperatorsPackagesAndChannels: src.Spec.PreCachingConfig.OperatorsPackagesAndChannels,
+			AdditionalImages:             src.Spec.PreCachingConfig.AdditionalImages,
+			ExcludePrecachePatterns:      src.Spec.PreCachingConfig.ExcludePrecachePatterns,
+			SpaceRequired:                src.Spec.PreCachingConfig.SpaceRequired,
+			PrecachedBy:                  src.Spec.PreCachingConfig.PrecachedBy,
+		}
+		if src.Spec.PreCachingConfig.Retry != nil {
+			retry := PreCachingRetryPolicy(*src.Spec.PreCachingConfig.Retry)
+			dst.Spec.PreCachingConfig.Retry = &retry
+		}
+	}
+	for _, blockingCR := range src.Spec.BlockingCRs {
+		dst.Spec.BlockingCRs = append(dst.Spec.BlockingCRs, BlockingCR(blockingCR))
+	}
+
+	dst.Status = ClusterGroupUpgradeStatus{
+		PlacementBindings:                     src.Status.PlacementBindings,
+		PlacementRules:                        src.Status.PlacementRules,
+		CopiedPolicies:                        src.Status.CopiedPolicies,
+		Conditions:                            src.Status.Conditions,
+		RemediationPlan:                       src.Status.RemediationPlan,
+		ManagedPoliciesCompliantBeforeUpgrade: src.Status.ManagedPoliciesCompliantBeforeUpgrade,
+		ComputedMaxConcurrency:                src.Status.ComputedMaxConcurrency,
+		ComputedTimeout:                       src.Status.ComputedTimeout,
+		Status: UpgradeStatus{
+			StartedAt:             src.Status.Status.StartedAt,
+			CompletedAt:           src.Status.Status.CompletedAt,
+			CurrentBatch:          src.Status.Status.CurrentBatch,
+			CurrentBatchStartedAt: src.Status.Status.CurrentBatchStartedAt,
+		},
+	}
+
+	// The v1alpha1 managed policies namespaces and contents are merged into a single list
+	managedPolicies := make(map[string]*ManagedPolicyStatus)
+	for name, namespace := range src.Status.ManagedPoliciesNs {
+		if namespace == "" {
+			data.ManagedPoliciesWithoutNs = append(data.ManagedPoliciesWithoutNs, name)
+		}
+		managedPolicies[name] = &ManagedPolicyStatus{PolicyReference: PolicyReference{Name: name, Namespace: namespace}}
+	}
+	for name, rawContent := range src.Status.ManagedPoliciesContent {
+		content, ok := parsePolicyContent(rawContent)
+		if !ok {
+			if data.ManagedPoliciesContent == nil {
+				data.ManagedPoliciesContent = make(map[string]string)
+			}
+			data.ManagedPoliciesContent[name] = rawContent
+			continue
+		}
+		if _, ok := managedPolicies[name]; !ok {
+			managedPolicies[name] = &ManagedPolicyStatus{PolicyReference: PolicyReference{Name: name}}
+		}
+		managedPolicies[name].Content = content
+	}
+	sort.Strings(data.ManagedPoliciesWithoutNs)
+	var policyNames []string
+	for name := range managedPolicies {
+		policyNames = append(policyNames, name)
+	}
+	sort.Strings(policyNames)
+	for _, name := range policyNames {
+		dst.Status.ManagedPolicies = append(dst.Status.ManagedPolicies, *managedPolicies[name])
+	}
+
+	for _, name := range sortedKeys(src.Status.SafeResourceNames) {
+		dst.Status.SafeResourceNames = append(dst.Status.SafeResourceNames,
+			SafeResourceName{Name: name, SafeName: src.Status.SafeResourceNames[name]})
+	}
+	for _, policy := range src.Status.ManagedPoliciesForUpgrade {
+		dst.Status.ManagedPoliciesForUpgrade = append(dst.Status.ManagedPoliciesForUpgrade, ManagedPolicyForUpgrade{
+			PolicyReference: PolicyReference{Name: policy.Name, Namespace: policy.Namespace}, Wave: policy.Wave})
+	}
+
+	progress := src.Status.Status.CurrentBatchRemediationProgress
+	var clusterNames []string
+	for name := range progress {
+		clusterNames = append(clusterNames, name)
+	}
+	sort.Strings(clusterNames)
+	for _, name := range clusterNames {
+		clusterProgress := ClusterRemediationProgress{Name: name}
+		if progress[name] != nil {
+			clusterProgress.State = progress[name].State
+			clusterProgress.PolicyIndex = progress[name].PolicyIndex
+			clusterProgress.LockedBy = progress[name].LockedBy
+		}
+		dst.Status.Status.CurrentBatchRemediationProgress = append(dst.Status.Status.CurrentBatchRemediationProgress, clusterProgress)
+	}
+	for _, name := range sortedKeys(src.Status.Status.SkippedClusters) {
+		dst.Status.Status.SkippedClusters = append(dst.Status.Status.SkippedClusters,
+			SkippedCluster{Name: name, LockedBy: src.Status.Status.SkippedClusters[name]})
+	}
+
+	if src.Status.Precaching != nil {
+		dst.Status.Precaching = &PrecachingStatus{
+			Status:       mapToClusterStates(src.Status.Precaching.Status),
+			Clusters:     src.Status.Precaching.Clusters,
+			Counts:       src.Status.Precaching.Counts,
+			RetryTrigger: src.Status.Precaching.RetryTrigger,
+		}
+		if src.Status.Precaching.Spec != nil {
+			spec := PrecachingSpec(*src.Status.Precaching.Spec)
+			dst.Status.Precaching.Spec = &spec
+		}
+		var attemptsClusters []string
+		for name := range src.Status.Precaching.Attempts {
+			attemptsClusters = append(attemptsClusters, name)
+		}
+		sort.Strings(attemptsClusters)
+		for _, name := range attemptsClusters {
+			attempts := PrecachingAttempts{Name: name}
+			if src.Status.Precaching.Attempts[name] != nil {
+				attempts.Count = src.Status.Precaching.Attempts[name].Count
+				attempts.LastFailureTime = src.Status.Precaching.Attempts[name].LastFailureTime
+			}
+			dst.Status.Precaching.Attempts = append(dst.Status.Precaching.Attempts, attempts)
+		}
+		var summaryClusters []string
+		for name := range src.Status.Precaching.Summaries {
+			summaryClusters = append(summaryClusters, name)
+		}
+		sort.Strings(summaryClusters)
+		for _, name := range summaryClusters {
+			summary := PrecachingSummary{Name: name}
+			if srcSummary := src.Status.Precaching.Summaries[name]; srcSummary != nil {
+				summary.Total = srcSummary.Total
+				summary.Pulled = srcSummary.Pulled
+				summary.Skipped = srcSummary.Skipped
+				summary.Failed = srcSummary.Failed
+				summary.Bytes = srcSummary.Bytes
+				summary.Error = srcSummary.Error
+				summary.SpaceAvailable = srcSummary.SpaceAvailable
+				summary.SpaceRequired = srcSummary.SpaceRequired
+				for _, failure := range srcSummary.Failures {
+					summary.Failures = append(summary.Failures, PrecachingImageFailure(failure))
+				}
+			}
+			dst.Status.Precaching.Summaries = append(dst.Status.Precaching.Summaries, summary)
+		}
+		var specNames []string
+		for name := range src.Status.Precaching.Specs {
+			specNames = append(specNames, name)
+		}
+		sort.Strings(specNames)
+		for _, name := range specNames {
+			spec := NamedPrecachingSpec{Name: name}
+			if src.Status.Precaching.Specs[name] != nil {
+				spec.Spec = PrecachingSpec(*src.Status.Precaching.Specs[name])
+			}
+			dst.Status.Precaching.Specs = append(dst.Status.Precaching.Specs, spec)
+		}
+		var specClusters []string
+		for name := range src.Status.Precaching.ClusterSpecs {
+			specClusters = append(specClusters, name)
+		}
+		sort.Strings(specClusters)
+		for _, name := range specClusters {
+			dst.Status.Precaching.ClusterSpecs = append(dst.Status.Precaching.ClusterSpecs,
+				ClusterPrecachingSpec{Name: name, Spec: src.Status.Precaching.ClusterSpecs[name]})
+		}
+	}
+	if src.Status.Backup != nil {
+		dst.Status.Backup = &BackupStatus{
+			Status:   mapToClusterStates(src.Status.Backup.Status),
+			Clusters: src.Status.Backup.Clusters,
+		}
+	}
+	if src.Status.ClusterStates != nil {
+		dst.Status.ClusterStates = &ClusterStatesStatus{
+			Clusters:   src.Status.ClusterStates.Clusters,
+			Batches:    src.Status.ClusterStates.Batches,
+			Precaching: src.Status.ClusterStates.Precaching,
+			Backup:     src.Status.ClusterStates.Backup,
+		}
+		for _, configMap := range src.Status.ClusterStates.ConfigMaps {
+			dst.Status.ClusterStates.ConfigMaps = append(dst.Status.ClusterStates.ConfigMaps,
+				ClusterStateConfigMap(configMap))
+		}
+	}
+
+	if data.ClusterSelector != nil || data.ManagedPoliciesContent != nil || data.ManagedPoliciesWithoutNs != nil {
+		raw, err := json.Marshal(data)
+		if err != nil {
+			return err
+		}
+		if dst.Annotations == nil {
+			dst.Annotations = make(map[string]string)
+		}
+		dst.Annotations[ConversionDataAnnotation] = string(raw)
+	}
+	return nil
+}
+
+// parsePolicyContent parses a v1alpha1 managed policy content. It returns false if the content
+// can't be converted back to the exact same string.
+func parsePolicyContent(rawContent string) ([]PolicyContent, bool) {
+	var content []v1alpha1.PolicyContent
+	if err := json.Unmarshal([]byte(rawContent), &content); err != nil || len(content) == 0 {
+		return nil, false
+	}
+	p, err := json.Marshal(content)
+	if err != nil || !bytes.Equal(p, []byte(rawContent)) {
+		return nil, false
+	}
+
+	result := make([]PolicyContent, 0, len(content))
+	for _, policyContent := range content {
+		result = append(result, PolicyContent(policyContent))
+	}
+	return result, true
+}
+
+// mapToClusterStates converts a map of cluster states to a list sorted by cluster name
+func mapToClusterStates(states map[string]string) []ClusterState {
+	var result []ClusterState
+	for _, name := range sortedKeys(states) {
+		result = append(result, ClusterState{Name: name, State: states[name]})
+	}
+	return result
+}
+
+// clusterStatesToMap converts a list of cluster states to a map
+func clusterStatesToMap(states []ClusterState) map[string]string {
+	if states == nil {
+		return nil
+	}
+	result := make(map[string]string, len(states))
+	for _, state := range states {
+		result[state.Name] = state.State
+	}
+	return result
+}
+
+// sortedKeys returns the keys of a map in alphabetical order
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
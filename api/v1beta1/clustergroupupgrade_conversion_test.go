@@ -0,0 +1,250 @@
+package v1beta1
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/openshift-kni/cluster-group-upgrades-operator/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestConversion_roundTrip(t *testing.T) {
+	enable := true
+	deleteObjects := false
+	wave := 10
+	policyIndex := 1
+	namespace := "openshift-sriov-network-operator"
+	defaultNs := "default"
+	failureTime := metav1.NewTime(time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC))
+
+	testcases := []struct {
+		name string
+		cgu  *v1alpha1.ClusterGroupUpgrade
+		// expected v1beta1 status fields
+		expectedManagedPolicies []ManagedPolicyStatus
+		expectedAnnotation      bool
+	}{
+		{
+			name: "empty",
+			cgu: &v1alpha1.ClusterGroupUpgrade{
+				ObjectMeta: metav1.ObjectMeta{Name: "cgu", Namespace: "default"},
+			},
+		},
+		{
+			name: "complete",
+			cgu: &v1alpha1.ClusterGroupUpgrade{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "cgu", Namespace: "default", Labels: map[string]string{"app": "test"},
+					Annotations: map[string]string{"user": "annotation"},
+				},
+				Spec: v1alpha1.ClusterGroupUpgradeSpec{
+					Backup:     true,
+					PreCaching: true,
+					PreCachingConfig: &v1alpha1.PreCachingConfig{
+						MaxConcurrency: 10, PullRateLimit: 30,
+						Retry: &v1alpha1.PreCachingRetryPolicy{
+							Attempts: 3, Backoff: &metav1.Duration{Duration: time.Minute},
+						},
+						Standalone:                   true,
+						PlatformImage:                "quay.io/release",
+						OperatorsIndexes:             []string{"quay.io/index"},
+						OperatorsPackagesAndChannels: []string{"sriov-network-operator:stable"},
+						AdditionalImages:             []string{"quay.io/cnf:v1"},
+						ExcludePrecachePatterns:      []string{"aws"},
+						SpaceRequired:                "40Gi",
+						PrecachedBy:                  "precache",
+					},
+					Enable:   &enable,
+					Clusters: []string{"spoke1", "spoke2"},
+					ClusterLabelSelectors: []metav1.LabelSelector{
+						{MatchLabels: map[string]string{"upgrade": "true"}},
+					},
+					RemediationStrategy: &v1alpha1.RemediationStrategySpec{
+						Canaries: []string{"spoke1"}, MaxConcurrency: 2, Timeout: 60, ParallelWaves: true,
+					},
+					ManagedPolicies: []string{"policy1", "policy2"},
+					BlockingCRs:     []v1alpha1.BlockingCR{{Name: "blocking", Namespace: "default"}},
+					Actions: v1alpha1.Actions{
+						BeforeEnable: v1alpha1.BeforeEnable{AddClusterLabels: map[string]string{"a": "b"}},
+						AfterClusterCompletion: v1alpha1.AfterClusterCompletion{
+							AddClusterLabels: map[string]string{"e": "f"},
+						},
+						AfterCompletion: v1alpha1.AfterCompletion{
+							DeleteClusterLabels: map[string]string{"c": "d"},
+							DeleteObjects:       &deleteObjects,
+						},
+					},
+					BatchTimeoutAction:  v1alpha1.BatchTimeoutAction.Abort,
+					LockedClusterAction: v1alpha1.LockedClusterAction.Skip,
+					Priority:            10,
+				},
+				Status: v1alpha1.ClusterGroupUpgradeStatus{
+					PlacementBindings: []string{"cgu-policy1-placement"},
+					PlacementRules:    []string{"cgu-policy1-placement"},
+					CopiedPolicies:    []string{"cgu-policy1"},
+					Conditions: []metav1.Condition{
+						{Type: "Progressing", Status: metav1.ConditionTrue, Reason: "InProgress"},
+					},
+					RemediationPlan: [][]string{{"spoke1"}, {"spoke2"}},
+					ManagedPoliciesNs: map[string]string{
+						"policy1": "default",
+						"policy2": "default",
+					},
+					SafeResourceNames: map[string]string{"cgu-policy1": "cgu-policy1-kpqz2"},
+					ManagedPoliciesForUpgrade: []v1alpha1.ManagedPolicyForUpgrade{
+						{Name: "policy2", Namespace: "default", Wave: &wave},
+					},
+					ManagedPoliciesCompliantBeforeUpgrade: []string{"policy1"},
+					ManagedPoliciesContent: map[string]string{
+						"policy2": `[{"kind":"Subscription","name":"sriov-network-operator-subscription","namespace":"openshift-sriov-network-operator"}]`,
+					},
+					Status: v1alpha1.UpgradeStatus{
+						CurrentBatch: 1,
+						CurrentBatchRemediationProgress: map[string]*v1alpha1.ClusterRemediationProgress{
+							"spoke1": {State: v1alpha1.InProgress, PolicyIndex: &policyIndex},
+							"spoke3": {State: v1alpha1.Skipped, LockedBy: "default/other"},
+						},
+						SkippedClusters: map[string]string{"spoke3": "default/other"},
+					},
+					Precaching: &v1alpha1.PrecachingStatus{
+						Spec: &v1alpha1.PrecachingSpec{
+							PlatformImage: "quay.io/release", AdditionalImages: []string{"quay.io/cnf:v1"},
+							ExcludePrecachePatterns: []string{"aws"}, SpaceRequired: "40Gi",
+							OperatorsInstalledCSVs: []string{"sriov-network-operator:sriov-network-operator.v4.10.0"},
+						},
+						Status:   map[string]string{"spoke1": "Succeeded", "spoke2": "Active"},
+						Clusters: []string{"spoke1", "spoke2"},
+						Counts:   map[string]int{"Succeeded": 1, "Active": 1},
+						Attempts: map[string]*v1alpha1.PrecachingAttempts{
+							"spoke1": {Count: 1},
+							"spoke2": {Count: 2, LastFailureTime: &failureTime},
+						},
+						RetryTrigger: "1",
+						Summaries: map[string]*v1alpha1.PrecachingSummary{
+							"spoke1": {Total: 10, Pulled: 8, Skipped: 2, Bytes: 4096, SpaceAvailable: 8192, SpaceRequired: 4096},
+							"spoke2": {Total: 10, Pulled: 3, Failed: 1, Failures: []v1alpha1.PrecachingImageFailure{
+								{Image: "quay.io/operator:v1", Reason: "manifest unknown"},
+							}},
+						},
+						Specs: map[string]*v1alpha1.PrecachingSpec{
+							"spec-1": {PlatformImage: "quay.io/release:4.10"},
+							"spec-2": {PlatformImage: "quay.io/release:4.11"},
+						},
+						ClusterSpecs: map[string]string{"spoke1": "spec-1", "spoke2": "spec-2"},
+					},
+					Backup: &v1alpha1.BackupStatus{
+						Status:   map[string]string{"spoke1": "Succeeded"},
+						Clusters: []string{"spoke1"},
+					},
+					ComputedMaxConcurrency: 2,
+					ComputedTimeout:        240,
+					ClusterStates: &v1alpha1.ClusterStatesStatus{
+						ConfigMaps: []v1alpha1.ClusterStateConfigMap{{Name: "cgu-cluster-states-0", ResourceVersion: "10"}},
+						Clusters:   2,
+						Batches:    2,
+						Precaching: map[string]int{"Succeeded": 1, "Active": 1},
+						Backup:     map[string]int{"Succeeded": 1},
+					},
+				},
+			},
+			expectedManagedPolicies: []ManagedPolicyStatus{
+				{PolicyReference: PolicyReference{Name: "policy1", Namespace: "default"}},
+				{
+					PolicyReference: PolicyReference{Name: "policy2", Namespace: "default"},
+					Content: []PolicyContent{
+						{Kind: "Subscription", Name: "sriov-network-operator-subscription", Namespace: &namespace},
+					},
+				},
+			},
+		},
+		{
+			name: "deprecated and invalid fields",
+			cgu: &v1alpha1.ClusterGroupUpgrade{
+				ObjectMeta: metav1.ObjectMeta{Name: "cgu", Namespace: "default"},
+				Spec: v1alpha1.ClusterGroupUpgradeSpec{
+					ClusterSelector: []string{"upgrade=true"},
+				},
+				Status: v1alpha1.ClusterGroupUpgradeStatus{
+					ManagedPoliciesNs: map[string]string{"policy1": "default"},
+					ManagedPoliciesContent: map[string]string{
+						"policy1": `[ {"kind": "Subscription"} ]`,
+						"policy2": `not json`,
+					},
+				},
+			},
+			expectedManagedPolicies: []ManagedPolicyStatus{
+				{PolicyReference: PolicyReference{Name: "policy1", Namespace: "default"}},
+			},
+			expectedAnnotation: true,
+		},
+		{
+			name: "managed policies without namespace",
+			cgu: &v1alpha1.ClusterGroupUpgrade{
+				ObjectMeta: metav1.ObjectMeta{Name: "cgu", Namespace: "default"},
+				Status: v1alpha1.ClusterGroupUpgradeStatus{
+					ManagedPoliciesNs: map[string]string{"policy1": "default", "policy2": ""},
+					ManagedPoliciesContent: map[string]string{
+						"policy3": `[{"kind":"Subscription","name":"sub","namespace":"default"}]`,
+					},
+				},
+			},
+			expectedManagedPolicies: []ManagedPolicyStatus{
+				{PolicyReference: PolicyReference{Name: "policy1", Namespace: "default"}},
+				{PolicyReference: PolicyReference{Name: "policy2"}},
+				{PolicyReference: PolicyReference{Name: "policy3"}, Content: []PolicyContent{
+					{Kind: "Subscription", Name: "sub", Namespace: &defaultNs}}},
+			},
+			expectedAnnotation: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			original := tc.cgu.DeepCopy()
+
+			beta := &ClusterGroupUpgrade{}
+			assert.NoError(t, beta.ConvertFrom(tc.cgu))
+			assert.Equal(t, original, tc.cgu, "the source object must not be modified")
+			assert.Equal(t, tc.expectedManagedPolicies, beta.Status.ManagedPolicies)
+			_, ok := beta.Annotations[ConversionDataAnnotation]
+			assert.Equal(t, tc.expectedAnnotation, ok)
+
+			alpha := &v1alpha1.ClusterGroupUpgrade{}
+			assert.NoError(t, beta.ConvertTo(alpha))
+
+			expected, err := json.Marshal(original)
+			assert.NoError(t, err)
+			actual, err := json.Marshal(alpha)
+			assert.NoError(t, err)
+			assert.JSONEq(t, string(expected), string(actual))
+		})
+	}
+}
+
+func TestConversion_typedStatus(t *testing.T) {
+	cgu := &v1alpha1.ClusterGroupUpgrade{
+		Status: v1alpha1.ClusterGroupUpgradeStatus{
+			SafeResourceNames: map[string]string{"b": "b-1", "a": "a-1"},
+			Status: v1alpha1.UpgradeStatus{
+				CurrentBatchRemediationProgress: map[string]*v1alpha1.ClusterRemediationProgress{
+					"spoke2": {State: v1alpha1.Completed},
+					"spoke1": {State: v1alpha1.NotStarted},
+				},
+			},
+			Backup: &v1alpha1.BackupStatus{Status: map[string]string{"spoke2": "Active", "spoke1": "Succeeded"}},
+		},
+	}
+	beta := &ClusterGroupUpgrade{}
+	assert.NoError(t, beta.ConvertFrom(cgu))
+
+	assert.Equal(t, []SafeResourceName{{Name: "a", SafeName: "a-1"}, {Name: "b", SafeName: "b-1"}},
+		beta.Status.SafeResourceNames)
+	assert.Equal(t, []ClusterRemediationProgress{
+		{Name: "spoke1", State: v1alpha1.NotStarted},
+		{Name: "spoke2", State: v1alpha1.Completed},
+	}, beta.Status.Status.CurrentBatchRemediationProgress)
+	assert.Equal(t, []ClusterState{{Name: "spoke1", State: "Succeeded"}, {Name: "spoke2", State: "Active"}},
+		beta.Status.Backup.Status)
+}
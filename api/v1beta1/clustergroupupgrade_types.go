@@ -0,0 +1,430 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RemediationStrategySpec defines the remediation policy
+type RemediationStrategySpec struct {
+	// Canaries defines the list of managed clusters that should be remediated first when remediateAction is set to enforce
+	Canaries       []string `json:"canaries,omitempty"`
+	MaxConcurrency int      `json:"maxConcurrency"`
+	// Timeout of the whole upgrade in minutes. Defaults to the defaultTimeout of the operator settings of
+	// the namespace, or 240, recorded in status.computedTimeout.
+	Timeout int `json:"timeout,omitempty"`
+	// ParallelWaves remediates together the consecutive managed policies with the same
+	// ran.openshift.io/ztp-deploy-wave annotation
+	//+kubebuilder:default=false
+	ParallelWaves bool `json:"parallelWaves,omitempty"`
+}
+
+// BlockingCR defines the Upgrade CRs that block the current CR from running if not completed
+type BlockingCR struct {
+	Name      string `json:"name,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// ClusterLabelActions defines the cluster labels to add or delete
+type ClusterLabelActions struct {
+	// This field defines a map of key/value pairs that identify the cluster labels
+	// to be added to the clusters of the upgrade.
+	AddClusterLabels map[string]string `json:"addClusterLabels,omitempty"`
+	// This field defines a map of key/value pairs that identify the cluster labels
+	// to be deleted from the clusters of the upgrade.
+	DeleteClusterLabels map[string]string `json:"deleteClusterLabels,omitempty"`
+}
+
+// AfterCompletion defines the actions to be done after upgrade is completed
+type AfterCompletion struct {
+	ClusterLabelActions `json:",inline"`
+	// This field defines whether clean up the resources created for upgrade
+	//+kubebuilder:default=true
+	DeleteObjects *bool `json:"deleteObjects,omitempty"`
+}
+
+// Actions defines the actions to be done either before or after the managedPolicies are remediated
+type Actions struct {
+	BeforeEnable ClusterLabelActions `json:"beforeEnable,omitempty"`
+	// AfterClusterCompletion is applied to each cluster as soon as it completes its remediation
+	AfterClusterCompletion ClusterLabelActions `json:"afterClusterCompletion,omitempty"`
+	AfterCompletion        AfterCompletion     `json:"afterCompletion,omitempty"`
+}
+
+// ClusterGroupUpgradeSpec defines the desired state of ClusterGroupUpgrade
+type ClusterGroupUpgradeSpec struct {
+	// This field determines whether the cluster would be running a backup prior to the upgrade.
+	//+kubebuilder:default=false
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Backup",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:bool"}
+	Backup bool `json:"backup,omitempty"`
+	// This field determines whether container image pre-caching will be done on all the clusters
+	// of the upgrade. If required, the pre-caching process starts immediately on all clusters
+	// irrespectively of the value of the "enable" flag
+	//+kubebuilder:default=false
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="PreCaching",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:bool"}
+	PreCaching bool `json:"preCaching,omitempty"`
+	// This field configures the pre-caching jobs started when preCaching is true
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="PreCachingConfig",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	PreCachingConfig *PreCachingConfig `json:"preCachingConfig,omitempty"`
+	// This field determines when the upgrade starts. While false, the upgrade doesn't start. The policies,
+	// placement rules and placement bindings are created, but clusters are not added to the placement rule.
+	// Once set to true, the clusters start being upgraded, one batch at a time.
+	//+kubebuilder:default=true
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Enable",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:bool"}
+	Enable *bool `json:"enable,omitempty"`
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Clusters",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	Clusters []string `json:"clusters,omitempty"`
+	// This field holds a list of expressions or labels that will be used to determine what clusters to
+	// include in the operation. A cluster is selected if it matches any of the selectors.
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Cluster Label Selectors",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	ClusterLabelSelectors []metav1.LabelSelector `json:"clusterLabelSelectors,omitempty"`
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Remediation Strategy",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	RemediationStrategy *RemediationStrategySpec `json:"remediationStrategy"`
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Managed Policies",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	ManagedPolicies []string `json:"managedPolicies,omitempty"`
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Blocking CRs",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	BlockingCRs []BlockingCR `json:"blockingCRs,omitempty"`
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Actions",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	Actions Actions `json:"actions,omitempty"`
+	// The Batch Timeout Action controls what happens when a batch times out. The default value is `Continue`.
+	// The possible values are Continue and Abort.
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="BatchTimeoutAction",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	BatchTimeoutAction string `json:"batchTimeoutAction,omitempty"`
+	// The Locked Cluster Action controls what happens when a cluster of the current batch is being remediated
+	// by another ClusterGroupUpgrade. The default value is `Wait`.
+	//+kubebuilder:validation:Enum=Wait;Skip
+	//+kubebuilder:default=Wait
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="LockedClusterAction",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:text"}
+	LockedClusterAction string `json:"lockedClusterAction,omitempty"`
+	// This field defines the order in which ClusterGroupUpgrades obtain slots when the operator is
+	// configured with a fleet-wide concurrency limit. Higher values are served first.
+	//+kubebuilder:default=0
+	//+operator-sdk:csv:customresourcedefinitions:type=spec,displayName="Priority",xDescriptors={"urn:alm:descriptor:com.tectonic.ui:number"}
+	Priority int `json:"priority,omitempty"`
+}
+
+// ClusterRemediationProgress stores the remediation progress of a cluster
+type ClusterRemediationProgress struct {
+	// Name of the cluster
+	Name string `json:"name"`
+	// State should be one of the following: NotStarted, InProgress, Completed, Skipped
+	State string `json:"state,omitempty"`
+	// PolicyIndex is the index in status.managedPoliciesForUpgrade of the policy being remediated
+	PolicyIndex *int `json:"policyIndex,omitempty"`
+	// LockedBy holds the namespace/name of the ClusterGroupUpgrade remediating the cluster
+	// when the cluster could not be locked for this one
+	LockedBy string `json:"lockedBy,omitempty"`
+}
+
+// SkippedCluster defines a cluster left out of the upgrade
+type SkippedCluster struct {
+	Name string `json:"name"`
+	// LockedBy holds the namespace/name of the ClusterGroupUpgrade that held the cluster lock
+	LockedBy string `json:"lockedBy,omitempty"`
+}
+
+// UpgradeStatus defines the observed state of the upgrade
+type UpgradeStatus struct {
+	StartedAt             metav1.Time `json:"startedAt,omitempty"`
+	CompletedAt           metav1.Time `json:"completedAt,omitempty"`
+	CurrentBatch          int         `json:"currentBatch,omitempty"`
+	CurrentBatchStartedAt metav1.Time `json:"currentBatchStartedAt,omitempty"`
+
+	CurrentBatchRemediationProgress []ClusterRemediationProgress `json:"currentBatchRemediationProgress,omitempty"`
+	// SkippedClusters holds the clusters left out of the upgrade because they were locked by another
+	// ClusterGroupUpgrade
+	SkippedClusters []SkippedCluster `json:"skippedClusters,omitempty"`
+}
+
+// PolicyReference references a Policy by namespace and name
+type PolicyReference struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// PolicyContent defines the details of an object configured through a Policy
+type PolicyContent struct {
+	Kind      string  `json:"kind,omitempty"`
+	Name      string  `json:"name,omitempty"`
+	Namespace *string `json:"namespace,omitempty"`
+}
+
+// ManagedPolicyStatus defines the observed state of a managed policy
+type ManagedPolicyStatus struct {
+	PolicyReference `json:",inline"`
+	// Content lists the objects configured through the policy that are relevant for the upgrade,
+	// e.g. the operator subscriptions
+	Content []PolicyContent `json:"content,omitempty"`
+}
+
+// ManagedPolicyForUpgrade defines a managed policy with NonCompliant clusters
+type ManagedPolicyForUpgrade struct {
+	PolicyReference `json:",inline"`
+	// Wave is the ran.openshift.io/ztp-deploy-wave annotation of the policy, when the policies of a wave are
+	// remediated together
+	Wave *int `json:"wave,omitempty"`
+}
+
+// SafeResourceName maps the name of an object created for the upgrade to its actual, length-safe name
+type SafeResourceName struct {
+	Name     string `json:"name"`
+	SafeName string `json:"safeName"`
+}
+
+// ClusterState holds the state of a cluster for a pre-caching or backup operation
+type ClusterState struct {
+	Name  string `json:"name"`
+	State string `json:"state"`
+}
+
+// PreCachingConfig defines how the pre-caching jobs are run on the clusters
+type PreCachingConfig struct {
+	// MaxConcurrency is the maximum number of clusters of the ClusterGroupUpgrade pre-caching at the same
+	// time. The other clusters are queued until a job ends. 0 means no limit.
+	//+kubebuilder:validation:Minimum=0
+	MaxConcurrency int `json:"maxConcurrency,omitempty"`
+	// PullRateLimit is the maximum number of image pulls started per minute by the pre-caching job of each
+	// cluster. 0 means no limit.
+	//+kubebuilder:validation:Minimum=0
+	PullRateLimit int `json:"pullRateLimit,omitempty"`
+	// Retry defines how the clusters failing to pre-cache are retried. By default they are not.
+	Retry *PreCachingRetryPolicy `json:"retry,omitempty"`
+	// Standalone only pre-caches the software spec set below on the clusters: the managed policies are neither
+	// read nor remediated. The ClusterGroupUpgrade succeeds once all the clusters are pre-cached, and fails
+	// once the pre-caching of a cluster failed and is not retried anymore. The actions after completion are
+	// not taken. Requires preCaching.
+	Standalone bool `json:"standalone,omitempty"`
+	// PlatformImage is the release image to pre-cache. It takes precedence over the one found in the managed
+	// policies and in the overrides ConfigMap.
+	PlatformImage string `json:"platformImage,omitempty"`
+	// OperatorsIndexes are the operator index images to pre-cache from. They take precedence over the ones
+	// found in the managed policies and in the overrides ConfigMap.
+	OperatorsIndexes []string `json:"operatorsIndexes,omitempty"`
+	// OperatorsPackagesAndChannels are the <package>:<channel> of the operators to pre-cache. They take
+	// precedence over the ones found in the managed policies and in the overrides ConfigMap.
+	OperatorsPackagesAndChannels []string `json:"operatorsPackagesAndChannels,omitempty"`
+	// AdditionalImages are images pre-cached in addition to the release and operator images, e.g. the
+	// workload images of the CNFs
+	AdditionalImages []string `json:"additionalImages,omitempty"`
+	// ExcludePrecachePatterns are extended regular expressions excluding the matching images from the pre-caching.
+	// The release images are matched by both their name in the release and their pull spec.
+	ExcludePrecachePatterns []string `json:"excludePrecachePatterns,omitempty"`
+	// SpaceRequired is the disk space the spoke must have available to pre-cache, e.g. 40Gi
+	SpaceRequired string `json:"spaceRequired,omitempty"`
+	// PrecachedBy names a standalone ClusterGroupUpgrade of the same namespace. The clusters it pre-cached
+	// successfully with the software spec they need for this ClusterGroupUpgrade skip its pre-caching.
+	PrecachedBy string `json:"precachedBy,omitempty"`
+}
+
+// PreCachingRetryPolicy defines how the clusters failing to pre-cache are retried
+type PreCachingRetryPolicy struct {
+	// Attempts is the maximum number of pre-caching attempts on a cluster, including the first one
+	//+kubebuilder:validation:Minimum=1
+	//+kubebuilder:default=1
+	Attempts int `json:"attempts,omitempty"`
+	// Backoff is the time waited before retrying a cluster after its first failure, doubled after each
+	// further failure. The default value is 5m.
+	Backoff *metav1.Duration `json:"backoff,omitempty"`
+}
+
+// PrecachingSpec defines the pre-caching software spec derived from policies
+type PrecachingSpec struct {
+	PlatformImage                string   `json:"platformImage,omitempty"`
+	OperatorsIndexes             []string `json:"operatorsIndexes,omitempty"`
+	OperatorsPackagesAndChannels []string `json:"operatorsPackagesAndChannels,omitempty"`
+	// OperatorsInstalledCSVs are the <package>:<csv> entries of the operators installed on the cluster. The
+	// bundles on the upgrade path from the installed CSV to the channel head are pre-cached.
+	OperatorsInstalledCSVs  []string `json:"operatorsInstalledCSVs,omitempty"`
+	AdditionalImages        []string `json:"additionalImages,omitempty"`
+	ExcludePrecachePatterns []string `json:"excludePrecachePatterns,omitempty"`
+	SpaceRequired           string   `json:"spaceRequired,omitempty"`
+}
+
+// PrecachingStatus defines the observed pre-caching status
+type PrecachingStatus struct {
+	Spec     *PrecachingSpec `json:"spec,omitempty"`
+	Status   []ClusterState  `json:"status,omitempty"`
+	Clusters []string        `json:"clusters,omitempty"`
+	// Counts is the number of clusters per pre-caching state
+	Counts map[string]int `json:"counts,omitempty"`
+	// Attempts records the pre-caching attempts per cluster
+	Attempts []PrecachingAttempts `json:"attempts,omitempty"`
+	// RetryTrigger is the value of the ran.openshift.io/precache-retry annotation the failed clusters
+	// were last retried for
+	RetryTrigger string `json:"retryTrigger,omitempty"`
+	// Summaries is the progress of the pre-caching job per cluster, as published by the job on the cluster
+	Summaries []PrecachingSummary `json:"summaries,omitempty"`
+	// Specs are the distinct software specs found in the child policies of the clusters when the clusters
+	// don't all pre-cache the same software. Spec is the one of all the clusters otherwise.
+	Specs []NamedPrecachingSpec `json:"specs,omitempty"`
+	// ClusterSpecs is the name of the spec in Specs each cluster pre-caches
+	ClusterSpecs []ClusterPrecachingSpec `json:"clusterSpecs,omitempty"`
+}
+
+// NamedPrecachingSpec is a software spec pre-cached by some of the clusters
+type NamedPrecachingSpec struct {
+	Name string         `json:"name"`
+	Spec PrecachingSpec `json:"spec"`
+}
+
+// ClusterPrecachingSpec is the name of the software spec a cluster pre-caches
+type ClusterPrecachingSpec struct {
+	Name string `json:"name"`
+	Spec string `json:"spec"`
+}
+
+// PrecachingAttempts records the pre-caching attempts on a cluster
+type PrecachingAttempts struct {
+	Name string `json:"name"`
+	// Count is the number of pre-caching attempts started on the cluster
+	Count int `json:"count,omitempty"`
+	// LastFailureTime is the time the last attempt failed
+	LastFailureTime *metav1.Time `json:"lastFailureTime,omitempty"`
+}
+
+// PrecachingSummary is the progress of the pre-caching job of a cluster
+type PrecachingSummary struct {
+	Name string `json:"name"`
+	// Total is the number of images to pre-cache
+	Total int `json:"total,omitempty"`
+	// Pulled is the number of images pulled so far
+	Pulled int `json:"pulled,omitempty"`
+	// Skipped is the number of images already present on the cluster
+	Skipped int `json:"skipped,omitempty"`
+	// Failed is the number of images that could not be pulled
+	Failed int `json:"failed,omitempty"`
+	// Bytes is the size of the images pulled
+	Bytes int64 `json:"bytes,omitempty"`
+	// Failures lists the first images that could not be pulled, with the reason
+	Failures []PrecachingImageFailure `json:"failures,omitempty"`
+	// Error is the reason the job failed before pulling the images
+	Error string `json:"error,omitempty"`
+	// SpaceAvailable is the disk space in bytes available on the cluster before pulling the images
+	SpaceAvailable int64 `json:"spaceAvailable,omitempty"`
+	// SpaceRequired is the disk space in bytes required to pull the images, either set in the pre-caching
+	// config or estimated from the images missing on the cluster
+	SpaceRequired int64 `json:"spaceRequired,omitempty"`
+}
+
+// PrecachingImageFailure is an image that could not be pulled
+type PrecachingImageFailure struct {
+	Image  string `json:"image"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// BackupStatus defines the observed backup status
+type BackupStatus struct {
+	Status   []ClusterState `json:"status,omitempty"`
+	Clusters []string       `json:"clusters,omitempty"`
+}
+
+// ClusterStateConfigMap references a ConfigMap holding part of the per-cluster state of a ClusterGroupUpgrade
+type ClusterStateConfigMap struct {
+	Name string `json:"name"`
+	// ResourceVersion of the ConfigMap when the status was written, used to detect a stale read
+	ResourceVersion string `json:"resourceVersion,omitempty"`
+}
+
+// ClusterStatesStatus summarizes the per-cluster state of the ClusterGroupUpgrade when it is stored in
+// ConfigMaps owned by the ClusterGroupUpgrade. The remediation plan, the safe resource names and the
+// clusters and states of pre-caching and backup are then left out of the status.
+type ClusterStatesStatus struct {
+	ConfigMaps []ClusterStateConfigMap `json:"configMaps,omitempty"`
+	// Clusters is the number of clusters in the remediation plan
+	Clusters int `json:"clusters,omitempty"`
+	// Batches is the number of batches in the remediation plan
+	Batches int `json:"batches,omitempty"`
+	// Precaching counts the clusters per pre-caching state
+	Precaching map[string]int `json:"precaching,omitempty"`
+	// Backup counts the clusters per backup state
+	Backup map[string]int `json:"backup,omitempty"`
+}
+
+// ClusterGroupUpgradeStatus defines the observed state of ClusterGroupUpgrade
+type ClusterGroupUpgradeStatus struct {
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Placement Bindings"
+	PlacementBindings []string `json:"placementBindings,omitempty"`
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Placement Rules"
+	PlacementRules []string `json:"placementRules,omitempty"`
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Copied Policies"
+	CopiedPolicies []string `json:"copiedPolicies,omitempty"`
+	// Conditions of the ClusterGroupUpgrade: ClustersSelected, Validated, PrecachingSucceeded, BackupSucceeded,
+	// Progressing, Succeeded and Failed. The Ready condition is deprecated and kept for compatibility.
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Conditions"
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Remediation Plan"
+	RemediationPlan [][]string `json:"remediationPlan,omitempty"`
+	// ManagedPolicies holds the namespace and the relevant content of the managed policies found on the hub
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Managed Policies"
+	ManagedPolicies []ManagedPolicyStatus `json:"managedPolicies,omitempty"`
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Safe Resource Names"
+	SafeResourceNames []SafeResourceName `json:"safeResourceNames,omitempty"`
+	// Contains the managed policies (and the namespaces) that have NonCompliant clusters
+	// that require updating.
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Managed Policies For Upgrade"
+	ManagedPoliciesForUpgrade []ManagedPolicyForUpgrade `json:"managedPoliciesForUpgrade,omitempty"`
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Managed Policies Compliant Before Upgrade"
+	ManagedPoliciesCompliantBeforeUpgrade []string `json:"managedPoliciesCompliantBeforeUpgrade,omitempty"`
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Status"
+	Status UpgradeStatus `json:"status,omitempty"`
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Precaching"
+	Precaching *PrecachingStatus `json:"precaching,omitempty"`
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Backup"
+	Backup *BackupStatus `json:"backup,omitempty"`
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Computed Maximum Concurrency"
+	ComputedMaxConcurrency int `json:"computedMaxConcurrency,omitempty"`
+	// ComputedTimeout is the timeout of the upgrade in minutes: the timeout of the spec, or the defaultTimeout of
+	// the operator settings of the namespace when the ClusterGroupUpgrade was first reconciled
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Computed Timeout"
+	ComputedTimeout int `json:"computedTimeout,omitempty"`
+	// ClusterStates is set when the per-cluster state is stored in ConfigMaps instead of the status
+	// +operator-sdk:csv:customresourcedefinitions:type=status,displayName="Cluster States"
+	ClusterStates *ClusterStatesStatus `json:"clusterStates,omitempty"`
+}
+
+// +genclient
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:path=clustergroupupgrades,shortName=cgu
+//+kubebuilder:printcolumn:name="State",type="string",JSONPath=`.status.conditions[?(@.type=="Progressing")].reason`
+//+kubebuilder:printcolumn:name="Succeeded",type="string",JSONPath=`.status.conditions[?(@.type=="Succeeded")].status`
+//+kubebuilder:printcolumn:name="Failed",type="string",JSONPath=`.status.conditions[?(@.type=="Failed")].status`
+//+kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// ClusterGroupUpgrade is the Schema for the ClusterGroupUpgrades API
+// +operator-sdk:csv:customresourcedefinitions:displayName="Cluster Group Upgrade",resources={{Namespace, v1},{Deployment,apps/v1}}
+type ClusterGroupUpgrade struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterGroupUpgradeSpec   `json:"spec,omitempty"`
+	Status ClusterGroupUpgradeStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// ClusterGroupUpgradeList contains a list of ClusterGroupUpgrade
+type ClusterGroupUpgradeList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterGroupUpgrade `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&ClusterGroupUpgrade{}, &ClusterGroupUpgradeList{})
+}
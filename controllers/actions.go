@@ -36,6 +36,22 @@ func (r *ClusterGroupUpgradeReconciler) takeActionsBeforeEnable(
 	return nil
 }
 
+// takeActionsAfterClusterCompletion takes the required actions on a cluster once it completes its remediation
+// returns: error/nil
+func (r *ClusterGroupUpgradeReconciler) takeActionsAfterClusterCompletion(
+	ctx context.Context, clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade, cluster string) error {
+
+	actionsAfterClusterCompletion := clusterGroupUpgrade.Spec.Actions.AfterClusterCompletion
+	if actionsAfterClusterCompletion.AddClusterLabels == nil && actionsAfterClusterCompletion.DeleteClusterLabels == nil {
+		return nil
+	}
+	labels := map[string]map[string]string{
+		"add":    actionsAfterClusterCompletion.AddClusterLabels,
+		"delete": actionsAfterClusterCompletion.DeleteClusterLabels,
+	}
+	return r.manageClusterLabels(ctx, []string{cluster}, labels)
+}
+
 // takeActionsAfterCompletion takes the required actions after upgrade is completed
 // returns: error/nil
 func (r *ClusterGroupUpgradeReconciler) takeActionsAfterCompletion(
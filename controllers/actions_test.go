@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/go-logr/logr"
+	ranv1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/api/v1alpha1"
 	"github.com/stretchr/testify/assert"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -225,3 +226,42 @@ func TestActions_deleteClusterLabels(t *testing.T) {
 		})
 	}
 }
+
+func TestActions_takeActionsAfterClusterCompletion(t *testing.T) {
+	r := &ClusterGroupUpgradeReconciler{
+		Client: fake.NewClientBuilder().WithScheme(scheme.Scheme).WithObjects().Build(),
+		Log:    logr.Discard(),
+		Scheme: scheme.Scheme,
+	}
+	for _, name := range []string{"spoke1", "spoke2"} {
+		cluster := &clusterv1.ManagedCluster{
+			ObjectMeta: v1.ObjectMeta{Name: name, Labels: map[string]string{"ztp-running": ""}},
+		}
+		if err := r.Create(context.TODO(), cluster); err != nil {
+			t.Errorf("Unexpected error when creating cluster: %v", err)
+		}
+	}
+	cgu := &ranv1alpha1.ClusterGroupUpgrade{
+		Spec: ranv1alpha1.ClusterGroupUpgradeSpec{
+			Clusters: []string{"spoke1", "spoke2"},
+			Actions: ranv1alpha1.Actions{
+				AfterClusterCompletion: ranv1alpha1.AfterClusterCompletion{
+					AddClusterLabels:    map[string]string{"ztp-done": ""},
+					DeleteClusterLabels: map[string]string{"ztp-running": ""},
+				},
+			},
+		},
+	}
+
+	// Only the completed cluster is labeled
+	assert.NoError(t, r.takeActionsAfterClusterCompletion(context.TODO(), cgu, "spoke1"))
+	cluster := &clusterv1.ManagedCluster{}
+	assert.NoError(t, r.Get(context.TODO(), types.NamespacedName{Name: "spoke1"}, cluster))
+	assert.Equal(t, map[string]string{"ztp-done": ""}, cluster.Labels)
+	cluster = &clusterv1.ManagedCluster{}
+	assert.NoError(t, r.Get(context.TODO(), types.NamespacedName{Name: "spoke2"}, cluster))
+	assert.Equal(t, map[string]string{"ztp-running": ""}, cluster.Labels)
+
+	// A deleted cluster is ignored
+	assert.NoError(t, r.takeActionsAfterClusterCompletion(context.TODO(), cgu, "spoke3"))
+}
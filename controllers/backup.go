@@ -66,6 +66,13 @@ func (r *ClusterGroupUpgradeReconciler) triggerBackup(ctx context.Context, clust
 		clusterGroupUpgrade.Status.Backup.Clusters = clusters
 	}
 
+	// Backup jobs only start while there are fleet-wide backup slots available.
+	availableSlots, err := r.getAvailableCapacity(ctx, clusterGroupUpgrade, capacityBackup)
+	if err != nil {
+		return err
+	}
+	waitingForCapacity := false
+
 	for _, cluster := range clusters {
 		var (
 			currentState, nextState string
@@ -81,6 +88,12 @@ func (r *ClusterGroupUpgradeReconciler) triggerBackup(ctx context.Context, clust
 		switch currentState {
 		// Initial State
 		case BackupStatePreparingToStart:
+			if availableSlots <= 0 {
+				nextState = currentState
+				waitingForCapacity = true
+				break
+			}
+			availableSlots--
 			nextState, err = r.backupPreparing(ctx, clusterGroupUpgrade, cluster)
 			if err != nil {
 				return err
@@ -108,6 +121,7 @@ func (r *ClusterGroupUpgradeReconciler) triggerBackup(ctx context.Context, clust
 		clusterStates[cluster] = nextState
 	}
 	clusterGroupUpgrade.Status.Backup.Status = clusterStates
+	r.setCapacityCondition(clusterGroupUpgrade, capacityBackup, waitingForCapacity)
 	r.checkAllBackupDone(clusterGroupUpgrade)
 	return nil
 }
@@ -127,7 +141,7 @@ func (r *ClusterGroupUpgradeReconciler) backupPreparing(ctx context.Context, clu
 		return currentState, err
 	}
 
-	spec, err := r.getBackupJobTemplateData(clusterGroupUpgrade, cluster)
+	spec, err := r.getBackupJobTemplateData(ctx, clusterGroupUpgrade, cluster)
 	if err != nil {
 		return currentState, err
 	}
@@ -155,7 +169,7 @@ func (r *ClusterGroupUpgradeReconciler) backupStarting(ctx context.Context, clus
 		return currentState, err
 	}
 	r.Log.Info("[starting]", "starting started condition: ", condition)
-	spec, err := r.getBackupJobTemplateData(clusterGroupUpgrade, cluster)
+	spec, err := r.getBackupJobTemplateData(ctx, clusterGroupUpgrade, cluster)
 	if err != nil {
 		return currentState, err
 	}
@@ -163,7 +177,8 @@ func (r *ClusterGroupUpgradeReconciler) backupStarting(ctx context.Context, clus
 	r.Log.Info("[starting]", "conditions: ", condition)
 	switch condition {
 	case DependenciesNotPresent:
-		err := r.createResourcesFromTemplates(ctx, spec, backupDependenciesCreateTemplates)
+		err := r.createResourcesFromTemplates(ctx, spec, withClusterRoleTemplate(
+			spec, backupClusterRoleCreateTemplate, backupDependenciesCreateTemplates))
 		if err != nil {
 			return currentState, err
 		}
@@ -0,0 +1,613 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	ranv1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/api/v1alpha1"
+	"github.com/openshift-kni/cluster-group-upgrades-operator/controllers/utils"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// Kinds of work that can be limited fleet-wide
+const (
+	capacityRemediation = "remediation"
+	capacityPrecaching  = "precaching"
+	capacityBackup      = "backup"
+)
+
+// capacityLimit returns the fleet-wide limit configured for the given kind of work.
+// A value of 0 or lower means no limit.
+// The limits of the operator configuration take precedence over the operator flags.
+func (r *ClusterGroupUpgradeReconciler) capacityLimit(kind string) int {
+	r.operatorConfigLock.RLock()
+	concurrency := r.operatorConfig.Concurrency
+	r.operatorConfigLock.RUnlock()
+	if concurrency != nil {
+		switch {
+		case kind == capacityRemediation && concurrency.MaxConcurrentRemediations != nil:
+			return *concurrency.MaxConcurrentRemediations
+		case kind == capacityPrecaching && concurrency.MaxConcurrentPrecaching != nil:
+			return *concurrency.MaxConcurrentPrecaching
+		case kind == capacityBackup && concurrency.MaxConcurrentBackups != nil:
+			return *concurrency.MaxConcurrentBackups
+		}
+	}
+
+	switch kind {
+	case capacityRemediation:
+		return r.MaxConcurrentRemediations
+	case capacityPrecaching:
+		return r.MaxConcurrentPrecaching
+	case capacityBackup:
+		return r.MaxConcurrentBackups
+	}
+	return 0
+}
+
+// Claims expire in case their reconcile never ends, for example when the operator is restarted
+const (
+	// capacityClaimTTL bounds how long the slots handed out to a reconcile are counted as used
+	capacityClaimTTL = 5 * time.Minute
+	// releasedClaimTTL bounds how long the version of a released claim is kept for the other reconciles
+	// to read the CGU from the API server until their cache has the status write recording its slots
+	releasedClaimTTL = time.Minute
+)
+
+// capacityClaim holds the slots handed out to a CGU for a kind of work, counted as used by the other CGUs
+// until the status write recording the clusters started with them
+type capacityClaim struct {
+	// Slots is the number of slots handed out, 0 once released
+	Slots int `json:"slots,omitempty"`
+	// Version is the resourceVersion of the CGU once the claim is released, empty while pending
+	Version string `json:"version,omitempty"`
+	// Time is when the claim was made or released
+	Time metav1.Time `json:"time"`
+	// Unused is true if some of the slots were not used when the claim was released
+	Unused bool `json:"unused,omitempty"`
+}
+
+// pending returns true if the slots of a claim are not recorded in the status of its CGU yet
+func (c capacityClaim) pending() bool {
+	return c.Version == ""
+}
+
+// expired returns true if a claim is too old to be taken into account
+func (c capacityClaim) expired(now time.Time) bool {
+	if c.pending() {
+		return now.Sub(c.Time.Time) > capacityClaimTTL
+	}
+	return now.Sub(c.Time.Time) > releasedClaimTTL
+}
+
+// capacityClaimKey returns the key of the claim of a CGU for a kind of work
+func capacityClaimKey(kind, namespace, name string) string {
+	return kind + "_" + namespace + "_" + name
+}
+
+// capacityLedger holds the capacity claims of the CGUs, by capacityClaimKey
+type capacityLedger interface {
+	// update calls change with the current claims, and saves them if it returns true. The reconciles
+	// updating the ledger are serialized, for the time of the call.
+	update(ctx context.Context, change func(claims map[string]capacityClaim) (bool, error)) error
+}
+
+// memoryCapacityLedger keeps the claims in memory, for a single replica
+type memoryCapacityLedger struct {
+	lock   sync.Mutex
+	claims map[string]capacityClaim
+}
+
+func (l *memoryCapacityLedger) update(
+	ctx context.Context, change func(claims map[string]capacityClaim) (bool, error)) error {
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if l.claims == nil {
+		l.claims = make(map[string]capacityClaim)
+	}
+	// The changes are made on a copy, so that a failed change leaves the claims as they were
+	claims := make(map[string]capacityClaim, len(l.claims))
+	for key, claim := range l.claims {
+		claims[key] = claim
+	}
+	changed, err := change(claims)
+	if err != nil || !changed {
+		return err
+	}
+	l.claims = claims
+	return nil
+}
+
+/* configMapCapacityLedger keeps the claims in a ConfigMap shared by the replicas of a sharded operator, so that
+   the slots of the whole fleet are handed out from one place. The ConfigMap is read from the API server and
+   written with its resourceVersion, the update is made again if another replica wrote it in the meantime.
+*/
+type configMapCapacityLedger struct {
+	client client.Client
+	reader client.Reader
+	key    types.NamespacedName
+	// lock serializes the updates of this replica, which would otherwise only conflict
+	lock sync.Mutex
+}
+
+func (l *configMapCapacityLedger) update(
+	ctx context.Context, change func(claims map[string]capacityClaim) (bool, error)) error {
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		configMap := &corev1.ConfigMap{}
+		err := l.reader.Get(ctx, l.key, configMap)
+		exists := err == nil
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+		claims := make(map[string]capacityClaim, len(configMap.Data))
+		for key, value := range configMap.Data {
+			claim := capacityClaim{}
+			if err := json.Unmarshal([]byte(value), &claim); err != nil {
+				// An invalid claim would otherwise block the ledger, it is dropped with the next change
+				continue
+			}
+			claims[key] = claim
+		}
+		changed, err := change(claims)
+		if err != nil || !changed {
+			return err
+		}
+
+		configMap.Data = make(map[string]string, len(claims))
+		for key, claim := range claims {
+			value, err := json.Marshal(claim)
+			if err != nil {
+				return err
+			}
+			configMap.Data[key] = string(value)
+		}
+		if exists {
+			return l.client.Update(ctx, configMap)
+		}
+		configMap.Name, configMap.Namespace = l.key.Name, l.key.Namespace
+		err = l.client.Create(ctx, configMap)
+		if errors.IsAlreadyExists(err) {
+			// Created by another replica, update it instead
+			return errors.NewConflict(corev1.Resource("configmaps"), l.key.Name, err)
+		}
+		return err
+	})
+}
+
+// getCapacityLedger returns the capacity ledger of the reconciler, shared by the replicas when sharded
+func (r *ClusterGroupUpgradeReconciler) getCapacityLedger() capacityLedger {
+	r.capacityLedgerOnce.Do(func() {
+		if r.Shards == nil {
+			r.capacityLedger = &memoryCapacityLedger{}
+			return
+		}
+		r.capacityLedger = &configMapCapacityLedger{
+			client: r.Client,
+			reader: r.apiReader(),
+			key:    r.Shards.capacityLedgerKey(),
+		}
+	})
+	return r.capacityLedger
+}
+
+// pruneCapacityClaims removes the expired claims
+// returns: bool true if a claim was removed
+func pruneCapacityClaims(claims map[string]capacityClaim) bool {
+	now := time.Now()
+	pruned := false
+	for key, claim := range claims {
+		if claim.expired(now) {
+			delete(claims, key)
+			pruned = true
+		}
+	}
+	return pruned
+}
+
+/* getAvailableCapacity computes how many more clusters of the given CGU may start the given kind of work without
+   exceeding the fleet-wide limit, and claims these slots for the CGU. Slots are handed out in queue order: while
+   a CGU with a higher priority is waiting for the same kind of work, no slots are returned.
+   The claimed slots are counted as used by the other CGUs until the status recording the clusters started with
+   them is written, or the reconcile ends, see releaseCapacity. The ledger is only locked while the slots are
+   counted and claimed.
+
+   returns: int      the number of clusters that may start; math.MaxInt32 if no limit is configured
+            error/nil
+*/
+func (r *ClusterGroupUpgradeReconciler) getAvailableCapacity(
+	ctx context.Context, clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade, kind string) (int, error) {
+
+	limit := r.capacityLimit(kind)
+	if limit <= 0 {
+		return math.MaxInt32, nil
+	}
+	cache := getReconcileCache(ctx)
+	key := capacityClaimKey(kind, clusterGroupUpgrade.Namespace, clusterGroupUpgrade.Name)
+
+	available := 0
+	err := r.getCapacityLedger().update(ctx, func(claims map[string]capacityClaim) (bool, error) {
+		changed := pruneCapacityClaims(claims)
+		used, queued, err := r.countCapacityUsage(ctx, clusterGroupUpgrade, kind, claims)
+		if err != nil {
+			return false, err
+		}
+		r.Log.Info("[getAvailableCapacity]", "kind", kind, "limit", limit, "used", used)
+		available = 0
+		if !queued && used < limit {
+			available = limit - used
+		}
+		// Slots are only claimed during a reconcile, which releases them when it ends
+		previous, claimed := claims[key]
+		if cache == nil || (available == 0 && !(claimed && previous.pending())) {
+			return changed, nil
+		}
+		claims[key] = capacityClaim{Slots: available, Time: metav1.Now()}
+		return true, nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	if cache != nil {
+		cache.capacityClaims[kind] = clustersUsingCapacity(clusterGroupUpgrade, kind)
+	}
+	return available, nil
+}
+
+/* countCapacityUsage counts the slots used for the given kind of work by the CGU and the other CGUs of all the
+   shards, with the pending claims of the other CGUs. A CGU whose last claim was released with a status write not
+   in the cache yet is read from the API server.
+
+   returns: int      the number of slots used
+            bool     true if a CGU with a higher priority is waiting for the same kind of work
+            error/nil
+*/
+func (r *ClusterGroupUpgradeReconciler) countCapacityUsage(ctx context.Context,
+	clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade, kind string, claims map[string]capacityClaim) (
+	int, bool, error) {
+
+	cguList := &ranv1alpha1.ClusterGroupUpgradeList{}
+	if err := r.List(ctx, cguList); err != nil {
+		return 0, false, err
+	}
+
+	used := clustersUsingCapacity(clusterGroupUpgrade, kind)
+	for i := range cguList.Items {
+		cgu := &cguList.Items[i]
+		if cgu.Namespace == clusterGroupUpgrade.Namespace && cgu.Name == clusterGroupUpgrade.Name {
+			continue
+		}
+		claim, claimed := claims[capacityClaimKey(kind, cgu.Namespace, cgu.Name)]
+		if claimed && !claim.pending() && claim.Version != cgu.ResourceVersion {
+			key := types.NamespacedName{Name: cgu.Name, Namespace: cgu.Namespace}
+			if err := client.IgnoreNotFound(r.apiReader().Get(ctx, key, cgu)); err != nil {
+				return 0, false, err
+			}
+		} else if err := r.refreshStaleCgu(ctx, cgu); err != nil {
+			return 0, false, err
+		}
+		if isWaitingForCapacity(cgu, kind) && hasHigherPriority(cgu, clusterGroupUpgrade) {
+			r.Log.Info("[countCapacityUsage] Queued behind a higher priority CGU", "kind", kind,
+				"waitingFor", cgu.Namespace+"/"+cgu.Name)
+			return used, true, nil
+		}
+		used += clustersUsingCapacity(cgu, kind)
+		if claimed && claim.pending() {
+			used += claim.Slots
+		}
+	}
+	return used, false, nil
+}
+
+/* releaseCapacity releases the slots claimed by the reconcile for the CGU, once its status recording the clusters
+   started with them is written or the reconcile ends. The claims keep the resourceVersion of the CGU for a while,
+   for the other reconciles to read it from the API server until their cache has it. The CGUs waiting for capacity
+   are reconciled again if some of the slots claimed were not used.
+*/
+func (r *ClusterGroupUpgradeReconciler) releaseCapacity(
+	ctx context.Context, clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade) {
+
+	cache := getReconcileCache(ctx)
+	if cache == nil || len(cache.capacityClaims) == 0 {
+		return
+	}
+	var unused []string
+	err := r.getCapacityLedger().update(ctx, func(claims map[string]capacityClaim) (bool, error) {
+		unused = nil
+		pruneCapacityClaims(claims)
+		for kind, usedBefore := range cache.capacityClaims {
+			key := capacityClaimKey(kind, clusterGroupUpgrade.Namespace, clusterGroupUpgrade.Name)
+			claim, ok := claims[key]
+			if !ok || !claim.pending() {
+				continue
+			}
+			released := capacityClaim{Version: clusterGroupUpgrade.ResourceVersion, Time: metav1.Now()}
+			if clustersUsingCapacity(clusterGroupUpgrade, kind)-usedBefore < claim.Slots {
+				unused = append(unused, kind)
+				released.Unused = true
+			}
+			claims[key] = released
+		}
+		return true, nil
+	})
+	if err != nil {
+		// The claims expire on their own
+		r.Log.Error(err, "[releaseCapacity] Failed to release the capacity claims",
+			"name", clusterGroupUpgrade.Namespace+"/"+clusterGroupUpgrade.Name)
+		return
+	}
+	cache.capacityClaims = make(map[string]int)
+	if len(unused) != 0 {
+		r.notifyCapacityReleased(ctx, clusterGroupUpgrade, unused...)
+	}
+}
+
+// notifyCapacityReleased reconciles again the CGUs waiting for the kinds of work some slots were released for.
+// The other replicas of a sharded operator are notified through the ledger ConfigMap, see capacityLedgerPredicate.
+func (r *ClusterGroupUpgradeReconciler) notifyCapacityReleased(
+	ctx context.Context, releasedBy *ranv1alpha1.ClusterGroupUpgrade, kinds ...string) {
+
+	if r.capacityEvents == nil {
+		return
+	}
+	waiting := r.listCgusWaitingForCapacity(ctx, releasedBy, kinds...)
+	if len(waiting) == 0 {
+		return
+	}
+	go func() {
+		for _, cgu := range waiting {
+			r.capacityEvents <- event.GenericEvent{Object: cgu}
+		}
+	}()
+}
+
+// listCgusWaitingForCapacity lists the CGUs other than the given one waiting for one of the kinds of work
+// returns: []client.Object
+func (r *ClusterGroupUpgradeReconciler) listCgusWaitingForCapacity(
+	ctx context.Context, except *ranv1alpha1.ClusterGroupUpgrade, kinds ...string) []client.Object {
+
+	cguList := &ranv1alpha1.ClusterGroupUpgradeList{}
+	if err := r.List(ctx, cguList); err != nil {
+		r.Log.Error(err, "[listCgusWaitingForCapacity] Failed to list ClusterGroupUpgrades")
+		return nil
+	}
+	var waiting []client.Object
+	for i := range cguList.Items {
+		cgu := &cguList.Items[i]
+		if cgu.Namespace == except.Namespace && cgu.Name == except.Name {
+			continue
+		}
+		for _, kind := range kinds {
+			if isWaitingForCapacity(cgu, kind) {
+				waiting = append(waiting, cgu)
+				break
+			}
+		}
+	}
+	return waiting
+}
+
+// clustersUsingCapacity returns the number of clusters of a CGU currently holding a slot for the given kind of work
+func clustersUsingCapacity(clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade, kind string) int {
+	count := 0
+	switch kind {
+	case capacityRemediation:
+		readyCondition := meta.FindStatusCondition(clusterGroupUpgrade.Status.Conditions, "Ready")
+		if readyCondition == nil || readyCondition.Reason != "UpgradeNotCompleted" ||
+			clusterGroupUpgrade.Status.Status.CurrentBatchStartedAt.IsZero() {
+			return 0
+		}
+		for _, progress := range clusterGroupUpgrade.Status.Status.CurrentBatchRemediationProgress {
+			if progress != nil && progress.State == ranv1alpha1.InProgress {
+				count++
+			}
+		}
+	case capacityPrecaching:
+		counts := getClusterStateCounts(clusterGroupUpgrade, kind)
+		count = counts[PrecacheStatePreparingToStart] + counts[PrecacheStateStarting] + counts[PrecacheStateActive]
+	case capacityBackup:
+		counts := getClusterStateCounts(clusterGroupUpgrade, kind)
+		count = counts[BackupStateStarting] + counts[BackupStateActive]
+	}
+	return count
+}
+
+// isWaitingForCapacity returns true if a CGU has clusters queued for the given kind of work
+func isWaitingForCapacity(clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade, kind string) bool {
+	switch kind {
+	case capacityRemediation:
+		readyCondition := meta.FindStatusCondition(clusterGroupUpgrade.Status.Conditions, "Ready")
+		if readyCondition == nil || readyCondition.Reason != "UpgradeNotCompleted" {
+			return false
+		}
+		if clusterGroupUpgrade.Status.Status.CurrentBatchStartedAt.IsZero() {
+			return true
+		}
+		for _, progress := range clusterGroupUpgrade.Status.Status.CurrentBatchRemediationProgress {
+			// Clusters waiting for another CGU to release them are not waiting for capacity
+			if progress != nil && progress.State == ranv1alpha1.NotStarted && progress.LockedBy == "" {
+				return true
+			}
+		}
+	case capacityPrecaching:
+		counts := getClusterStateCounts(clusterGroupUpgrade, kind)
+		if counts[PrecacheStateNotStarted]+counts[PrecacheStateQueued] == 0 {
+			return false
+		}
+		// Clusters queued behind the limit of the CGU itself are not waiting for fleet-wide capacity
+		maxConcurrency := getPrecachingMaxConcurrency(clusterGroupUpgrade)
+		return maxConcurrency <= 0 || clustersUsingCapacity(clusterGroupUpgrade, kind) < maxConcurrency
+	case capacityBackup:
+		return getClusterStateCounts(clusterGroupUpgrade, kind)[BackupStatePreparingToStart] > 0
+	}
+	return false
+}
+
+// hasHigherPriority returns true if the first CGU is ahead of the second one in the capacity queue.
+// Higher spec.priority goes first, then the older CGU, then the namespace/name in alphabetical order.
+func hasHigherPriority(first, second *ranv1alpha1.ClusterGroupUpgrade) bool {
+	if first.Spec.Priority != second.Spec.Priority {
+		return first.Spec.Priority > second.Spec.Priority
+	}
+	if !first.CreationTimestamp.Equal(&second.CreationTimestamp) {
+		return first.CreationTimestamp.Before(&second.CreationTimestamp)
+	}
+	return first.Namespace+"/"+first.Name < second.Namespace+"/"+second.Name
+}
+
+// capacityConditions are the conditions reporting the CGUs waiting for a fleet-wide slot, one per kind of work
+var capacityConditions = map[string]string{
+	capacityRemediation: utils.RemediationCapacityAvailableCondition,
+	capacityPrecaching:  utils.PrecachingCapacityAvailableCondition,
+	capacityBackup:      utils.BackupCapacityAvailableCondition,
+}
+
+// setCapacityCondition reports whether the CGU is waiting for a fleet-wide slot for the given kind of work
+func (r *ClusterGroupUpgradeReconciler) setCapacityCondition(
+	clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade, kind string, waiting bool) {
+
+	if !waiting {
+		meta.RemoveStatusCondition(&clusterGroupUpgrade.Status.Conditions, capacityConditions[kind])
+		return
+	}
+	meta.SetStatusCondition(&clusterGroupUpgrade.Status.Conditions, metav1.Condition{
+		Type:    capacityConditions[kind],
+		Status:  metav1.ConditionFalse,
+		Reason:  utils.WaitingForCapacity,
+		Message: fmt.Sprintf("Waiting for a fleet-wide %s slot (limit %d)", kind, r.capacityLimit(kind)),
+	})
+}
+
+// capacityReleasePredicate passes the CGU events that can free fleet-wide slots
+var capacityReleasePredicate = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldCgu, okOld := e.ObjectOld.(*ranv1alpha1.ClusterGroupUpgrade)
+		newCgu, okNew := e.ObjectNew.(*ranv1alpha1.ClusterGroupUpgrade)
+		if !okOld || !okNew {
+			return false
+		}
+		return len(releasedCapacity(oldCgu, newCgu)) != 0
+	},
+	CreateFunc:  func(ce event.CreateEvent) bool { return false },
+	GenericFunc: func(ge event.GenericEvent) bool { return false },
+	DeleteFunc:  func(de event.DeleteEvent) bool { return true },
+}
+
+// releasedCapacity returns the kinds of work a CGU uses fewer slots for than before
+func releasedCapacity(before, after *ranv1alpha1.ClusterGroupUpgrade) []string {
+	var kinds []string
+	for _, kind := range []string{capacityRemediation, capacityPrecaching, capacityBackup} {
+		if clustersUsingCapacity(after, kind) < clustersUsingCapacity(before, kind) {
+			kinds = append(kinds, kind)
+		}
+	}
+	return kinds
+}
+
+// mapCapacityReleaseToCgus maps a CGU that freed fleet-wide slots to the CGUs waiting for capacity
+// returns: []reconcile.Request the waiting CGUs
+func (r *ClusterGroupUpgradeReconciler) mapCapacityReleaseToCgus(obj client.Object) []reconcile.Request {
+	releasedBy, ok := obj.(*ranv1alpha1.ClusterGroupUpgrade)
+	if !ok {
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, cgu := range r.listCgusWaitingForCapacity(context.TODO(), releasedBy,
+		capacityRemediation, capacityPrecaching, capacityBackup) {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Namespace: cgu.GetNamespace(), Name: cgu.GetName()}})
+	}
+	return requests
+}
+
+// capacityLedgerPredicate passes the updates of the capacity ledger ConfigMap releasing unused slots, for the
+// replicas to reconcile their CGUs waiting for capacity when the slots were claimed by another replica
+func (r *ClusterGroupUpgradeReconciler) capacityLedgerPredicate() predicate.Funcs {
+	key := r.Shards.capacityLedgerKey()
+	isLedger := func(obj client.Object) bool {
+		return obj.GetNamespace() == key.Namespace && obj.GetName() == key.Name
+	}
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldConfigMap, okOld := e.ObjectOld.(*corev1.ConfigMap)
+			newConfigMap, okNew := e.ObjectNew.(*corev1.ConfigMap)
+			if !okOld || !okNew || !isLedger(newConfigMap) {
+				return false
+			}
+			return len(releasedClaims(oldConfigMap.Data, newConfigMap.Data)) != 0
+		},
+		CreateFunc:  func(ce event.CreateEvent) bool { return false },
+		GenericFunc: func(ge event.GenericEvent) bool { return false },
+		DeleteFunc:  func(de event.DeleteEvent) bool { return isLedger(de.Object) },
+	}
+}
+
+// releasedClaims returns the kinds of work pending claims were released with unused slots for between two
+// versions of the ledger data
+func releasedClaims(before, after map[string]string) []string {
+	var kinds []string
+	released := make(map[string]bool)
+	for key, value := range after {
+		claim := capacityClaim{}
+		if err := json.Unmarshal([]byte(value), &claim); err != nil || claim.pending() || !claim.Unused {
+			continue
+		}
+		previous := capacityClaim{}
+		if err := json.Unmarshal([]byte(before[key]), &previous); err != nil || !previous.pending() {
+			continue
+		}
+		kind := strings.SplitN(key, "_", 2)[0]
+		if !released[kind] {
+			released[kind] = true
+			kinds = append(kinds, kind)
+		}
+	}
+	sort.Strings(kinds)
+	return kinds
+}
+
+// mapCapacityLedgerToCgus maps the capacity ledger ConfigMap to the CGUs waiting for the slots it released
+// returns: []reconcile.Request the waiting CGUs
+func (r *ClusterGroupUpgradeReconciler) mapCapacityLedgerToCgus(obj client.Object) []reconcile.Request {
+	var requests []reconcile.Request
+	for _, cgu := range r.listCgusWaitingForCapacity(context.TODO(), &ranv1alpha1.ClusterGroupUpgrade{},
+		capacityRemediation, capacityPrecaching, capacityBackup) {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Namespace: cgu.GetNamespace(), Name: cgu.GetName()}})
+	}
+	return requests
+}
@@ -0,0 +1,304 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	ranv1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/api/v1alpha1"
+	"github.com/openshift-kni/cluster-group-upgrades-operator/controllers/utils"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func newCapacityTestCgu(name string, priority int, created time.Time, progress map[string]string) *ranv1alpha1.ClusterGroupUpgrade {
+	cgu := &ranv1alpha1.ClusterGroupUpgrade{
+		ObjectMeta: v1.ObjectMeta{
+			Name:              name,
+			Namespace:         "default",
+			CreationTimestamp: v1.NewTime(created),
+		},
+		Spec: ranv1alpha1.ClusterGroupUpgradeSpec{
+			Priority: priority,
+		},
+		Status: ranv1alpha1.ClusterGroupUpgradeStatus{
+			Conditions: []v1.Condition{{Type: "Ready", Status: v1.ConditionFalse, Reason: "UpgradeNotCompleted"}},
+			Status: ranv1alpha1.UpgradeStatus{
+				CurrentBatch:                    1,
+				CurrentBatchStartedAt:           v1.NewTime(created),
+				CurrentBatchRemediationProgress: make(map[string]*ranv1alpha1.ClusterRemediationProgress),
+			},
+		},
+	}
+	for cluster, state := range progress {
+		cgu.Status.Status.CurrentBatchRemediationProgress[cluster] = &ranv1alpha1.ClusterRemediationProgress{State: state}
+	}
+	return cgu
+}
+
+func TestCapacity_getAvailableCapacity(t *testing.T) {
+	now := time.Now()
+	testcases := []struct {
+		name     string
+		limit    int
+		priority int
+		others   []client.Object
+		expected int
+	}{
+		{
+			name:     "no limit configured",
+			limit:    0,
+			others:   []client.Object{newCapacityTestCgu("other", 0, now, map[string]string{"spoke1": ranv1alpha1.InProgress})},
+			expected: math.MaxInt32,
+		},
+		{
+			name:  "slots used by other CGUs",
+			limit: 3,
+			others: []client.Object{newCapacityTestCgu("other", 0, now, map[string]string{
+				"spoke1": ranv1alpha1.InProgress, "spoke2": ranv1alpha1.Completed})},
+			expected: 2,
+		},
+		{
+			name:  "limit reached",
+			limit: 2,
+			others: []client.Object{newCapacityTestCgu("other", 0, now, map[string]string{
+				"spoke1": ranv1alpha1.InProgress, "spoke2": ranv1alpha1.InProgress})},
+			expected: 0,
+		},
+		{
+			name:  "queued behind a higher priority CGU",
+			limit: 5,
+			others: []client.Object{newCapacityTestCgu("other", 10, now, map[string]string{
+				"spoke1": ranv1alpha1.InProgress, "spoke2": ranv1alpha1.NotStarted})},
+			expected: 0,
+		},
+		{
+			name:     "ahead of a lower priority CGU",
+			limit:    5,
+			priority: 20,
+			others: []client.Object{newCapacityTestCgu("other", 10, now, map[string]string{
+				"spoke1": ranv1alpha1.InProgress, "spoke2": ranv1alpha1.NotStarted})},
+			expected: 4,
+		},
+		{
+			name:  "queued behind an older CGU with the same priority",
+			limit: 5,
+			others: []client.Object{newCapacityTestCgu("other", 0, now.Add(-time.Hour), map[string]string{
+				"spoke2": ranv1alpha1.NotStarted})},
+			expected: 0,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := getFakeClientFromObjects(tc.others...)
+			if err != nil {
+				t.Errorf("error in creating fake client")
+			}
+			r := &ClusterGroupUpgradeReconciler{
+				Client:                    c,
+				Log:                       logr.Discard(),
+				Scheme:                    testscheme,
+				MaxConcurrentRemediations: tc.limit,
+			}
+			cgu := newCapacityTestCgu("test", tc.priority, now, map[string]string{"spoke3": ranv1alpha1.NotStarted})
+			available, err := r.getAvailableCapacity(context.TODO(), cgu, capacityRemediation)
+			if err != nil {
+				t.Errorf("error getting available capacity: %v", err)
+			}
+			assert.Equal(t, tc.expected, available)
+		})
+	}
+}
+
+func TestCapacity_claims(t *testing.T) {
+	now := time.Now()
+	first := newCapacityTestCgu("first", 0, now, map[string]string{
+		"spoke1": ranv1alpha1.InProgress, "spoke2": ranv1alpha1.Completed})
+	second := newCapacityTestCgu("second", 0, now.Add(time.Hour), map[string]string{"spoke3": ranv1alpha1.NotStarted})
+	c, err := getFakeClientFromObjects(first, second)
+	if err != nil {
+		t.Errorf("error in creating fake client")
+	}
+	r := &ClusterGroupUpgradeReconciler{
+		Client:                    c,
+		Log:                       logr.Discard(),
+		Scheme:                    testscheme,
+		MaxConcurrentRemediations: 3,
+		capacityEvents:            make(chan event.GenericEvent, 1),
+	}
+
+	// The slots claimed by a reconcile are used until it records them, without blocking the other reconciles
+	firstCtx := withReconcileCache(context.TODO())
+	available, err := r.getAvailableCapacity(firstCtx, first, capacityRemediation)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, available)
+	secondCtx := withReconcileCache(context.TODO())
+	available, err = r.getAvailableCapacity(secondCtx, second, capacityRemediation)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, available)
+
+	// Once released, only the clusters started use slots and the CGUs waiting for the unused ones are notified
+	r.releaseCapacity(firstCtx, first)
+	select {
+	case notified := <-r.capacityEvents:
+		assert.Equal(t, "second", notified.Object.GetName())
+	case <-time.After(5 * time.Second):
+		t.Fatal("the CGUs waiting for capacity were not notified")
+	}
+	available, err = r.getAvailableCapacity(secondCtx, second, capacityRemediation)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, available)
+
+	// The claims of a reconcile that never ends expire
+	r.releaseCapacity(secondCtx, second)
+	assert.NoError(t, r.getCapacityLedger().update(context.TODO(), func(claims map[string]capacityClaim) (bool, error) {
+		claims[capacityClaimKey(capacityRemediation, "default", "first")] = capacityClaim{
+			Slots: 2, Time: v1.NewTime(now.Add(-capacityClaimTTL - time.Minute))}
+		return true, nil
+	}))
+	available, err = r.getAvailableCapacity(secondCtx, second, capacityRemediation)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, available)
+}
+
+func TestCapacity_setCapacityCondition(t *testing.T) {
+	r := &ClusterGroupUpgradeReconciler{Log: logr.Discard(), MaxConcurrentRemediations: 1, MaxConcurrentPrecaching: 1}
+	cgu := newCapacityTestCgu("test", 0, time.Now(), nil)
+
+	// Each kind of work only clears its own wait
+	r.setCapacityCondition(cgu, capacityPrecaching, true)
+	r.setCapacityCondition(cgu, capacityRemediation, false)
+	condition := meta.FindStatusCondition(cgu.Status.Conditions, utils.PrecachingCapacityAvailableCondition)
+	if assert.NotNil(t, condition) {
+		assert.Equal(t, utils.WaitingForCapacity, condition.Reason)
+	}
+	assert.Nil(t, meta.FindStatusCondition(cgu.Status.Conditions, utils.RemediationCapacityAvailableCondition))
+	r.setCapacityCondition(cgu, capacityPrecaching, false)
+	assert.Nil(t, meta.FindStatusCondition(cgu.Status.Conditions, utils.PrecachingCapacityAvailableCondition))
+}
+
+func TestCapacity_capacityRelease(t *testing.T) {
+	now := time.Now()
+	running := newCapacityTestCgu("running", 0, now, map[string]string{"spoke1": ranv1alpha1.InProgress})
+	waiting := newCapacityTestCgu("waiting", 0, now, map[string]string{"spoke2": ranv1alpha1.NotStarted})
+	idle := newCapacityTestCgu("idle", 0, now, map[string]string{"spoke3": ranv1alpha1.Completed})
+	c, err := getFakeClientFromObjects(running, waiting, idle)
+	if err != nil {
+		t.Errorf("error in creating fake client")
+	}
+	r := &ClusterGroupUpgradeReconciler{Client: c, Log: logr.Discard(), Scheme: testscheme}
+
+	completed := running.DeepCopy()
+	completed.Status.Status.CurrentBatchRemediationProgress["spoke1"].State = ranv1alpha1.Completed
+	assert.True(t, capacityReleasePredicate.Update(event.UpdateEvent{ObjectOld: running, ObjectNew: completed}))
+	assert.False(t, capacityReleasePredicate.Update(event.UpdateEvent{ObjectOld: completed, ObjectNew: running}))
+	assert.True(t, capacityReleasePredicate.Delete(event.DeleteEvent{Object: running}))
+
+	assert.Equal(t, []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: "default", Name: "waiting"}}},
+		r.mapCapacityReleaseToCgus(completed))
+}
+
+func TestCapacity_getAvailableCapacityStaleCache(t *testing.T) {
+	now := time.Now()
+	other := newCapacityTestCgu("other", 0, now, map[string]string{"spoke1": ranv1alpha1.NotStarted})
+	cachedClient, err := getFakeClientFromObjects(other.DeepCopy())
+	if err != nil {
+		t.Errorf("error in creating fake client")
+	}
+	apiClient, err := getFakeClientFromObjects(other)
+	if err != nil {
+		t.Errorf("error in creating fake client")
+	}
+	r := &ClusterGroupUpgradeReconciler{
+		Client:                    cachedClient,
+		APIReader:                 apiClient,
+		Log:                       logr.Discard(),
+		Scheme:                    testscheme,
+		MaxConcurrentRemediations: 3,
+	}
+
+	// The status write of the other CGU isn't in the cache yet
+	other.Status.Status.CurrentBatchRemediationProgress["spoke1"].State = ranv1alpha1.InProgress
+	other.Status.Status.CurrentBatchRemediationProgress["spoke2"] = &ranv1alpha1.ClusterRemediationProgress{
+		State: ranv1alpha1.InProgress}
+	assert.NoError(t, apiClient.Update(context.TODO(), other))
+	r.writtenVersions.Store(types.NamespacedName{Name: "other", Namespace: "default"}, other.ResourceVersion)
+
+	ctx := withReconcileCache(context.TODO())
+	cgu := newCapacityTestCgu("test", 0, now, map[string]string{"spoke3": ranv1alpha1.NotStarted})
+	available, err := r.getAvailableCapacity(ctx, cgu, capacityRemediation)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, available)
+	r.releaseCapacity(ctx, cgu)
+}
+
+func TestCapacity_sharedLedger(t *testing.T) {
+	// One CGU in each of two shards, with a limit lower than the number of shards
+	newShards := func(shard int) *ShardLeases {
+		shards := &ShardLeases{Namespace: "openshift-operators", Name: "shards", Count: 2, Log: logr.Discard()}
+		shards.acquired(context.TODO(), shard)
+		return shards
+	}
+	cguNames := make([]string, 2)
+	for i := 0; cguNames[0] == "" || cguNames[1] == ""; i++ {
+		name := fmt.Sprintf("cgu%d", i)
+		if shard := newShards(0).ShardOf("default", name); cguNames[shard] == "" {
+			cguNames[shard] = name
+		}
+	}
+	now := time.Now()
+	idle := newCapacityTestCgu(cguNames[0], 0, now, map[string]string{"spoke1": ranv1alpha1.NotStarted})
+	busy := newCapacityTestCgu(cguNames[1], 0, now.Add(-time.Hour), map[string]string{
+		"spoke2": ranv1alpha1.NotStarted, "spoke3": ranv1alpha1.NotStarted})
+	c, err := getFakeClientFromObjects(idle, busy)
+	if err != nil {
+		t.Errorf("error in creating fake client")
+	}
+	newReconciler := func(shard int) *ClusterGroupUpgradeReconciler {
+		return &ClusterGroupUpgradeReconciler{
+			Client:                    c,
+			Log:                       logr.Discard(),
+			Scheme:                    testscheme,
+			MaxConcurrentRemediations: 1,
+			Shards:                    newShards(shard),
+		}
+	}
+	first, second := newReconciler(0), newReconciler(1)
+
+	// The slot isn't split between the shards: the busy shard gets it
+	busyCtx := withReconcileCache(context.TODO())
+	available, err := second.getAvailableCapacity(busyCtx, busy, capacityRemediation)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, available)
+
+	// The other replica sees the claim and queues behind the older CGU of the other shard
+	available, err = first.getAvailableCapacity(withReconcileCache(context.TODO()), idle, capacityRemediation)
+	assert.NoError(t, err)
+	assert.Equal(t, 0, available)
+
+	// The claims are shared through the ledger ConfigMap
+	ledger := &corev1.ConfigMap{}
+	assert.NoError(t, c.Get(context.TODO(), second.Shards.capacityLedgerKey(), ledger))
+	assert.Contains(t, ledger.Data, capacityClaimKey(capacityRemediation, "default", cguNames[1]))
+
+	// Releasing unused slots notifies the other replicas through the ledger
+	previous := ledger.DeepCopy()
+	second.releaseCapacity(busyCtx, busy)
+	assert.NoError(t, c.Get(context.TODO(), second.Shards.capacityLedgerKey(), ledger))
+	predicate := first.capacityLedgerPredicate()
+	assert.True(t, predicate.Update(event.UpdateEvent{ObjectOld: previous, ObjectNew: ledger}))
+	assert.False(t, predicate.Update(event.UpdateEvent{ObjectOld: ledger, ObjectNew: ledger}))
+	assert.Equal(t, []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Namespace: "default", Name: cguNames[0]}},
+		{NamespacedName: types.NamespacedName{Namespace: "default", Name: cguNames[1]}},
+	}, first.mapCapacityLedgerToCgus(ledger))
+}
@@ -21,7 +21,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -30,18 +32,22 @@ import (
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
-	"k8s.io/client-go/util/retry"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
+	actionv1beta1 "github.com/open-cluster-management/multicloud-operators-foundation/pkg/apis/action/v1beta1"
 	viewv1beta1 "github.com/open-cluster-management/multicloud-operators-foundation/pkg/apis/view/v1beta1"
 	ranv1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/api/v1alpha1"
 	utils "github.com/openshift-kni/cluster-group-upgrades-operator/controllers/utils"
@@ -54,10 +60,37 @@ type ClusterGroupUpgradeReconciler struct {
 	Log      logr.Logger
 	Scheme   *runtime.Scheme
 	Recorder record.EventRecorder
+	// MaxConcurrentRemediations caps the number of clusters being remediated at once across all CGUs; 0 means no limit
+	MaxConcurrentRemediations int
+	// MaxConcurrentPrecaching caps the number of pre-caching jobs running at once across all CGUs; 0 means no limit
+	MaxConcurrentPrecaching int
+	// MaxConcurrentBackups caps the number of backup jobs running at once across all CGUs; 0 means no limit
+	MaxConcurrentBackups int
+
+	// APIReader reads from the API server instead of the cache, to recover from stale reads and
+	// to compute the status patches. The client is used when it is not set.
+	APIReader client.Reader
+
+	// operatorConfig caches the cluster-wide operator configuration, refreshed on every reconcile
+	operatorConfig     ranv1alpha1.ClusterGroupUpgradeOperatorConfigSpec
+	operatorConfigLock sync.RWMutex
+	// writtenVersions holds the resourceVersion of the last status write of each CGU, until the cache has it
+	writtenVersions sync.Map
+	// updateGraphCache holds the update graphs requested, by upstream and channel
+	updateGraphCache sync.Map
+
+	// MaxConcurrentReconciles is the number of CGUs reconciled at once. A CGU is never reconciled by two
+	// workers at the same time, the workqueue hands out each CGU to one worker at a time.
+	MaxConcurrentReconciles int
+	// Shards restricts this replica to the CGUs of the shards it owns; nil reconciles all the CGUs
+	Shards *ShardLeases
+	// capacityLedger holds the fleet-wide slots claimed by the reconciles, see getCapacityLedger
+	capacityLedger     capacityLedger
+	capacityLedgerOnce sync.Once
+	// capacityEvents reconciles the CGUs waiting for capacity when slots claimed by another CGU are not used
+	capacityEvents chan event.GenericEvent
 }
 
-const statusUpdateWaitInMilliSeconds = 100
-
 func doNotRequeue() ctrl.Result {
 	return ctrl.Result{}
 }
@@ -66,32 +99,50 @@ func requeueImmediately() ctrl.Result {
 	return ctrl.Result{Requeue: true}
 }
 
-func requeueWithShortInterval() ctrl.Result {
-	return requeueWithCustomInterval(30 * time.Second)
+func (r *ClusterGroupUpgradeReconciler) requeueWithShortInterval() ctrl.Result {
+	short, _, _ := r.getRequeueIntervals()
+	return requeueWithCustomInterval(short)
 }
 
-func requeueWithMediumInterval() ctrl.Result {
-	return requeueWithCustomInterval(1 * time.Minute)
+func (r *ClusterGroupUpgradeReconciler) requeueWithMediumInterval() ctrl.Result {
+	_, medium, _ := r.getRequeueIntervals()
+	return requeueWithCustomInterval(medium)
 }
 
-func requeueWithLongInterval() ctrl.Result {
-	return requeueWithCustomInterval(5 * time.Minute)
+func (r *ClusterGroupUpgradeReconciler) requeueWithLongInterval() ctrl.Result {
+	_, _, long := r.getRequeueIntervals()
+	return requeueWithCustomInterval(long)
 }
 
 func requeueWithCustomInterval(interval time.Duration) ctrl.Result {
 	return ctrl.Result{RequeueAfter: interval}
 }
 
+// requeueBefore requeues when the deadline is reached, or after the long interval if it comes first.
+// Progress is driven by the watches, this only catches the timeouts.
+func (r *ClusterGroupUpgradeReconciler) requeueBefore(deadline time.Time) ctrl.Result {
+	_, _, long := r.getRequeueIntervals()
+	untilDeadline := time.Until(deadline)
+	if untilDeadline <= 0 {
+		return requeueImmediately()
+	}
+	if untilDeadline < long {
+		return requeueWithCustomInterval(untilDeadline)
+	}
+	return requeueWithCustomInterval(long)
+}
+
 //+kubebuilder:rbac:groups=ran.openshift.io,resources=clustergroupupgrades,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=ran.openshift.io,resources=clustergroupupgrades/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=ran.openshift.io,resources=clustergroupupgrades/finalizers,verbs=update
+//+kubebuilder:rbac:groups=ran.openshift.io,resources=clustergroupupgradeoperatorconfigs,verbs=get;list;watch
 //+kubebuilder:rbac:groups=apps.open-cluster-management.io,resources=placementrules,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=policy.open-cluster-management.io,resources=placementbindings,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=policy.open-cluster-management.io,resources=policies,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=cluster.open-cluster-management.io,resources=managedclusters,verbs=get;list;watch;update;patch
 //+kubebuilder:rbac:groups=action.open-cluster-management.io,resources=managedclusteractions,verbs=create;update;delete;get;list;watch;patch
 //+kubebuilder:rbac:groups=view.open-cluster-management.io,resources=managedclusterviews,verbs=create;update;delete;get;list;watch;patch
-//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;delete
 //+kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 //+kubebuilder:rbac:groups=monitoring.coreos.com,resources=servicemonitors,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=monitoring.coreos.com,resources=prometheusrules,verbs=get;list;watch;create;update;patch;delete
@@ -108,8 +159,27 @@ func requeueWithCustomInterval(interval time.Duration) ctrl.Result {
 //nolint:gocyclo // TODO: simplify this function
 func (r *ClusterGroupUpgradeReconciler) Reconcile(ctx context.Context, req ctrl.Request) (nextReconcile ctrl.Result, err error) {
 
+	if !r.Shards.Owns(req.Namespace, req.Name) {
+		// Reconciled by the replica owning the shard of the CGU
+		return doNotRequeue(), nil
+	}
 	r.Log.Info("Start reconciling CGU", "name", req.NamespacedName)
+	ctx = withReconcileCache(ctx)
+	clusterGroupUpgrade := &ranv1alpha1.ClusterGroupUpgrade{}
+	var previousState string
 	defer func() {
+		r.releaseCapacity(ctx, clusterGroupUpgrade)
+		if err == nil && clusterGroupUpgrade.Name != "" && getCguState(clusterGroupUpgrade) != previousState {
+			if notifyErr := r.notifyStateChange(ctx, clusterGroupUpgrade, previousState); notifyErr != nil {
+				r.Log.Error(notifyErr, "Failed to send the state change notifications", "name", req.NamespacedName)
+			}
+		}
+		if errors.IsConflict(err) {
+			// The CGU was written by someone else while reconciling, start over from its latest version
+			r.Log.Info("CGU changed while reconciling", "name", req.NamespacedName)
+			err = nil
+			nextReconcile = requeueImmediately()
+		}
 		if nextReconcile.RequeueAfter > 0 {
 			r.Log.Info("Finish reconciling CGU", "name", req.NamespacedName, "requeueAfter", nextReconcile.RequeueAfter.Seconds())
 		} else {
@@ -118,12 +188,10 @@ func (r *ClusterGroupUpgradeReconciler) Reconcile(ctx context.Context, req ctrl.
 	}()
 
 	nextReconcile = doNotRequeue()
-	// Wait a bit so that API server/etcd syncs up and this reconsile has a better chance of getting the updated CGU and policies
-	time.Sleep(statusUpdateWaitInMilliSeconds * time.Millisecond)
-	clusterGroupUpgrade := &ranv1alpha1.ClusterGroupUpgrade{}
-	err = r.Get(ctx, req.NamespacedName, clusterGroupUpgrade)
+	err = r.getClusterGroupUpgrade(ctx, req.NamespacedName, clusterGroupUpgrade)
 	if err != nil {
 		if errors.IsNotFound(err) {
+			r.writtenVersions.Delete(req.NamespacedName)
 			err = nil
 			return
 		}
@@ -132,6 +200,12 @@ func (r *ClusterGroupUpgradeReconciler) Reconcile(ctx context.Context, req ctrl.
 	}
 
 	r.Log.Info("Loaded CGU", "name", req.NamespacedName, "version", clusterGroupUpgrade.GetResourceVersion())
+	previousState = getCguState(clusterGroupUpgrade)
+	err = r.refreshOperatorConfig(ctx)
+	if err != nil {
+		r.Log.Error(err, "Failed to get the operator configuration")
+		return
+	}
 	var reconcileTime int
 	reconcileTime, err = r.handleCguFinalizer(ctx, clusterGroupUpgrade)
 	if err != nil {
@@ -144,6 +218,12 @@ func (r *ClusterGroupUpgradeReconciler) Reconcile(ctx context.Context, req ctrl.
 		return
 	}
 
+	err = r.setComputedTimeout(ctx, clusterGroupUpgrade)
+	if err != nil {
+		r.Log.Error(err, "Failed to compute the timeout")
+		return
+	}
+
 	var reconcile bool
 	reconcile, err = r.validateCR(ctx, clusterGroupUpgrade)
 	if err != nil {
@@ -161,13 +241,24 @@ func (r *ClusterGroupUpgradeReconciler) Reconcile(ctx context.Context, req ctrl.
 	}
 
 	if clusterGroupUpgrade.Status.Backup != nil {
+		backupInProgress := false
 		for _, v := range clusterGroupUpgrade.Status.Backup.Status {
 			//nolint
-			if v == BackupStatePreparingToStart || v == BackupStateStarting || v == BackupStateActive {
+			if v == BackupStatePreparingToStart {
+				// Nothing has been created on the spoke yet, so no watch event will move this state forward
 				err = r.updateStatus(ctx, clusterGroupUpgrade)
-				nextReconcile = requeueWithShortInterval()
+				nextReconcile = r.requeueWithShortInterval()
 				return
 			}
+			if v == BackupStateStarting || v == BackupStateActive {
+				backupInProgress = true
+			}
+		}
+		if backupInProgress {
+			// The backup views and actions are watched, requeue only to catch the job timeouts
+			err = r.updateStatus(ctx, clusterGroupUpgrade)
+			nextReconcile = r.requeueWithLongInterval()
+			return
 		}
 	}
 
@@ -178,13 +269,24 @@ func (r *ClusterGroupUpgradeReconciler) Reconcile(ctx context.Context, req ctrl.
 			return
 		}
 		if clusterGroupUpgrade.Status.Precaching != nil {
+			precachingInProgress := false
 			for _, v := range clusterGroupUpgrade.Status.Precaching.Status {
 				//nolint
-				if v == PrecacheStatePreparingToStart || v == PrecacheStateStarting {
+				if v == PrecacheStateNotStarted || v == PrecacheStateQueued {
+					// Nothing has been created on the spoke yet, so no watch event will move these states forward
 					err = r.updateStatus(ctx, clusterGroupUpgrade)
-					nextReconcile = requeueWithShortInterval()
+					nextReconcile = r.requeueWithShortInterval()
 					return
 				}
+				if v == PrecacheStatePreparingToStart || v == PrecacheStateStarting {
+					precachingInProgress = true
+				}
+			}
+			if precachingInProgress {
+				// The pre-caching views and actions are watched, requeue only to catch the job timeouts
+				err = r.updateStatus(ctx, clusterGroupUpgrade)
+				nextReconcile = r.requeueWithLongInterval()
+				return
 			}
 
 		}
@@ -201,7 +303,11 @@ func (r *ClusterGroupUpgradeReconciler) Reconcile(ctx context.Context, req ctrl.
 			nextReconcile = requeueImmediately()
 		} else if readyCondition.Status == metav1.ConditionFalse {
 			if readyCondition.Reason == "PrecachingRequired" {
-				nextReconcile = requeueWithLongInterval()
+				if nextRetry := getNextPrecachingRetry(clusterGroupUpgrade); nextRetry != nil {
+					nextReconcile = r.requeueBefore(*nextRetry)
+				} else {
+					nextReconcile = r.requeueWithLongInterval()
+				}
 			} else if readyCondition.Reason == "UpgradeNotStarted" || readyCondition.Reason == utils.CannotStart {
 				// Before starting the upgrade check that all the managed policies exist.
 				var allManagedPoliciesExist bool
@@ -214,6 +320,8 @@ func (r *ClusterGroupUpgradeReconciler) Reconcile(ctx context.Context, req ctrl.
 				}
 
 				if allManagedPoliciesExist {
+					setCondition(clusterGroupUpgrade, ranv1alpha1.ConditionTypes.Validated, metav1.ConditionTrue,
+						ranv1alpha1.ConditionReasons.ValidationCompleted, "Completed validation")
 					// Build the upgrade batches.
 					err = r.buildRemediationPlan(ctx, clusterGroupUpgrade, managedPoliciesPresent)
 					if err != nil {
@@ -246,12 +354,12 @@ func (r *ClusterGroupUpgradeReconciler) Reconcile(ctx context.Context, req ctrl.
 							// If there are blocking CRs missing, update the message to show which those are.
 							statusReason = utils.CannotStart
 							statusMessage = fmt.Sprintf("The ClusterGroupUpgrade CR has blocking CRs that are missing: %s", blockingCRsMissing)
-							nextReconcile = requeueWithMediumInterval()
+							nextReconcile = r.requeueWithLongInterval()
 						} else if len(blockingCRsNotCompleted) > 0 {
 							// If there are blocking CRs that are not completed, then the upgrade can't start.
 							statusReason = utils.CannotStart
 							statusMessage = fmt.Sprintf("The ClusterGroupUpgrade CR is blocked by other CRs that have not yet completed: %s", blockingCRsNotCompleted)
-							nextReconcile = requeueWithMediumInterval()
+							nextReconcile = r.requeueWithLongInterval()
 						} else {
 							// There are no blocking CRs, continue with the upgrade process.
 							// Take actions before starting upgrade.
@@ -277,7 +385,7 @@ func (r *ClusterGroupUpgradeReconciler) Reconcile(ctx context.Context, req ctrl.
 					} else {
 						statusReason = "UpgradeNotStarted"
 						statusMessage = "The ClusterGroupUpgrade CR is not enabled"
-						nextReconcile = requeueWithLongInterval()
+						nextReconcile = r.requeueWithLongInterval()
 					}
 
 					meta.SetStatusCondition(&clusterGroupUpgrade.Status.Conditions, metav1.Condition{
@@ -289,13 +397,15 @@ func (r *ClusterGroupUpgradeReconciler) Reconcile(ctx context.Context, req ctrl.
 				} else {
 					// If not all managedPolicies exist, update the Status accordingly.
 					statusMessage := fmt.Sprintf("The ClusterGroupUpgrade CR has managed policies that are missing: %s", managedPoliciesMissing)
+					setCondition(clusterGroupUpgrade, ranv1alpha1.ConditionTypes.Validated, metav1.ConditionFalse,
+						ranv1alpha1.ConditionReasons.NotAllManagedPoliciesExist, statusMessage)
 					meta.SetStatusCondition(&clusterGroupUpgrade.Status.Conditions, metav1.Condition{
 						Type:    "Ready",
 						Status:  metav1.ConditionFalse,
 						Reason:  utils.CannotStart,
 						Message: statusMessage,
 					})
-					nextReconcile = requeueWithMediumInterval()
+					nextReconcile = r.requeueWithLongInterval()
 				}
 			} else if readyCondition.Reason == "UpgradeNotCompleted" {
 				r.Log.Info("[Reconcile]", "Status.CurrentBatch", clusterGroupUpgrade.Status.Status.CurrentBatch)
@@ -308,24 +418,35 @@ func (r *ClusterGroupUpgradeReconciler) Reconcile(ctx context.Context, req ctrl.
 				if clusterGroupUpgrade.Status.Status.CurrentBatchStartedAt.IsZero() {
 					nextReconcile = requeueImmediately()
 				} else {
-					//nolint
-					requeueAfter := clusterGroupUpgrade.Status.Status.CurrentBatchStartedAt.Add(5 * time.Minute).Sub(time.Now())
-					if requeueAfter < 0 {
-						requeueAfter = 5 * time.Minute
-					}
-					nextReconcile = requeueWithCustomInterval(requeueAfter)
+					// Policy compliance changes are watched, only the CGU timeout needs a requeue
+					nextReconcile = r.requeueBefore(clusterGroupUpgrade.Status.Status.StartedAt.Add(
+						time.Duration(getCguTimeout(clusterGroupUpgrade)) * time.Minute))
 				}
 
 				// At first, assume all clusters in the batch start applying policies starting with the first one.
 				// Also set the start time of the current batch to the current timestamp.
 				if clusterGroupUpgrade.Status.Status.CurrentBatchStartedAt.IsZero() {
+					// Don't start the batch timer until there is at least one fleet-wide remediation slot available.
+					var availableSlots int
+					availableSlots, err = r.getAvailableCapacity(ctx, clusterGroupUpgrade, capacityRemediation)
+					if err != nil {
+						return
+					}
+					waitingForCapacity := availableSlots == 0 &&
+						time.Since(clusterGroupUpgrade.Status.Status.StartedAt.Time) <= time.Duration(getCguTimeout(clusterGroupUpgrade))*time.Minute
+					r.setCapacityCondition(clusterGroupUpgrade, capacityRemediation, waitingForCapacity)
+					if waitingForCapacity {
+						err = r.updateStatus(ctx, clusterGroupUpgrade)
+						nextReconcile = r.requeueWithMediumInterval()
+						return
+					}
 					r.initializeRemediationPolicyForBatch(clusterGroupUpgrade)
 					// Set the time for when the batch started updating.
 					clusterGroupUpgrade.Status.Status.CurrentBatchStartedAt = metav1.Now()
 				}
 
 				// Check whether we have time left on the cgu timeout
-				if time.Since(clusterGroupUpgrade.Status.Status.StartedAt.Time) > time.Duration(clusterGroupUpgrade.Spec.RemediationStrategy.Timeout)*time.Minute {
+				if time.Since(clusterGroupUpgrade.Status.Status.StartedAt.Time) > time.Duration(getCguTimeout(clusterGroupUpgrade))*time.Minute {
 					// We are completely out of time
 					meta.SetStatusCondition(&clusterGroupUpgrade.Status.Conditions, metav1.Condition{
 						Type:    "Ready",
@@ -352,7 +473,7 @@ func (r *ClusterGroupUpgradeReconciler) Reconcile(ctx context.Context, req ctrl.
 						nextReconcile = requeueImmediately()
 					} else {
 						// Add the needed cluster names to upgrade to the appropriate placement rule.
-						err = r.remediateCurrentBatch(ctx, clusterGroupUpgrade, &nextReconcile)
+						err = r.remediateCurrentBatch(ctx, clusterGroupUpgrade)
 						if err != nil {
 							return
 						}
@@ -361,7 +482,7 @@ func (r *ClusterGroupUpgradeReconciler) Reconcile(ctx context.Context, req ctrl.
 						if !clusterGroupUpgrade.Status.Status.CurrentBatchStartedAt.IsZero() {
 
 							currentBatchTimeout := utils.CalculateBatchTimeout(
-								clusterGroupUpgrade.Spec.RemediationStrategy.Timeout,
+								getCguTimeout(clusterGroupUpgrade),
 								len(clusterGroupUpgrade.Status.RemediationPlan),
 								clusterGroupUpgrade.Status.Status.CurrentBatch,
 								clusterGroupUpgrade.Status.Status.CurrentBatchStartedAt.Time,
@@ -369,7 +490,9 @@ func (r *ClusterGroupUpgradeReconciler) Reconcile(ctx context.Context, req ctrl.
 
 							r.Log.Info("[Reconcile] Calculating batch timeout (minutes)", "currentBatchTimeout", fmt.Sprintf("%f", currentBatchTimeout.Minutes()))
 
-							if time.Since(clusterGroupUpgrade.Status.Status.CurrentBatchStartedAt.Time) > currentBatchTimeout {
+							if time.Since(clusterGroupUpgrade.Status.Status.CurrentBatchStartedAt.Time) <= currentBatchTimeout {
+								nextReconcile = r.requeueBefore(clusterGroupUpgrade.Status.Status.CurrentBatchStartedAt.Add(currentBatchTimeout))
+							} else {
 								// We want to immediately continue to the next reconcile regardless of the timeout action
 								nextReconcile = requeueImmediately()
 
@@ -385,7 +508,16 @@ func (r *ClusterGroupUpgradeReconciler) Reconcile(ctx context.Context, req ctrl.
 									})
 								} else {
 									r.Log.Info("Batch upgrade timed out")
-									switch clusterGroupUpgrade.Spec.BatchTimeoutAction {
+									batchTimeoutAction := clusterGroupUpgrade.Spec.BatchTimeoutAction
+									if batchTimeoutAction == "" {
+										var settings ranv1alpha1.OperatorSettings
+										settings, err = r.getOperatorSettings(ctx, clusterGroupUpgrade.Namespace)
+										if err != nil {
+											return
+										}
+										batchTimeoutAction = settings.DefaultBatchTimeoutAction
+									}
+									switch batchTimeoutAction {
 									case ranv1alpha1.BatchTimeoutAction.Abort:
 										// If the value was abort then we need to fail out
 										meta.SetStatusCondition(&clusterGroupUpgrade.Status.Conditions, metav1.Condition{
@@ -396,6 +528,10 @@ func (r *ClusterGroupUpgradeReconciler) Reconcile(ctx context.Context, req ctrl.
 										})
 									default:
 										// If the value was continue or not defined then continue
+										err = r.releaseBatchClusterLocks(ctx, clusterGroupUpgrade)
+										if err != nil {
+											return
+										}
 										clusterGroupUpgrade.Status.Status.CurrentBatchStartedAt = metav1.Time{}
 										if clusterGroupUpgrade.Status.Status.CurrentBatch < len(clusterGroupUpgrade.Status.RemediationPlan) {
 											clusterGroupUpgrade.Status.Status.CurrentBatch++
@@ -421,7 +557,7 @@ func (r *ClusterGroupUpgradeReconciler) Reconcile(ctx context.Context, req ctrl.
 						})
 						nextReconcile = requeueImmediately()
 					} else {
-						err = r.remediateCurrentBatch(ctx, clusterGroupUpgrade, &nextReconcile)
+						err = r.remediateCurrentBatch(ctx, clusterGroupUpgrade)
 						if err != nil {
 							return
 						}
@@ -430,6 +566,11 @@ func (r *ClusterGroupUpgradeReconciler) Reconcile(ctx context.Context, req ctrl.
 			} else if readyCondition.Reason == "UpgradeTimedOut" {
 				r.Recorder.Event(clusterGroupUpgrade, corev1.EventTypeWarning, "UpgradeTimedOut", "The ClusterGroupUpgrade CR policies are taking too long to complete")
 				r.Log.Info("CGU has timed out")
+				r.setCapacityCondition(clusterGroupUpgrade, capacityRemediation, false)
+				err = r.releaseAllClusterLocks(ctx, clusterGroupUpgrade)
+				if err != nil {
+					return
+				}
 				// On timeout we don't want to complete actions other then to delete the resources
 				err = r.deleteResources(ctx, clusterGroupUpgrade)
 				if err != nil {
@@ -439,10 +580,19 @@ func (r *ClusterGroupUpgradeReconciler) Reconcile(ctx context.Context, req ctrl.
 		} else {
 			if clusterGroupUpgrade.Status.Status.CompletedAt.IsZero() {
 				r.Log.Info("Upgrade is completed")
+				r.setCapacityCondition(clusterGroupUpgrade, capacityRemediation, false)
+				if err = r.releaseAllClusterLocks(ctx, clusterGroupUpgrade); err != nil {
+					return
+				}
 				// Take actions after upgrade is completed
 				clusterGroupUpgrade.Status.Status.CurrentBatch = 0
 				clusterGroupUpgrade.Status.Status.CurrentBatchStartedAt = metav1.Time{}
-				if err = r.takeActionsAfterCompletion(ctx, clusterGroupUpgrade); err != nil {
+				if isStandalonePrecaching(clusterGroupUpgrade) {
+					// A standalone CGU upgrades nothing, only its pre-caching objects are deleted
+					if err = r.jobAndViewCleanup(ctx, clusterGroupUpgrade); err != nil {
+						return
+					}
+				} else if err = r.takeActionsAfterCompletion(ctx, clusterGroupUpgrade); err != nil {
 					return
 				}
 				// Set completion time only after post actions are executed with no errors
@@ -480,6 +630,7 @@ func (r *ClusterGroupUpgradeReconciler) initializeRemediationPolicyForBatch(
   The policy currently applied for each cluster has its index held in
   clusterGroupUpgrade.Status.Status.CurrentBatchRemediationProgress[clusterName].PolicyIndex (the index is used to range through the
   policies present in clusterGroupUpgrade.Status.ManagedPoliciesForUpgrade).
+  With parallel waves, the remaining policies of the wave of that policy are applied together with it.
 
   returns: bool     : true if the batch is done upgrading; false if not
            error/nil: in case any error happens
@@ -490,13 +641,45 @@ func (r *ClusterGroupUpgradeReconciler) getNextRemediationPoliciesForBatch(
 	numberOfPolicies := len(clusterGroupUpgrade.Status.ManagedPoliciesForUpgrade)
 	isBatchComplete := true
 
+	// Clusters only move to InProgress while there are fleet-wide remediation slots available.
+	availableSlots, err := r.getAvailableCapacity(ctx, clusterGroupUpgrade, capacityRemediation)
+	if err != nil {
+		return false, err
+	}
+	waitingForCapacity := false
+
 	for _, batchClusterName := range clusterGroupUpgrade.Status.RemediationPlan[batchIndex] {
 		clusterProgressState := clusterGroupUpgrade.Status.Status.CurrentBatchRemediationProgress[batchClusterName].State
 		if clusterProgressState == ranv1alpha1.NotStarted {
+			if availableSlots <= 0 {
+				isBatchComplete = false
+				waitingForCapacity = true
+				continue
+			}
+			// Lock the cluster so that no other CGU remediates it at the same time.
+			holder, err := r.acquireClusterLock(ctx, clusterGroupUpgrade, batchClusterName)
+			if err != nil {
+				return false, err
+			}
+			clusterGroupUpgrade.Status.Status.CurrentBatchRemediationProgress[batchClusterName].LockedBy = holder
+			if holder != "" {
+				if clusterGroupUpgrade.Spec.LockedClusterAction == ranv1alpha1.LockedClusterAction.Skip {
+					r.Log.Info("[getNextRemediationPoliciesForBatch] Skipping locked cluster", "cluster", batchClusterName, "holder", holder)
+					clusterGroupUpgrade.Status.Status.CurrentBatchRemediationProgress[batchClusterName].State = ranv1alpha1.Skipped
+					if clusterGroupUpgrade.Status.Status.SkippedClusters == nil {
+						clusterGroupUpgrade.Status.Status.SkippedClusters = make(map[string]string)
+					}
+					clusterGroupUpgrade.Status.Status.SkippedClusters[batchClusterName] = holder
+				} else {
+					isBatchComplete = false
+				}
+				continue
+			}
+			availableSlots--
 			clusterGroupUpgrade.Status.Status.CurrentBatchRemediationProgress[batchClusterName].PolicyIndex = new(int)
 			*clusterGroupUpgrade.Status.Status.CurrentBatchRemediationProgress[batchClusterName].PolicyIndex = 0
 			clusterGroupUpgrade.Status.Status.CurrentBatchRemediationProgress[batchClusterName].State = ranv1alpha1.InProgress
-		} else if clusterProgressState == ranv1alpha1.Completed {
+		} else if clusterProgressState == ranv1alpha1.Completed || clusterProgressState == ranv1alpha1.Skipped {
 			continue
 		}
 		currentPolicyIndex := *clusterGroupUpgrade.Status.Status.CurrentBatchRemediationProgress[batchClusterName].PolicyIndex
@@ -508,14 +691,21 @@ func (r *ClusterGroupUpgradeReconciler) getNextRemediationPoliciesForBatch(
 		}
 
 		if currentPolicyIndex >= numberOfPolicies {
+			if err := r.takeActionsAfterClusterCompletion(ctx, clusterGroupUpgrade, batchClusterName); err != nil {
+				return false, err
+			}
 			clusterGroupUpgrade.Status.Status.CurrentBatchRemediationProgress[batchClusterName].PolicyIndex = nil
 			clusterGroupUpgrade.Status.Status.CurrentBatchRemediationProgress[batchClusterName].State = ranv1alpha1.Completed
+			if err := r.releaseClusterLock(ctx, clusterGroupUpgrade, batchClusterName); err != nil {
+				return false, err
+			}
 		} else {
 			isBatchComplete = false
 			*clusterGroupUpgrade.Status.Status.CurrentBatchRemediationProgress[batchClusterName].PolicyIndex = currentPolicyIndex
 		}
 	}
 
+	r.setCapacityCondition(clusterGroupUpgrade, capacityRemediation, waitingForCapacity)
 	r.Log.Info("[getNextRemediationPoliciesForBatch]", "isBatchComplete", isBatchComplete)
 	r.Log.Info("[getNextRemediationPoliciesForBatch]", "plan", clusterGroupUpgrade.Status.Status.CurrentBatchRemediationProgress)
 	return isBatchComplete, nil
@@ -525,23 +715,20 @@ func (r *ClusterGroupUpgradeReconciler) getNextRemediationPoliciesForBatch(
   remediateCurrentBatch:
   - steps through the remediationPolicyIndex and add the clusterNames to the corresponding
   placement rules in order so that at the end of a batch upgrade, all the copied policies are Compliant.
-  - approves the needed InstallPlans for the Subscription type policies
+  - approves the needed InstallPlans for the Subscription type policies. The views used for that are watched,
+  so a pending InstallPlan is retried as soon as its view is updated.
 
   returns: error/nil
 */
 func (r *ClusterGroupUpgradeReconciler) remediateCurrentBatch(
-	ctx context.Context, clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade, nextReconcile *ctrl.Result) error {
+	ctx context.Context, clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade) error {
 
 	err := r.updatePlacementRules(ctx, clusterGroupUpgrade)
 	if err != nil {
 		return err
 	}
 	// Approve needed InstallPlans.
-	reconcileSooner, err := r.approveInstallPlan(ctx, clusterGroupUpgrade)
-	if reconcileSooner {
-		*nextReconcile = requeueWithShortInterval()
-	}
-	return err
+	return r.approveInstallPlan(ctx, clusterGroupUpgrade)
 }
 
 func (r *ClusterGroupUpgradeReconciler) updatePlacementRules(ctx context.Context, clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade) error {
@@ -551,9 +738,10 @@ func (r *ClusterGroupUpgradeReconciler) updatePlacementRules(ctx context.Context
 		if clusterProgress.State != ranv1alpha1.InProgress {
 			continue
 		}
-		clusterNames := policiesToUpdate[*clusterProgress.PolicyIndex]
-		clusterNames = append(clusterNames, clusterName)
-		policiesToUpdate[*clusterProgress.PolicyIndex] = clusterNames
+		// The cluster is added to all the remaining policies of its current wave at once
+		for index := *clusterProgress.PolicyIndex; index < getWaveEnd(clusterGroupUpgrade, *clusterProgress.PolicyIndex); index++ {
+			policiesToUpdate[index] = append(policiesToUpdate[index], clusterName)
+		}
 	}
 
 	for index, clusterNames := range policiesToUpdate {
@@ -571,72 +759,82 @@ func (r *ClusterGroupUpgradeReconciler) updatePlacementRules(ctx context.Context
 }
 
 func (r *ClusterGroupUpgradeReconciler) approveInstallPlan(
-	ctx context.Context, clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade) (bool, error) {
+	ctx context.Context, clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade) error {
 
-	reconcileSooner := false
 	for clusterName, clusterProgress := range clusterGroupUpgrade.Status.Status.CurrentBatchRemediationProgress {
 		if clusterProgress.State != ranv1alpha1.InProgress {
 			continue
 		}
-		managedPolicyName := clusterGroupUpgrade.Status.ManagedPoliciesForUpgrade[*clusterProgress.PolicyIndex].Name
-
-		// If there is no content saved for the current managed policy, return.
-		_, ok := clusterGroupUpgrade.Status.ManagedPoliciesContent[managedPolicyName]
-		if !ok {
-			r.Log.Info("[approveInstallPlan] No content for policy", "managedPolicyName", managedPolicyName)
-			return false, nil
+		for index := *clusterProgress.PolicyIndex; index < getWaveEnd(clusterGroupUpgrade, *clusterProgress.PolicyIndex); index++ {
+			if err := r.approveInstallPlanForPolicy(ctx, clusterGroupUpgrade, clusterName, index); err != nil {
+				return err
+			}
 		}
+	}
+	return nil
+}
 
-		// If there is content saved for the current managed policy, retrieve it.
-		policyContentArr := []ranv1alpha1.PolicyContent{}
-		json.Unmarshal([]byte(clusterGroupUpgrade.Status.ManagedPoliciesContent[managedPolicyName]), &policyContentArr)
+// approveInstallPlanForPolicy approves the InstallPlans of the Subscriptions of a managed policy on a cluster
+// returns: error/nil
+func (r *ClusterGroupUpgradeReconciler) approveInstallPlanForPolicy(ctx context.Context,
+	clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade, clusterName string, policyIndex int) error {
 
-		for _, policyContent := range policyContentArr {
-			if policyContent.Kind != utils.PolicyTypeSubscription {
-				continue
-			}
+	managedPolicyName := clusterGroupUpgrade.Status.ManagedPoliciesForUpgrade[policyIndex].Name
 
-			r.Log.Info("[approveInstallPlan] Attempt to approve install plan for subscription",
-				"name", policyContent.Name, "in namespace", policyContent.Namespace)
-			// Get the managedClusterView for the subscription contained in the current managedPolicy.
-			// If missing, then return error.
-			mcvName := utils.GetMultiCloudObjectName(clusterGroupUpgrade, policyContent.Kind, policyContent.Name)
-			safeName, ok := clusterGroupUpgrade.Status.SafeResourceNames[mcvName]
-			if !ok {
-				r.Log.Info("ManagedClusterView name should have been present, but it was not found")
-				continue
-			}
-			mcv := &viewv1beta1.ManagedClusterView{}
-			if err := r.Get(ctx, types.NamespacedName{Name: safeName, Namespace: clusterName}, mcv); err != nil {
-				if errors.IsNotFound(err) {
-					r.Log.Info("ManagedClusterView should have been present, but it was not found")
-					continue
-				} else {
-					return false, err
-				}
-			}
+	// If there is no content saved for the current managed policy, return.
+	_, ok := clusterGroupUpgrade.Status.ManagedPoliciesContent[managedPolicyName]
+	if !ok {
+		r.Log.Info("[approveInstallPlan] No content for policy", "managedPolicyName", managedPolicyName)
+		return nil
+	}
 
-			// If the specific managedClusterView was found, check that it's condition Reason is "GetResourceProcessing"
-			installPlanStatus, err := utils.ProcessSubscriptionManagedClusterView(
-				ctx, r.Client, clusterGroupUpgrade, clusterName, mcv)
-			// If there is an error in trying to approve the install plan, just print the error and continue.
-			if err != nil {
-				r.Log.Info("An error occurred trying to approve install plan", "error", err.Error())
+	// If there is content saved for the current managed policy, retrieve it.
+	policyContentArr := []ranv1alpha1.PolicyContent{}
+	json.Unmarshal([]byte(clusterGroupUpgrade.Status.ManagedPoliciesContent[managedPolicyName]), &policyContentArr)
+
+	for _, policyContent := range policyContentArr {
+		if policyContent.Kind != utils.PolicyTypeSubscription {
+			continue
+		}
+
+		r.Log.Info("[approveInstallPlan] Attempt to approve install plan for subscription",
+			"name", policyContent.Name, "in namespace", policyContent.Namespace)
+		// Get the managedClusterView for the subscription contained in the current managedPolicy.
+		// If missing, then return error.
+		mcvName := utils.GetMultiCloudObjectName(clusterGroupUpgrade, policyContent.Kind, policyContent.Name)
+		safeName, ok := clusterGroupUpgrade.Status.SafeResourceNames[mcvName]
+		if !ok {
+			r.Log.Info("ManagedClusterView name should have been present, but it was not found")
+			continue
+		}
+		mcv := &viewv1beta1.ManagedClusterView{}
+		if err := r.Get(ctx, types.NamespacedName{Name: safeName, Namespace: clusterName}, mcv); err != nil {
+			if errors.IsNotFound(err) {
+				r.Log.Info("ManagedClusterView should have been present, but it was not found")
 				continue
-			}
-			if installPlanStatus == utils.InstallPlanCannotBeApproved {
-				r.Log.Info("InstallPlan for subscription could not be approved", "subscription name", policyContent.Name)
-				reconcileSooner = true
-			} else if installPlanStatus == utils.InstallPlanWasApproved {
-				r.Log.Info("InstallPlan for subscription was approved", "subscription name", policyContent.Name)
-			} else if installPlanStatus == utils.MultiCloudPendingStatus {
-				r.Log.Info("InstallPlan for subscription could not be approved due to a MultiCloud object pending status, "+
-					"retry again later", "subscription name", policyContent.Name)
-				reconcileSooner = true
+			} else {
+				return err
 			}
 		}
+
+		// If the specific managedClusterView was found, check that it's condition Reason is "GetResourceProcessing"
+		installPlanStatus, err := utils.ProcessSubscriptionManagedClusterView(
+			ctx, r.Client, clusterGroupUpgrade, clusterName, mcv)
+		// If there is an error in trying to approve the install plan, just print the error and continue.
+		if err != nil {
+			r.Log.Info("An error occurred trying to approve install plan", "error", err.Error())
+			continue
+		}
+		if installPlanStatus == utils.InstallPlanCannotBeApproved {
+			r.Log.Info("InstallPlan for subscription could not be approved", "subscription name", policyContent.Name)
+		} else if installPlanStatus == utils.InstallPlanWasApproved {
+			r.Log.Info("InstallPlan for subscription was approved", "subscription name", policyContent.Name)
+		} else if installPlanStatus == utils.MultiCloudPendingStatus {
+			r.Log.Info("InstallPlan for subscription could not be approved due to a MultiCloud object pending status, "+
+				"retry again later", "subscription name", policyContent.Name)
+		}
 	}
-	return reconcileSooner, nil
+	return nil
 }
 
 func (r *ClusterGroupUpgradeReconciler) updatePlacementRuleWithClusters(
@@ -749,7 +947,7 @@ func (r *ClusterGroupUpgradeReconciler) doManagedPoliciesExist(
 	if err != nil {
 		return false, nil, nil, err
 	}
-	childPoliciesList, err := utils.GetChildPolicies(ctx, r.Client, clusters)
+	childPoliciesList, err := utils.GetChildPoliciesOfPolicies(ctx, r.Client, clusterGroupUpgrade.Spec.ManagedPolicies, clusters)
 	if err != nil {
 		return false, nil, nil, err
 	}
@@ -816,7 +1014,8 @@ func (r *ClusterGroupUpgradeReconciler) doManagedPoliciesExist(
 				}
 
 				// Update the info on the policies used in the upgrade.
-				newPolicyInfo := ranv1alpha1.ManagedPolicyForUpgrade{Name: managedPolicyName, Namespace: managedPolicyNamespace}
+				newPolicyInfo := ranv1alpha1.ManagedPolicyForUpgrade{
+					Name: managedPolicyName, Namespace: managedPolicyNamespace, Wave: getPolicyWave(clusterGroupUpgrade, foundPolicy)}
 				managedPoliciesForUpgrade = append(managedPoliciesForUpgrade, newPolicyInfo)
 			}
 			// Add the policy to the list of present policies and update the status with the policy's namespace.
@@ -894,7 +1093,8 @@ func (r *ClusterGroupUpgradeReconciler) createSubscriptionManagedClusterView(
 			for _, nonCompliantCluster := range nonCompliantClusters {
 				_, err = utils.EnsureManagedClusterView(
 					ctx, r.Client, safeName, managedClusterViewName, nonCompliantCluster, "subscriptions.operators.coreos.com",
-					policyContent.Name, *policyContent.Namespace, clusterGroupUpgrade.Namespace+"-"+clusterGroupUpgrade.Name)
+					policyContent.Name, *policyContent.Namespace,
+					types.NamespacedName{Name: clusterGroupUpgrade.Name, Namespace: clusterGroupUpgrade.Namespace})
 				if err != nil {
 					return err
 				}
@@ -1228,7 +1428,7 @@ func (r *ClusterGroupUpgradeReconciler) getNextNonCompliantPolicyForCluster(
 		}
 
 		// Check if current cluster is compliant or not for its current managed policy.
-		clusterStatus := r.getClusterComplianceWithPolicy(clusterName, currentManagedPolicy)
+		clusterStatus := r.getClusterComplianceWithPolicy(ctx, clusterName, currentManagedPolicy)
 
 		// If the cluster is compliant for the policy or if the cluster is not matched with the policy,
 		// move to the next policy index.
@@ -1244,6 +1444,34 @@ func (r *ClusterGroupUpgradeReconciler) getNextNonCompliantPolicyForCluster(
 	return currentPolicyIndex, nil
 }
 
+// getPolicyWave returns the ztp-deploy-wave of a managed policy when the policies of a wave are remediated together,
+// nil otherwise or when the policy has no valid wave
+func getPolicyWave(clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade, policy *unstructured.Unstructured) *int {
+	if clusterGroupUpgrade.Spec.RemediationStrategy == nil || !clusterGroupUpgrade.Spec.RemediationStrategy.ParallelWaves {
+		return nil
+	}
+	wave, err := strconv.Atoi(policy.GetAnnotations()[ztpDeployWaveAnnotation])
+	if err != nil {
+		return nil
+	}
+	return &wave
+}
+
+/* getWaveEnd returns the end of the wave of a managed policy: the index following the last of the consecutive
+   policies sharing its wave. A policy without a wave is a wave of its own.
+
+   returns: int the index following the last policy of the wave
+*/
+func getWaveEnd(clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade, policyIndex int) int {
+	policies := clusterGroupUpgrade.Status.ManagedPoliciesForUpgrade
+	end := policyIndex + 1
+	wave := policies[policyIndex].Wave
+	for wave != nil && end < len(policies) && policies[end].Wave != nil && *policies[end].Wave == *wave {
+		end++
+	}
+	return end
+}
+
 /* isUpgradeComplete checks if there is at least one managed policy left for which at least one cluster in the
    batch is NonCompliant.
 
@@ -1260,6 +1488,10 @@ func (r *ClusterGroupUpgradeReconciler) isUpgradeComplete(ctx context.Context, c
 		// Check previous batches
 		for i := 0; i < len(clusterGroupUpgrade.Status.RemediationPlan)-1; i++ {
 			for _, batchClusterName := range clusterGroupUpgrade.Status.RemediationPlan[i] {
+				// Clusters skipped because they were locked by another CGU are not part of the upgrade anymore
+				if _, skipped := clusterGroupUpgrade.Status.Status.SkippedClusters[batchClusterName]; skipped {
+					continue
+				}
 				// Start with policy index 0 as we don't keep progress info from previous batches
 				nextNonCompliantPolicyIndex, err := r.getNextNonCompliantPolicyForCluster(ctx, clusterGroupUpgrade, batchClusterName, 0)
 				if err != nil || nextNonCompliantPolicyIndex < len(clusterGroupUpgrade.Status.ManagedPoliciesForUpgrade) {
@@ -1419,6 +1651,10 @@ func (r *ClusterGroupUpgradeReconciler) getCopiedPolicies(ctx context.Context, c
 }
 
 func (r *ClusterGroupUpgradeReconciler) reconcileResources(ctx context.Context, clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade, managedPoliciesPresent []*unstructured.Unstructured) error {
+	// Reuse the child resources created without recording their names before generating new ones
+	if err := r.recoverChildResourceNames(ctx, clusterGroupUpgrade); err != nil {
+		return err
+	}
 	// Reconcile resources
 	for _, managedPolicy := range managedPoliciesPresent {
 
@@ -1483,7 +1719,7 @@ func (r *ClusterGroupUpgradeReconciler) getClustersNonCompliantWithPolicy(
 		return nil, fmt.Errorf("cannot obtain all the details about the clusters in the CR: %s", err)
 	}
 	for _, cluster := range allClustersForUpgrade {
-		compliance := r.getClusterComplianceWithPolicy(cluster, policy)
+		compliance := r.getClusterComplianceWithPolicy(ctx, cluster, policy)
 		if compliance != utils.ClusterStatusCompliant {
 			nonCompliantClusters = append(nonCompliantClusters, cluster)
 		}
@@ -1514,33 +1750,67 @@ func (r *ClusterGroupUpgradeReconciler) getClustersNonCompliantWithPolicy(
 	         error
 */
 func (r *ClusterGroupUpgradeReconciler) getClusterComplianceWithPolicy(
-	clusterName string, policy *unstructured.Unstructured) string {
-	// Get the status of the clusters matching the policy.
-	subStatus := r.getPolicyClusterStatus(policy)
-	if subStatus == nil {
+	ctx context.Context, clusterName string, policy *unstructured.Unstructured) string {
+	clusterCompliance := r.getPolicyClusterCompliance(ctx, policy)
+	if clusterCompliance == nil {
 		r.Log.Info(
 			"[getClusterComplianceWithPolicy] Policy is missing its status, treat as NonCompliant")
 		return utils.ClusterStatusNonCompliant
 	}
 
-	// Loop through all the clusters in the policy's compliance status.
-	for _, crtSubStatusCrt := range subStatus {
-		crtSubStatusMap := crtSubStatusCrt.(map[string]interface{})
-		// If the cluster is Compliant, return true.
-		if clusterName == crtSubStatusMap["clustername"].(string) {
-			if crtSubStatusMap["compliant"] == utils.ClusterStatusCompliant {
-				return utils.ClusterStatusCompliant
-			} else if crtSubStatusMap["compliant"] == utils.ClusterStatusNonCompliant {
-				return utils.ClusterStatusNonCompliant
-			} else if crtSubStatusMap["compliant"] == nil {
-				r.Log.Info(
-					"[getClusterComplianceWithPolicy] Cluster is missing its compliance status, treat as NonCompliant",
-					"clusterName", clusterName, "policyName", policy.GetName())
-				return utils.ClusterStatusNonCompliant
+	compliance, ok := clusterCompliance[clusterName]
+	if !ok {
+		return utils.ClusterNotMatchedWithPolicy
+	}
+	if compliance == "" {
+		r.Log.Info(
+			"[getClusterComplianceWithPolicy] Cluster is missing its compliance status, treat as NonCompliant",
+			"clusterName", clusterName, "policyName", policy.GetName())
+		return utils.ClusterStatusNonCompliant
+	}
+	return compliance
+}
+
+/* getPolicyClusterCompliance maps the clusters in the status of a policy to their compliance, an empty string
+   if the compliance of the cluster is missing. The map is computed once per reconcile and version of the policy,
+   instead of scanning the policy status for each cluster.
+
+   returns: map[string]string the compliance by cluster name, nil if the policy is missing its status
+*/
+func (r *ClusterGroupUpgradeReconciler) getPolicyClusterCompliance(
+	ctx context.Context, policy *unstructured.Unstructured) map[string]string {
+	cache := getReconcileCache(ctx)
+	if cache != nil {
+		if clusterCompliance, ok := cache.clusterCompliance[policyCacheKey(policy)]; ok {
+			return clusterCompliance
+		}
+	}
+
+	var clusterCompliance map[string]string
+	if subStatus := r.getPolicyClusterStatus(policy); subStatus != nil {
+		clusterCompliance = make(map[string]string)
+		for _, crtSubStatusCrt := range subStatus {
+			crtSubStatusMap := crtSubStatusCrt.(map[string]interface{})
+			clusterName := crtSubStatusMap["clustername"].(string)
+			// The first entry of a cluster wins
+			if _, ok := clusterCompliance[clusterName]; ok {
+				continue
+			}
+			switch crtSubStatusMap["compliant"] {
+			case utils.ClusterStatusCompliant:
+				clusterCompliance[clusterName] = utils.ClusterStatusCompliant
+			case utils.ClusterStatusNonCompliant:
+				clusterCompliance[clusterName] = utils.ClusterStatusNonCompliant
+			case nil:
+				clusterCompliance[clusterName] = ""
 			}
 		}
 	}
-	return utils.ClusterNotMatchedWithPolicy
+
+	if cache != nil {
+		cache.clusterCompliance[policyCacheKey(policy)] = clusterCompliance
+	}
+	return clusterCompliance
 }
 
 func (r *ClusterGroupUpgradeReconciler) getClustersNonCompliantWithManagedPolicies(ctx context.Context,
@@ -1555,7 +1825,7 @@ func (r *ClusterGroupUpgradeReconciler) getClustersNonCompliantWithManagedPolici
 	}
 	for _, clusterName := range allClustersForUpgrade {
 		for _, managedPolicy := range managedPolicies {
-			clusterCompliance := r.getClusterComplianceWithPolicy(clusterName, managedPolicy)
+			clusterCompliance := r.getClusterComplianceWithPolicy(ctx, clusterName, managedPolicy)
 
 			if clusterCompliance == utils.ClusterStatusNonCompliant {
 				// If the cluster is NonCompliant in this current policy mark it as such and move to the next cluster.
@@ -1617,7 +1887,34 @@ func (r *ClusterGroupUpgradeReconciler) buildRemediationPlan(
 	return nil
 }
 
+/* getAllClustersForUpgrade returns the sorted names of the clusters selected by the CGU. The clusters matching the
+   selectors are listed through the ManagedCluster label index. The result is computed once per reconcile.
+
+   returns: []string the cluster names
+            error
+*/
 func (r *ClusterGroupUpgradeReconciler) getAllClustersForUpgrade(ctx context.Context, clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade) ([]string, error) {
+	cache := getReconcileCache(ctx)
+	if cache != nil {
+		if clusterNames, ok := cache.clusters[cguCacheKey(clusterGroupUpgrade)]; ok {
+			return append([]string{}, clusterNames...), nil
+		}
+	}
+
+	clusterNames, err := r.listClustersForUpgrade(ctx, clusterGroupUpgrade)
+	if err != nil {
+		return nil, err
+	}
+	r.Log.Info("[getAllClustersForUpgrade]", "clusterNames", clusterNames)
+
+	if cache != nil {
+		cache.clusters[cguCacheKey(clusterGroupUpgrade)] = clusterNames
+		return append([]string{}, clusterNames...), nil
+	}
+	return clusterNames, nil
+}
+
+func (r *ClusterGroupUpgradeReconciler) listClustersForUpgrade(ctx context.Context, clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade) ([]string, error) {
 
 	// These will be used later
 	clusterNames := []string{}
@@ -1637,12 +1934,8 @@ func (r *ClusterGroupUpgradeReconciler) getAllClustersForUpgrade(ctx context.Con
 			continue
 		}
 
-		listOpts := []client.ListOption{
-			client.MatchingLabels(clusterLabels),
-		}
-
-		clusterList := &clusterv1.ManagedClusterList{}
-		if err := r.List(ctx, clusterList, listOpts...); err != nil {
+		clusterList, err := r.listManagedClustersByLabels(ctx, clusterLabels, nil)
+		if err != nil {
 			return nil, err
 		}
 
@@ -1665,12 +1958,8 @@ func (r *ClusterGroupUpgradeReconciler) getAllClustersForUpgrade(ctx context.Con
 			return nil, err
 		}
 
-		listOpts := []client.ListOption{
-			client.MatchingLabelsSelector{Selector: selector},
-		}
-
-		clusterList := &clusterv1.ManagedClusterList{}
-		if err := r.List(ctx, clusterList, listOpts...); err != nil {
+		clusterList, err := r.listManagedClustersByLabels(ctx, clusterLabelSelector.MatchLabels, selector)
+		if err != nil {
 			return nil, err
 		}
 
@@ -1695,36 +1984,93 @@ func (r *ClusterGroupUpgradeReconciler) getAllClustersForUpgrade(ctx context.Con
 	// The kubernetes api does not return consistent results for label selectors
 	// Due to this behaviour we have to sort the list so that the result is consistent
 	sort.Strings(clusterNames)
-	r.Log.Info("[getAllClustersForUpgrade]", "clusterNames", clusterNames)
 	return clusterNames, nil
 }
 
-/* checkDuplicateChildResources looks up the name and desired name of the new resource in the list of resource names and the safe name map, before
-   adding the names to them. If duplicate (with same desired name annotation value) resource is found, it gets deleted, i.e. the new one takes precedence.
+/* listManagedClustersByLabels lists the ManagedClusters having the given labels and matching the selector. One of
+   the labels is looked up in the label index, the cache then only filters the clusters having it.
 
-   returns: the updated childResourceNameList
+   returns: *clusterv1.ManagedClusterList the matching clusters
+            error
 */
-func (r *ClusterGroupUpgradeReconciler) checkDuplicateChildResources(ctx context.Context, safeNameMap map[string]string, childResourceNames []string, newResource *unstructured.Unstructured) ([]string, error) {
-	if desiredName, ok := newResource.GetAnnotations()[utils.DesiredResourceName]; ok {
-		if safeName, ok := safeNameMap[desiredName]; ok {
-			if newResource.GetName() != safeName {
-				// Found an object with the same object name in annotation but different from our records in the names map
-				// This could happen when reconcile calls work on a stale version of CGU right after a status update from a previous reconcile
-				// Or the controller pod fails to update the status after creating objects, e.g. node failure
-				// Remove it as we have created a new one and updated the map
-				r.Log.Info("[checkDuplicateChildResources] clean up stale child resource", "name", newResource.GetName(), "kind", newResource.GetKind())
-				err := r.Client.Delete(ctx, newResource)
-				if err != nil {
-					return childResourceNames, err
-				}
-				return childResourceNames, nil
+func (r *ClusterGroupUpgradeReconciler) listManagedClustersByLabels(
+	ctx context.Context, clusterLabels map[string]string, selector labels.Selector) (*clusterv1.ManagedClusterList, error) {
+
+	if selector == nil {
+		selector = labels.SelectorFromSet(clusterLabels)
+	}
+	listOpts := []client.ListOption{
+		client.MatchingLabelsSelector{Selector: selector},
+	}
+	if len(clusterLabels) > 0 {
+		// Use the smallest key so that the same index entry is used every time
+		var indexKey string
+		for key := range clusterLabels {
+			if indexKey == "" || key < indexKey {
+				indexKey = key
 			}
-		} else {
-			safeNameMap[desiredName] = newResource.GetName()
+		}
+		listOpts = append(listOpts,
+			client.MatchingFields{utils.ManagedClusterLabelIndex: utils.LabelIndexValue(indexKey, clusterLabels[indexKey])})
+	}
+
+	clusterList := &clusterv1.ManagedClusterList{}
+	if err := r.List(ctx, clusterList, listOpts...); err != nil {
+		return nil, err
+	}
+	return clusterList, nil
+}
+
+// addChildResourceName adds the name of the child resource to the list of names and records its safe name
+// returns: the updated childResourceNameList
+func addChildResourceName(safeNameMap map[string]string, childResourceNames []string, resource *unstructured.Unstructured) []string {
+	if desiredName, ok := resource.GetAnnotations()[utils.DesiredResourceName]; ok {
+		if _, ok := safeNameMap[desiredName]; !ok {
+			safeNameMap[desiredName] = resource.GetName()
+		}
+	}
+	return append(childResourceNames, resource.GetName())
+}
+
+/* recoverChildResourceNames records the safe names of the child resources owned by the CGU whose names are
+   missing from the status, e.g. when the operator restarted between creating them and writing the status,
+   so that they are found again instead of being created once more under a new random name.
+
+   returns: error/nil
+*/
+func (r *ClusterGroupUpgradeReconciler) recoverChildResourceNames(ctx context.Context, clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade) error {
+	placementRules, err := r.getPlacementRules(ctx, clusterGroupUpgrade, nil)
+	if err != nil {
+		return err
+	}
+	placementBindings, err := r.getPlacementBindings(ctx, clusterGroupUpgrade)
+	if err != nil {
+		return err
+	}
+	copiedPolicies, err := r.getCopiedPolicies(ctx, clusterGroupUpgrade)
+	if err != nil {
+		return err
+	}
+
+	for _, children := range []*unstructured.UnstructuredList{placementRules, placementBindings, copiedPolicies} {
+		for i := range children.Items {
+			child := &children.Items[i]
+			desiredName, ok := child.GetAnnotations()[utils.DesiredResourceName]
+			if !ok || child.GetDeletionTimestamp() != nil || !metav1.IsControlledBy(child, clusterGroupUpgrade) {
+				continue
+			}
+			if _, ok := clusterGroupUpgrade.Status.SafeResourceNames[desiredName]; ok {
+				continue
+			}
+			if clusterGroupUpgrade.Status.SafeResourceNames == nil {
+				clusterGroupUpgrade.Status.SafeResourceNames = make(map[string]string)
+			}
+			r.Log.Info("[recoverChildResourceNames] Reusing child resource missing from the status",
+				"name", child.GetName(), "kind", child.GetKind())
+			clusterGroupUpgrade.Status.SafeResourceNames[desiredName] = child.GetName()
 		}
 	}
-	childResourceNames = append(childResourceNames, newResource.GetName())
-	return childResourceNames, nil
+	return nil
 }
 
 func (r *ClusterGroupUpgradeReconciler) updateChildResourceNamesInStatus(ctx context.Context, clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade) error {
@@ -1735,10 +2081,7 @@ func (r *ClusterGroupUpgradeReconciler) updateChildResourceNamesInStatus(ctx con
 
 	placementRuleNames := make([]string, 0)
 	for _, placementRule := range placementRules.Items {
-		placementRuleNames, err = r.checkDuplicateChildResources(ctx, clusterGroupUpgrade.Status.SafeResourceNames, placementRuleNames, &placementRule)
-		if err != nil {
-			return err
-		}
+		placementRuleNames = addChildResourceName(clusterGroupUpgrade.Status.SafeResourceNames, placementRuleNames, &placementRule)
 	}
 	clusterGroupUpgrade.Status.PlacementRules = placementRuleNames
 
@@ -1748,10 +2091,7 @@ func (r *ClusterGroupUpgradeReconciler) updateChildResourceNamesInStatus(ctx con
 	}
 	placementBindingNames := make([]string, 0)
 	for _, placementBinding := range placementBindings.Items {
-		placementBindingNames, err = r.checkDuplicateChildResources(ctx, clusterGroupUpgrade.Status.SafeResourceNames, placementBindingNames, &placementBinding)
-		if err != nil {
-			return err
-		}
+		placementBindingNames = addChildResourceName(clusterGroupUpgrade.Status.SafeResourceNames, placementBindingNames, &placementBinding)
 	}
 	clusterGroupUpgrade.Status.PlacementBindings = placementBindingNames
 
@@ -1761,28 +2101,12 @@ func (r *ClusterGroupUpgradeReconciler) updateChildResourceNamesInStatus(ctx con
 	}
 	copiedPolicyNames := make([]string, 0)
 	for _, policy := range copiedPolicies.Items {
-		copiedPolicyNames, err = r.checkDuplicateChildResources(ctx, clusterGroupUpgrade.Status.SafeResourceNames, copiedPolicyNames, &policy)
-		if err != nil {
-			return err
-		}
+		copiedPolicyNames = addChildResourceName(clusterGroupUpgrade.Status.SafeResourceNames, copiedPolicyNames, &policy)
 	}
 	clusterGroupUpgrade.Status.CopiedPolicies = copiedPolicyNames
 	return err
 }
 
-func (r *ClusterGroupUpgradeReconciler) updateStatus(ctx context.Context, clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade) error {
-	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
-		err := r.Status().Update(ctx, clusterGroupUpgrade)
-		return err
-	})
-
-	if err != nil {
-		return err
-	}
-
-	return nil
-}
-
 func (r *ClusterGroupUpgradeReconciler) blockingCRsNotCompleted(ctx context.Context, clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade) ([]string, []string, error) {
 
 	var blockingCRsNotCompleted []string
@@ -1803,18 +2127,9 @@ func (r *ClusterGroupUpgradeReconciler) blockingCRsNotCompleted(ctx context.Cont
 			}
 		}
 
-		// If a blocking CR doesn't have status conditions, it means something has gone wrong with processing
-		// it, so we should assume it's not completed.
-		if cgu.Status.Conditions == nil {
+		// If we find a blocking CR that has not succeeded, then we add it to the list.
+		if !isCguSucceeded(cgu) {
 			blockingCRsNotCompleted = append(blockingCRsNotCompleted, cgu.Name)
-			continue
-		}
-
-		// If we find a blocking CR with a status different than "UpgradeCompleted", then we add it to the list.
-		for i := range cgu.Status.Conditions {
-			if cgu.Status.Conditions[i].Reason != "UpgradeCompleted" {
-				blockingCRsNotCompleted = append(blockingCRsNotCompleted, cgu.Name)
-			}
 		}
 	}
 
@@ -1827,16 +2142,22 @@ func (r *ClusterGroupUpgradeReconciler) validateCR(ctx context.Context, clusterG
 	// Validate clusters in spec are ManagedCluster objects
 	clusters, err := r.getAllClustersForUpgrade(ctx, clusterGroupUpgrade)
 	if err != nil {
-		return reconcile, fmt.Errorf("cannot obtain all the details about the clusters in the CR: %s", err)
+		return reconcile, r.setValidationFailure(ctx, clusterGroupUpgrade, ranv1alpha1.ConditionTypes.ClustersSelected,
+			ranv1alpha1.ConditionReasons.ClusterNotFound,
+			fmt.Errorf("cannot obtain all the details about the clusters in the CR: %s", err))
 	}
 
-	for _, cluster := range clusters {
+	// The clusters matched by the selectors are ManagedClusters, only the ones listed explicitly are checked
+	for _, cluster := range clusterGroupUpgrade.Spec.Clusters {
 		managedCluster := &clusterv1.ManagedCluster{}
 		err := r.Client.Get(ctx, types.NamespacedName{Name: cluster}, managedCluster)
 		if err != nil {
-			return reconcile, fmt.Errorf("cluster %s is not a ManagedCluster", cluster)
+			return reconcile, r.setValidationFailure(ctx, clusterGroupUpgrade, ranv1alpha1.ConditionTypes.ClustersSelected,
+				ranv1alpha1.ConditionReasons.ClusterNotFound, fmt.Errorf("cluster %s is not a ManagedCluster", cluster))
 		}
 	}
+	setCondition(clusterGroupUpgrade, ranv1alpha1.ConditionTypes.ClustersSelected, metav1.ConditionTrue,
+		ranv1alpha1.ConditionReasons.ClusterSelectionCompleted, "All selected clusters are valid")
 
 	// Validate the canaries are in the list of clusters.
 	if clusterGroupUpgrade.Spec.RemediationStrategy.Canaries != nil && len(clusterGroupUpgrade.Spec.RemediationStrategy.Canaries) > 0 {
@@ -1849,11 +2170,27 @@ func (r *ClusterGroupUpgradeReconciler) validateCR(ctx context.Context, clusterG
 				}
 			}
 			if !foundCanary {
-				return reconcile, fmt.Errorf("canary cluster %s is not in the list of clusters", canary)
+				return reconcile, r.setValidationFailure(ctx, clusterGroupUpgrade, ranv1alpha1.ConditionTypes.Validated,
+					ranv1alpha1.ConditionReasons.InvalidCanaries, fmt.Errorf("canary cluster %s is not in the list of clusters", canary))
 			}
 		}
 	}
 
+	// A standalone CGU only pre-caches, so pre-caching must be enabled
+	if config := clusterGroupUpgrade.Spec.PreCachingConfig; config != nil && config.Standalone &&
+		!clusterGroupUpgrade.Spec.PreCaching {
+		return reconcile, r.setValidationFailure(ctx, clusterGroupUpgrade, ranv1alpha1.ConditionTypes.Validated,
+			ranv1alpha1.ConditionReasons.InvalidPreCachingConfig,
+			fmt.Errorf("standalone pre-caching requires spec.preCaching to be enabled"))
+	}
+
+	if validatedCondition := meta.FindStatusCondition(clusterGroupUpgrade.Status.Conditions, ranv1alpha1.ConditionTypes.Validated); validatedCondition != nil &&
+		(validatedCondition.Reason == ranv1alpha1.ConditionReasons.InvalidCanaries ||
+			validatedCondition.Reason == ranv1alpha1.ConditionReasons.InvalidPreCachingConfig) {
+		setCondition(clusterGroupUpgrade, ranv1alpha1.ConditionTypes.Validated, metav1.ConditionTrue,
+			ranv1alpha1.ConditionReasons.ValidationCompleted, "Completed validation")
+	}
+
 	var newMaxConcurrency int
 	// Automatically adjust maxConcurrency to the min of maxConcurrency and the number of clusters.
 	if clusterGroupUpgrade.Spec.RemediationStrategy.MaxConcurrency > 0 &&
@@ -1876,6 +2213,18 @@ func (r *ClusterGroupUpgradeReconciler) validateCR(ctx context.Context, clusterG
 	return reconcile, nil
 }
 
+// setValidationFailure reports a validation error in the given condition of the CGU status
+// returns: the validation error, or the error updating the status
+func (r *ClusterGroupUpgradeReconciler) setValidationFailure(ctx context.Context,
+	clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade, conditionType, reason string, validationErr error) error {
+
+	setCondition(clusterGroupUpgrade, conditionType, metav1.ConditionFalse, reason, validationErr.Error())
+	if err := r.updateStatus(ctx, clusterGroupUpgrade); err != nil {
+		return err
+	}
+	return validationErr
+}
+
 func (r *ClusterGroupUpgradeReconciler) handleCguFinalizer(
 	ctx context.Context, clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade) (int, error) {
 
@@ -1892,10 +2241,15 @@ func (r *ClusterGroupUpgradeReconciler) handleCguFinalizer(
 			if err != nil {
 				return utils.StopReconciling, err
 			}
+			err = r.releaseAllClusterLocks(ctx, clusterGroupUpgrade)
+			if err != nil {
+				return utils.StopReconciling, err
+			}
 
 			// Remove cguFinalizer. Once all finalizers have been removed, the object will be deleted.
+			patch := client.MergeFromWithOptions(clusterGroupUpgrade.DeepCopy(), client.MergeFromWithOptimisticLock{})
 			controllerutil.RemoveFinalizer(clusterGroupUpgrade, utils.CleanupFinalizer)
-			err = r.Update(ctx, clusterGroupUpgrade)
+			err = r.Patch(ctx, clusterGroupUpgrade, patch)
 			if err != nil {
 				return utils.StopReconciling, err
 			}
@@ -1905,8 +2259,9 @@ func (r *ClusterGroupUpgradeReconciler) handleCguFinalizer(
 
 	// Add finalizer for this CR.
 	if !controllerutil.ContainsFinalizer(clusterGroupUpgrade, utils.CleanupFinalizer) {
+		patch := client.MergeFromWithOptions(clusterGroupUpgrade.DeepCopy(), client.MergeFromWithOptimisticLock{})
 		controllerutil.AddFinalizer(clusterGroupUpgrade, utils.CleanupFinalizer)
-		err := r.Update(ctx, clusterGroupUpgrade)
+		err := r.Patch(ctx, clusterGroupUpgrade, patch)
 		if err != nil {
 			return utils.StopReconciling, err
 		}
@@ -1919,6 +2274,7 @@ func (r *ClusterGroupUpgradeReconciler) handleCguFinalizer(
 // SetupWithManager sets up the controller with the Manager.
 func (r *ClusterGroupUpgradeReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	r.Recorder = mgr.GetEventRecorderFor("ClusterGroupUpgrade")
+	r.capacityEvents = make(chan event.GenericEvent)
 
 	placementRuleUnstructured := &unstructured.Unstructured{}
 	placementRuleUnstructured.SetGroupVersionKind(schema.GroupVersionKind{
@@ -1941,31 +2297,84 @@ func (r *ClusterGroupUpgradeReconciler) SetupWithManager(mgr ctrl.Manager) error
 		Version: "v1",
 	})
 
-	return ctrl.NewControllerManagedBy(mgr).
+	// Status updates of the resources owned by the CGU
+	ownedStatusPredicate := predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			// Generation is only updated on spec changes (also on deletion),
+			// not metadata or status
+			oldGeneration := e.ObjectOld.GetGeneration()
+			newGeneration := e.ObjectNew.GetGeneration()
+			// status update only for parent policies and placement rules
+			return oldGeneration == newGeneration
+		},
+		CreateFunc:  func(ce event.CreateEvent) bool { return false },
+		GenericFunc: func(ge event.GenericEvent) bool { return false },
+		DeleteFunc:  func(de event.DeleteEvent) bool { return false },
+	}
+
+	managedPolicyUnstructured := &unstructured.Unstructured{}
+	managedPolicyUnstructured.SetGroupVersionKind(policyUnstructured.GroupVersionKind())
+
+	controllerBuilder := ctrl.NewControllerManagedBy(mgr).
+		WithOptions(controller.Options{MaxConcurrentReconciles: r.MaxConcurrentReconciles}).
 		For(&ranv1alpha1.ClusterGroupUpgrade{}, builder.WithPredicates(predicate.Funcs{
 			UpdateFunc: func(e event.UpdateEvent) bool {
 				// Generation is only updated on spec changes (also on deletion),
 				// not metadata or status
 				oldGeneration := e.ObjectOld.GetGeneration()
 				newGeneration := e.ObjectNew.GetGeneration()
-				// spec update only for CGU
-				return oldGeneration != newGeneration
+				// spec update only for CGU, or a pre-caching retry request
+				return oldGeneration != newGeneration ||
+					e.ObjectOld.GetAnnotations()[utils.PrecacheRetryAnnotation] !=
+						e.ObjectNew.GetAnnotations()[utils.PrecacheRetryAnnotation]
 			},
 			CreateFunc:  func(ce event.CreateEvent) bool { return true },
 			GenericFunc: func(ge event.GenericEvent) bool { return false },
 			DeleteFunc:  func(de event.DeleteEvent) bool { return false },
 		})).
-		Owns(policyUnstructured, builder.WithPredicates(predicate.Funcs{
-			UpdateFunc: func(e event.UpdateEvent) bool {
-				// Generation is only updated on spec changes (also on deletion),
-				// not metadata or status
-				oldGeneration := e.ObjectOld.GetGeneration()
-				newGeneration := e.ObjectNew.GetGeneration()
-				// status update only for parent policies
-				return oldGeneration == newGeneration
-			},
-			CreateFunc:  func(ce event.CreateEvent) bool { return false },
-			GenericFunc: func(ge event.GenericEvent) bool { return false },
-			DeleteFunc:  func(de event.DeleteEvent) bool { return false },
-		})).Complete(r)
+		Owns(policyUnstructured, builder.WithPredicates(ownedStatusPredicate)).
+		Owns(placementRuleUnstructured, builder.WithPredicates(ownedStatusPredicate)).
+		Watches(&source.Kind{Type: managedPolicyUnstructured},
+			handler.EnqueueRequestsFromMapFunc(r.mapManagedPolicyToCgus),
+			builder.WithPredicates(managedPolicyPredicate)).
+		Watches(&source.Kind{Type: &ranv1alpha1.ClusterGroupUpgrade{}},
+			handler.EnqueueRequestsFromMapFunc(r.mapBlockingCguToCgus),
+			builder.WithPredicates(blockingCguPredicate)).
+		Watches(&source.Kind{Type: &ranv1alpha1.ClusterGroupUpgrade{}},
+			handler.EnqueueRequestsFromMapFunc(r.mapCapacityReleaseToCgus),
+			builder.WithPredicates(capacityReleasePredicate)).
+		Watches(&source.Channel{Source: r.capacityEvents}, &handler.EnqueueRequestForObject{}).
+		Watches(&source.Kind{Type: &clusterv1.ManagedCluster{}},
+			handler.EnqueueRequestsFromMapFunc(r.mapLockedClusterToCgus),
+			builder.WithPredicates(clusterLockPredicate)).
+		Watches(&source.Kind{Type: &viewv1beta1.ManagedClusterView{}},
+			handler.EnqueueRequestsFromMapFunc(mapToOwnerCgu),
+			builder.WithPredicates(multiCloudStatusPredicate)).
+		Watches(&source.Kind{Type: &actionv1beta1.ManagedClusterAction{}},
+			handler.EnqueueRequestsFromMapFunc(mapToOwnerCgu),
+			builder.WithPredicates(multiCloudStatusPredicate))
+	if r.Shards != nil {
+		controllerBuilder = controllerBuilder.Watches(r.Shards.resyncSource(r.listCgusOfShard), &handler.EnqueueRequestForObject{})
+		// The slots released by the other replicas
+		controllerBuilder = controllerBuilder.Watches(&source.Kind{Type: &corev1.ConfigMap{}},
+			handler.EnqueueRequestsFromMapFunc(r.mapCapacityLedgerToCgus),
+			builder.WithPredicates(r.capacityLedgerPredicate()))
+	}
+	return controllerBuilder.Complete(r)
+}
+
+// listCgusOfShard lists the CGUs of a shard, to reconcile them once this replica acquires it
+func (r *ClusterGroupUpgradeReconciler) listCgusOfShard(shard int) []client.Object {
+	cguList := &ranv1alpha1.ClusterGroupUpgradeList{}
+	if err := r.List(context.TODO(), cguList); err != nil {
+		r.Log.Error(err, "[listCgusOfShard] Failed to list ClusterGroupUpgrades", "shard", shard)
+		return nil
+	}
+	var cgus []client.Object
+	for i := range cguList.Items {
+		if r.Shards.ShardOf(cguList.Items[i].Namespace, cguList.Items[i].Name) == shard {
+			cgus = append(cgus, &cguList.Items[i])
+		}
+	}
+	return cgus
 }
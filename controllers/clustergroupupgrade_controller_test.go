@@ -0,0 +1,149 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	ranv1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/api/v1alpha1"
+	"github.com/openshift-kni/cluster-group-upgrades-operator/controllers/utils"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+func newWaveTestCgu(parallelWaves bool, waves ...int) *ranv1alpha1.ClusterGroupUpgrade {
+	cgu := &ranv1alpha1.ClusterGroupUpgrade{}
+	cgu.Name, cgu.Namespace = "cgu", "default"
+	cgu.Spec.RemediationStrategy = &ranv1alpha1.RemediationStrategySpec{MaxConcurrency: 1, ParallelWaves: parallelWaves}
+	cgu.Status.SafeResourceNames = make(map[string]string)
+	for i, wave := range waves {
+		name := string(rune('a' + i))
+		policy := ranv1alpha1.ManagedPolicyForUpgrade{Name: name, Namespace: "policies"}
+		if wave >= 0 {
+			policy.Wave = new(int)
+			*policy.Wave = wave
+		}
+		cgu.Status.ManagedPoliciesForUpgrade = append(cgu.Status.ManagedPoliciesForUpgrade, policy)
+		placementRuleName := utils.GetResourceName(cgu, name+"-placement")
+		cgu.Status.SafeResourceNames[placementRuleName] = placementRuleName
+	}
+	return cgu
+}
+
+func TestController_getPolicyWave(t *testing.T) {
+	policy := &unstructured.Unstructured{}
+	policy.SetAnnotations(map[string]string{ztpDeployWaveAnnotation: "10"})
+	assert.Equal(t, 10, *getPolicyWave(newWaveTestCgu(true), policy))
+	assert.Nil(t, getPolicyWave(newWaveTestCgu(false), policy))
+
+	policy.SetAnnotations(map[string]string{ztpDeployWaveAnnotation: "ten"})
+	assert.Nil(t, getPolicyWave(newWaveTestCgu(true), policy))
+	policy.SetAnnotations(nil)
+	assert.Nil(t, getPolicyWave(newWaveTestCgu(true), policy))
+}
+
+func TestController_getWaveEnd(t *testing.T) {
+	// Policies without a wave (-1) are waves of their own
+	cgu := newWaveTestCgu(true, 1, 1, 2, -1, -1, 3, 3, 3)
+	var ends []int
+	for index := range cgu.Status.ManagedPoliciesForUpgrade {
+		ends = append(ends, getWaveEnd(cgu, index))
+	}
+	assert.Equal(t, []int{2, 2, 3, 4, 5, 8, 8, 8}, ends)
+}
+
+// placementRuleRecorder records the clusters of the updated placement rules, which the fake client can't store
+type placementRuleRecorder struct {
+	client.Client
+	clusters map[string][]string
+}
+
+func (c *placementRuleRecorder) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	placementRule := obj.(*unstructured.Unstructured)
+	for _, cluster := range placementRule.Object["spec"].(map[string]interface{})["clusters"].([]map[string]interface{}) {
+		c.clusters[placementRule.GetName()] = append(c.clusters[placementRule.GetName()], cluster["name"].(string))
+	}
+	return nil
+}
+
+func TestController_updatePlacementRulesWithWaves(t *testing.T) {
+	cgu := newWaveTestCgu(true, 1, 1, 2)
+	progress := func(index int) *ranv1alpha1.ClusterRemediationProgress {
+		return &ranv1alpha1.ClusterRemediationProgress{State: ranv1alpha1.InProgress, PolicyIndex: &index}
+	}
+	cgu.Status.Status.CurrentBatchRemediationProgress = map[string]*ranv1alpha1.ClusterRemediationProgress{
+		"spoke1": progress(0),
+		"spoke2": progress(1),
+		"spoke3": progress(2),
+	}
+	fakeClient, err := getFakeClientFromObjects()
+	if err != nil {
+		t.Errorf("error in creating fake client")
+	}
+	recorder := &placementRuleRecorder{Client: fakeClient, clusters: make(map[string][]string)}
+	r := &ClusterGroupUpgradeReconciler{Client: recorder, Log: logr.Discard(), Scheme: testscheme}
+	for _, name := range cgu.Status.SafeResourceNames {
+		placementRule := &unstructured.Unstructured{Object: map[string]interface{}{"spec": map[string]interface{}{}}}
+		placementRule.SetAPIVersion("apps.open-cluster-management.io/v1")
+		placementRule.SetKind("PlacementRule")
+		placementRule.SetName(name)
+		placementRule.SetNamespace("default")
+		assert.NoError(t, fakeClient.Create(context.TODO(), placementRule))
+	}
+
+	// The clusters are added to the remaining policies of their wave
+	assert.NoError(t, r.updatePlacementRules(context.TODO(), cgu))
+	expected := map[string][]string{
+		"a": {"spoke1"},
+		"b": {"spoke1", "spoke2"},
+		"c": {"spoke3"},
+	}
+	for policy, clusters := range expected {
+		assert.ElementsMatch(t, clusters, recorder.clusters[utils.GetResourceName(cgu, policy+"-placement")], policy)
+	}
+}
+
+func TestController_recoverChildResourceNames(t *testing.T) {
+	cgu := &ranv1alpha1.ClusterGroupUpgrade{
+		ObjectMeta: metav1.ObjectMeta{Name: "cgu", Namespace: "default", UID: "cgu-uid"},
+	}
+	previousCgu := &ranv1alpha1.ClusterGroupUpgrade{
+		ObjectMeta: metav1.ObjectMeta{Name: "cgu", Namespace: "default", UID: "previous-uid"},
+	}
+	newChild := func(apiVersion, kind, name, desiredName string, owner *ranv1alpha1.ClusterGroupUpgrade) *unstructured.Unstructured {
+		child := &unstructured.Unstructured{Object: map[string]interface{}{"spec": map[string]interface{}{}}}
+		child.SetAPIVersion(apiVersion)
+		child.SetKind(kind)
+		child.SetName(name)
+		child.SetNamespace("default")
+		child.SetLabels(map[string]string{"openshift-cluster-group-upgrades/clusterGroupUpgrade": "cgu"})
+		child.SetAnnotations(map[string]string{utils.DesiredResourceName: desiredName})
+		assert.NoError(t, controllerutil.SetControllerReference(owner, child, testscheme))
+		return child
+	}
+	fakeClient, err := getFakeClientFromObjects(
+		newChild("apps.open-cluster-management.io/v1", "PlacementRule",
+			"cgu-policy1-placement-kpqz2", "cgu-policy1-placement", cgu),
+		newChild("apps.open-cluster-management.io/v1", "PlacementRule",
+			"cgu-policy2-placement-x7f4w", "cgu-policy2-placement", previousCgu),
+		newChild("policy.open-cluster-management.io/v1", "PlacementBinding",
+			"cgu-policy3-placement-m2n8s", "cgu-policy3-placement", cgu),
+	)
+	if err != nil {
+		t.Errorf("error in creating fake client")
+	}
+	r := &ClusterGroupUpgradeReconciler{Client: fakeClient, Log: logr.Discard(), Scheme: testscheme}
+
+	cgu.Status.SafeResourceNames = map[string]string{"cgu-policy3-placement": "cgu-policy3-placement-b5c9d"}
+	assert.NoError(t, r.recoverChildResourceNames(context.TODO(), cgu))
+	assert.Equal(t, map[string]string{
+		"cgu-policy1-placement": "cgu-policy1-placement-kpqz2",
+		"cgu-policy3-placement": "cgu-policy3-placement-b5c9d",
+	}, cgu.Status.SafeResourceNames)
+	// The recovered name is used instead of a new one
+	assert.Equal(t, "cgu-policy1-placement-kpqz2",
+		utils.GetSafeResourceName("cgu-policy1-placement", cgu, utils.MaxObjectNameLength, 0))
+}
@@ -0,0 +1,156 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"strings"
+
+	ranv1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/api/v1alpha1"
+	utils "github.com/openshift-kni/cluster-group-upgrades-operator/controllers/utils"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// getClusterLockOwner returns the lock value identifying a CGU
+func getClusterLockOwner(clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade) string {
+	return clusterGroupUpgrade.Namespace + "/" + clusterGroupUpgrade.Name
+}
+
+/* acquireClusterLock takes the per-cluster lock for the CGU by setting the lock annotation on the ManagedCluster.
+   The annotation is patched with an optimistic lock so that only one CGU can win a race for the same cluster.
+   A lock left behind by a CGU that no longer exists or that has finished is taken over.
+
+   returns: string   the namespace/name of the CGU holding the lock if it is not the given CGU, empty otherwise
+            error/nil
+*/
+func (r *ClusterGroupUpgradeReconciler) acquireClusterLock(
+	ctx context.Context, clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade, clusterName string) (string, error) {
+
+	owner := getClusterLockOwner(clusterGroupUpgrade)
+	managedCluster := &clusterv1.ManagedCluster{}
+	if err := r.Get(ctx, types.NamespacedName{Name: clusterName}, managedCluster); err != nil {
+		return "", err
+	}
+
+	annotations := managedCluster.GetAnnotations()
+	if holder, ok := annotations[utils.ClusterLockAnnotation]; ok && holder != "" {
+		if holder == owner {
+			return "", nil
+		}
+		stale, err := r.isClusterLockStale(ctx, holder)
+		if err != nil {
+			return "", err
+		}
+		if !stale {
+			r.Log.Info("[acquireClusterLock] Cluster is locked by another CGU", "cluster", clusterName, "holder", holder)
+			return holder, nil
+		}
+		r.Log.Info("[acquireClusterLock] Taking over stale cluster lock", "cluster", clusterName, "holder", holder)
+	}
+
+	patch := client.MergeFromWithOptions(managedCluster.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[utils.ClusterLockAnnotation] = owner
+	managedCluster.SetAnnotations(annotations)
+	if err := r.Patch(ctx, managedCluster, patch); err != nil {
+		return "", err
+	}
+	r.Log.Info("[acquireClusterLock] Cluster locked", "cluster", clusterName, "owner", owner)
+	return "", nil
+}
+
+// isClusterLockStale returns true if the CGU holding a cluster lock no longer exists or has finished
+func (r *ClusterGroupUpgradeReconciler) isClusterLockStale(ctx context.Context, holder string) (bool, error) {
+	holderNsName := strings.SplitN(holder, "/", 2)
+	if len(holderNsName) != 2 {
+		return true, nil
+	}
+	holderCgu := &ranv1alpha1.ClusterGroupUpgrade{}
+	err := r.Get(ctx, types.NamespacedName{Namespace: holderNsName[0], Name: holderNsName[1]}, holderCgu)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, err
+	}
+	return isCguFinished(holderCgu), nil
+}
+
+// releaseClusterLock removes the lock annotation from the ManagedCluster if it is held by the CGU
+// returns: error/nil
+func (r *ClusterGroupUpgradeReconciler) releaseClusterLock(
+	ctx context.Context, clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade, clusterName string) error {
+
+	managedCluster := &clusterv1.ManagedCluster{}
+	if err := r.Get(ctx, types.NamespacedName{Name: clusterName}, managedCluster); err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if managedCluster.GetAnnotations()[utils.ClusterLockAnnotation] != getClusterLockOwner(clusterGroupUpgrade) {
+		return nil
+	}
+
+	patch := client.MergeFromWithOptions(managedCluster.DeepCopy(), client.MergeFromWithOptimisticLock{})
+	annotations := managedCluster.GetAnnotations()
+	delete(annotations, utils.ClusterLockAnnotation)
+	managedCluster.SetAnnotations(annotations)
+	if err := r.Patch(ctx, managedCluster, patch); err != nil {
+		return err
+	}
+	r.Log.Info("[releaseClusterLock] Cluster unlocked", "cluster", clusterName)
+	return nil
+}
+
+// releaseBatchClusterLocks releases the locks held for the clusters of the current batch
+// returns: error/nil
+func (r *ClusterGroupUpgradeReconciler) releaseBatchClusterLocks(
+	ctx context.Context, clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade) error {
+
+	for clusterName, clusterProgress := range clusterGroupUpgrade.Status.Status.CurrentBatchRemediationProgress {
+		if clusterProgress == nil || clusterProgress.State != ranv1alpha1.InProgress {
+			continue
+		}
+		if err := r.releaseClusterLock(ctx, clusterGroupUpgrade, clusterName); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// releaseAllClusterLocks releases the locks held by the CGU on any of its clusters
+// returns: error/nil
+func (r *ClusterGroupUpgradeReconciler) releaseAllClusterLocks(
+	ctx context.Context, clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade) error {
+
+	clusters, err := r.getAllClustersForUpgrade(ctx, clusterGroupUpgrade)
+	if err != nil {
+		return err
+	}
+	for _, clusterName := range clusters {
+		if err := r.releaseClusterLock(ctx, clusterGroupUpgrade, clusterName); err != nil {
+			return err
+		}
+	}
+	return nil
+}
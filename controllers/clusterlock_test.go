@@ -0,0 +1,112 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	ranv1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/api/v1alpha1"
+	"github.com/openshift-kni/cluster-group-upgrades-operator/controllers/utils"
+	"github.com/stretchr/testify/assert"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestClusterLock_acquireClusterLock(t *testing.T) {
+	holderCgu := &ranv1alpha1.ClusterGroupUpgrade{
+		ObjectMeta: v1.ObjectMeta{Name: "holder", Namespace: "default"},
+		Status: ranv1alpha1.ClusterGroupUpgradeStatus{
+			Conditions: []v1.Condition{{Type: "Ready", Status: v1.ConditionFalse, Reason: "UpgradeNotCompleted"}},
+		},
+	}
+	completedCgu := &ranv1alpha1.ClusterGroupUpgrade{
+		ObjectMeta: v1.ObjectMeta{Name: "completed", Namespace: "default"},
+		Status: ranv1alpha1.ClusterGroupUpgradeStatus{
+			Conditions: []v1.Condition{{Type: "Ready", Status: v1.ConditionTrue, Reason: "UpgradeCompleted"}},
+		},
+	}
+	testcases := []struct {
+		name           string
+		lockHolder     string
+		objs           []client.Object
+		expectedHolder string
+		expectedLock   string
+	}{
+		{
+			name:           "cluster is not locked",
+			expectedHolder: "",
+			expectedLock:   "default/test",
+		},
+		{
+			name:           "cluster is already locked by the same CGU",
+			lockHolder:     "default/test",
+			expectedHolder: "",
+			expectedLock:   "default/test",
+		},
+		{
+			name:           "cluster is locked by another CGU in progress",
+			lockHolder:     "default/holder",
+			objs:           []client.Object{holderCgu},
+			expectedHolder: "default/holder",
+			expectedLock:   "default/holder",
+		},
+		{
+			name:           "cluster is locked by a CGU that does not exist",
+			lockHolder:     "default/missing",
+			expectedHolder: "",
+			expectedLock:   "default/test",
+		},
+		{
+			name:           "cluster is locked by a completed CGU",
+			lockHolder:     "default/completed",
+			objs:           []client.Object{completedCgu},
+			expectedHolder: "",
+			expectedLock:   "default/test",
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			cluster := &clusterv1.ManagedCluster{ObjectMeta: v1.ObjectMeta{Name: "spoke1"}}
+			if tc.lockHolder != "" {
+				cluster.SetAnnotations(map[string]string{utils.ClusterLockAnnotation: tc.lockHolder})
+			}
+			c, err := getFakeClientFromObjects(append(tc.objs, cluster)...)
+			if err != nil {
+				t.Errorf("error in creating fake client")
+			}
+			r := &ClusterGroupUpgradeReconciler{
+				Client: c,
+				Log:    logr.Discard(),
+				Scheme: testscheme,
+			}
+			cgu := &ranv1alpha1.ClusterGroupUpgrade{ObjectMeta: v1.ObjectMeta{Name: "test", Namespace: "default"}}
+			holder, err := r.acquireClusterLock(context.TODO(), cgu, "spoke1")
+			if err != nil {
+				t.Errorf("error acquiring the cluster lock: %v", err)
+			}
+			assert.Equal(t, tc.expectedHolder, holder)
+
+			cluster = &clusterv1.ManagedCluster{}
+			if err := c.Get(context.TODO(), types.NamespacedName{Name: "spoke1"}, cluster); err != nil {
+				t.Errorf("error getting the managed cluster: %v", err)
+			}
+			assert.Equal(t, tc.expectedLock, cluster.GetAnnotations()[utils.ClusterLockAnnotation])
+
+			// Releasing only removes a lock held by the CGU itself
+			if err := r.releaseClusterLock(context.TODO(), cgu, "spoke1"); err != nil {
+				t.Errorf("error releasing the cluster lock: %v", err)
+			}
+			cluster = &clusterv1.ManagedCluster{}
+			if err := c.Get(context.TODO(), types.NamespacedName{Name: "spoke1"}, cluster); err != nil {
+				t.Errorf("error getting the managed cluster: %v", err)
+			}
+			if tc.expectedHolder == "" {
+				assert.NotContains(t, cluster.GetAnnotations(), utils.ClusterLockAnnotation)
+			} else {
+				assert.Equal(t, tc.expectedHolder, cluster.GetAnnotations()[utils.ClusterLockAnnotation])
+			}
+		})
+	}
+}
@@ -0,0 +1,560 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strings"
+
+	ranv1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/api/v1alpha1"
+	utils "github.com/openshift-kni/cluster-group-upgrades-operator/controllers/utils"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// The per-cluster state of a CGU is sharded over ConfigMaps holding about this many clusters each, or fewer
+// if their state doesn't fit. The number of ConfigMaps grows with the state but never shrinks.
+const clustersPerClusterStatesConfigMap = 500
+
+// maxClusterStatesConfigMapBytes bounds the data of a cluster states ConfigMap, well below the 1 MiB limit of
+// an object. The pre-caching summaries listing image failures can make it the limit before the cluster count.
+const maxClusterStatesConfigMapBytes = 512 * 1024
+
+// Keys of the cluster states ConfigMaps data
+const (
+	clusterStateKeyPrefix     = "cluster."
+	safeResourceNameKeyPrefix = "safeResourceName."
+)
+
+// clusterState is the state of a cluster stored in a cluster states ConfigMap
+type clusterState struct {
+	// Batch and BatchIndex locate the cluster in the remediation plan
+	Batch      *int `json:"batch,omitempty"`
+	BatchIndex int  `json:"batchIndex,omitempty"`
+	// PrecachingIndex and BackupIndex locate the cluster in precaching.clusters and backup.clusters
+	PrecachingIndex *int   `json:"precachingIndex,omitempty"`
+	Precaching      string `json:"precaching,omitempty"`
+	// PrecachingAttempts records the pre-caching attempts on the cluster
+	PrecachingAttempts *ranv1alpha1.PrecachingAttempts `json:"precachingAttempts,omitempty"`
+	// PrecachingSummary is the progress of the pre-caching job of the cluster
+	PrecachingSummary *ranv1alpha1.PrecachingSummary `json:"precachingSummary,omitempty"`
+	// PrecachingSpec is the name of the software spec the cluster pre-caches, if the clusters don't all
+	// pre-cache the same one
+	PrecachingSpec string `json:"precachingSpec,omitempty"`
+	BackupIndex    *int   `json:"backupIndex,omitempty"`
+	Backup         string `json:"backup,omitempty"`
+}
+
+// clusterStatesConfigMapName returns the name of the i-th cluster states ConfigMap of the CGU
+func clusterStatesConfigMapName(clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade, i int) string {
+	suffix := fmt.Sprintf("-cluster-states-%d", i)
+	name := clusterGroupUpgrade.Name
+	if len(name)+len(suffix) > utils.MaxObjectNameLength {
+		name = name[:utils.MaxObjectNameLength-len(suffix)]
+	}
+	return name + suffix
+}
+
+// getShard returns the cluster states ConfigMap a key is stored in
+func getShard(key string, shardCount int) int {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return int(h.Sum32() % uint32(shardCount))
+}
+
+// getClusterStatesShardCount returns the number of ConfigMaps needed for the per-cluster state in the status
+func getClusterStatesShardCount(status *ranv1alpha1.ClusterGroupUpgradeStatus) int {
+	clusters := 0
+	for _, batch := range status.RemediationPlan {
+		clusters += len(batch)
+	}
+	if status.Precaching != nil && len(status.Precaching.Status) > clusters {
+		clusters = len(status.Precaching.Status)
+	}
+	if status.Backup != nil && len(status.Backup.Status) > clusters {
+		clusters = len(status.Backup.Status)
+	}
+	shardCount := (clusters + clustersPerClusterStatesConfigMap - 1) / clustersPerClusterStatesConfigMap
+	if shardCount == 0 {
+		return 1
+	}
+	return shardCount
+}
+
+// getClusterStateCounts returns the number of clusters of the CGU per pre-caching or backup state. The counts
+// are read from the summary when the per-cluster state is stored in ConfigMaps, like for the CGUs listed from
+// the cache.
+func getClusterStateCounts(clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade, kind string) map[string]int {
+	var states map[string]string
+	summary := clusterGroupUpgrade.Status.ClusterStates
+	switch kind {
+	case capacityPrecaching:
+		if clusterGroupUpgrade.Status.Precaching != nil {
+			states = clusterGroupUpgrade.Status.Precaching.Status
+		}
+		if len(states) == 0 && summary != nil {
+			return summary.Precaching
+		}
+	case capacityBackup:
+		if clusterGroupUpgrade.Status.Backup != nil {
+			states = clusterGroupUpgrade.Status.Backup.Status
+		}
+		if len(states) == 0 && summary != nil {
+			return summary.Backup
+		}
+	}
+	counts := make(map[string]int)
+	for _, state := range states {
+		counts[state]++
+	}
+	return counts
+}
+
+// isClusterStateStorageEnabled returns true if the operator is configured to store the per-cluster state
+// in ConfigMaps
+func (r *ClusterGroupUpgradeReconciler) isClusterStateStorageEnabled() bool {
+	r.operatorConfigLock.RLock()
+	defer r.operatorConfigLock.RUnlock()
+	return r.operatorConfig.ClusterStateStorage == ranv1alpha1.ClusterStateStorage.ConfigMaps
+}
+
+/* splitClusterStates moves the per-cluster state out of a copy of the CGU status into the data of the given
+   number of ConfigMaps, and counts the clusters per state.
+
+   returns: ranv1alpha1.ClusterGroupUpgradeStatus the status without the per-cluster state
+            []map[string]string the data of the ConfigMaps
+            error
+*/
+func splitClusterStates(
+	status *ranv1alpha1.ClusterGroupUpgradeStatus, shardCount int) (
+	ranv1alpha1.ClusterGroupUpgradeStatus, []map[string]string, error) {
+
+	inlineStatus := *status.DeepCopy()
+	summary := &ranv1alpha1.ClusterStatesStatus{Batches: len(status.RemediationPlan)}
+	states := make(map[string]*clusterState)
+	getState := func(cluster string) *clusterState {
+		if _, ok := states[cluster]; !ok {
+			states[cluster] = &clusterState{}
+		}
+		return states[cluster]
+	}
+
+	for batch := range status.RemediationPlan {
+		for batchIndex, cluster := range status.RemediationPlan[batch] {
+			state := getState(cluster)
+			state.Batch = new(int)
+			*state.Batch = batch
+			state.BatchIndex = batchIndex
+			summary.Clusters++
+		}
+	}
+	inlineStatus.RemediationPlan = nil
+	if status.Precaching != nil {
+		for i, cluster := range status.Precaching.Clusters {
+			getState(cluster).PrecachingIndex = new(int)
+			*getState(cluster).PrecachingIndex = i
+		}
+		for cluster, precachingState := range status.Precaching.Status {
+			getState(cluster).Precaching = precachingState
+			if summary.Precaching == nil {
+				summary.Precaching = make(map[string]int)
+			}
+			summary.Precaching[precachingState]++
+		}
+		for cluster, attempts := range status.Precaching.Attempts {
+			getState(cluster).PrecachingAttempts = attempts.DeepCopy()
+		}
+		for cluster, precachingSummary := range status.Precaching.Summaries {
+			getState(cluster).PrecachingSummary = precachingSummary.DeepCopy()
+		}
+		for cluster, specName := range status.Precaching.ClusterSpecs {
+			getState(cluster).PrecachingSpec = specName
+		}
+		inlineStatus.Precaching.Clusters = nil
+		inlineStatus.Precaching.Status = nil
+		inlineStatus.Precaching.Attempts = nil
+		inlineStatus.Precaching.Summaries = nil
+		inlineStatus.Precaching.ClusterSpecs = nil
+	}
+	if status.Backup != nil {
+		for i, cluster := range status.Backup.Clusters {
+			getState(cluster).BackupIndex = new(int)
+			*getState(cluster).BackupIndex = i
+		}
+		for cluster, backupState := range status.Backup.Status {
+			getState(cluster).Backup = backupState
+			if summary.Backup == nil {
+				summary.Backup = make(map[string]int)
+			}
+			summary.Backup[backupState]++
+		}
+		inlineStatus.Backup.Clusters = nil
+		inlineStatus.Backup.Status = nil
+	}
+
+	shards := make([]map[string]string, shardCount)
+	for i := range shards {
+		shards[i] = make(map[string]string)
+	}
+	for cluster, state := range states {
+		value, err := json.Marshal(state)
+		if err != nil {
+			return inlineStatus, nil, err
+		}
+		shards[getShard(cluster, shardCount)][clusterStateKeyPrefix+cluster] = string(value)
+	}
+	for name, safeName := range status.SafeResourceNames {
+		shards[getShard(name, shardCount)][safeResourceNameKeyPrefix+name] = safeName
+	}
+	inlineStatus.SafeResourceNames = nil
+
+	inlineStatus.ClusterStates = summary
+	return inlineStatus, shards, nil
+}
+
+/* splitClusterStatesBySize splits the per-cluster state like splitClusterStates over at least the given
+   number of ConfigMaps, and over more of them if the data of one would exceed maxClusterStatesConfigMapBytes
+
+   returns: ranv1alpha1.ClusterGroupUpgradeStatus the status without the per-cluster state
+            []map[string]string the data of the ConfigMaps
+            error
+*/
+func splitClusterStatesBySize(
+	status *ranv1alpha1.ClusterGroupUpgradeStatus, shardCount int) (
+	ranv1alpha1.ClusterGroupUpgradeStatus, []map[string]string, error) {
+
+	for {
+		inlineStatus, shards, err := splitClusterStates(status, shardCount)
+		if err != nil {
+			return inlineStatus, nil, err
+		}
+		largest, size := 0, 0
+		for i, data := range shards {
+			if dataSize := getClusterStatesDataSize(data); dataSize > size {
+				largest, size = i, dataSize
+			}
+		}
+		// A single key can't be split further
+		if size <= maxClusterStatesConfigMapBytes || len(shards[largest]) <= 1 {
+			return inlineStatus, shards, nil
+		}
+		// The keys are spread by hash, so the ConfigMaps fill up unevenly: grow by at least one
+		grown := shardCount * size / maxClusterStatesConfigMapBytes
+		if grown <= shardCount {
+			grown = shardCount + 1
+		}
+		shardCount = grown
+	}
+}
+
+// getClusterStatesDataSize returns the size of the data of a cluster states ConfigMap
+func getClusterStatesDataSize(data map[string]string) int {
+	size := 0
+	for key, value := range data {
+		size += len(key) + len(value)
+	}
+	return size
+}
+
+/* mergeClusterStates restores the per-cluster state stored in the data of the cluster states ConfigMaps
+   into the CGU status
+
+   returns: error/nil
+*/
+func mergeClusterStates(status *ranv1alpha1.ClusterGroupUpgradeStatus, shards []map[string]string) error {
+	type indexedCluster struct {
+		name  string
+		index int
+	}
+	batches := make([][]indexedCluster, status.ClusterStates.Batches)
+	var precachingClusters, backupClusters []indexedCluster
+	precachingStates := make(map[string]string)
+	precachingAttempts := make(map[string]*ranv1alpha1.PrecachingAttempts)
+	precachingSummaries := make(map[string]*ranv1alpha1.PrecachingSummary)
+	precachingSpecs := make(map[string]string)
+	backupStates := make(map[string]string)
+
+	for _, data := range shards {
+		for key, value := range data {
+			if strings.HasPrefix(key, safeResourceNameKeyPrefix) {
+				if status.SafeResourceNames == nil {
+					status.SafeResourceNames = make(map[string]string)
+				}
+				status.SafeResourceNames[strings.TrimPrefix(key, safeResourceNameKeyPrefix)] = value
+				continue
+			}
+			if !strings.HasPrefix(key, clusterStateKeyPrefix) {
+				continue
+			}
+			cluster := strings.TrimPrefix(key, clusterStateKeyPrefix)
+			state := clusterState{}
+			if err := json.Unmarshal([]byte(value), &state); err != nil {
+				return fmt.Errorf("invalid state of cluster %s: %s", cluster, err)
+			}
+			if state.Batch != nil {
+				if *state.Batch >= len(batches) {
+					return fmt.Errorf("cluster %s is in batch %d out of %d", cluster, *state.Batch+1, len(batches))
+				}
+				batches[*state.Batch] = append(batches[*state.Batch], indexedCluster{cluster, state.BatchIndex})
+			}
+			if state.PrecachingIndex != nil {
+				precachingClusters = append(precachingClusters, indexedCluster{cluster, *state.PrecachingIndex})
+			}
+			if state.Precaching != "" {
+				precachingStates[cluster] = state.Precaching
+			}
+			if state.PrecachingAttempts != nil {
+				precachingAttempts[cluster] = state.PrecachingAttempts
+			}
+			if state.PrecachingSummary != nil {
+				precachingSummaries[cluster] = state.PrecachingSummary
+			}
+			if state.PrecachingSpec != "" {
+				precachingSpecs[cluster] = state.PrecachingSpec
+			}
+			if state.BackupIndex != nil {
+				backupClusters = append(backupClusters, indexedCluster{cluster, *state.BackupIndex})
+			}
+			if state.Backup != "" {
+				backupStates[cluster] = state.Backup
+			}
+		}
+	}
+
+	sortedNames := func(clusters []indexedCluster) []string {
+		sort.Slice(clusters, func(i, j int) bool { return clusters[i].index < clusters[j].index })
+		names := make([]string, 0, len(clusters))
+		for _, cluster := range clusters {
+			names = append(names, cluster.name)
+		}
+		return names
+	}
+	if len(batches) > 0 {
+		status.RemediationPlan = make([][]string, 0, len(batches))
+		for _, batch := range batches {
+			status.RemediationPlan = append(status.RemediationPlan, sortedNames(batch))
+		}
+	}
+	if len(precachingClusters) > 0 || len(precachingStates) > 0 || len(precachingAttempts) > 0 ||
+		len(precachingSummaries) > 0 || len(precachingSpecs) > 0 {
+		if status.Precaching == nil {
+			status.Precaching = &ranv1alpha1.PrecachingStatus{}
+		}
+		if len(precachingClusters) > 0 {
+			status.Precaching.Clusters = sortedNames(precachingClusters)
+		}
+		if len(precachingStates) > 0 {
+			status.Precaching.Status = precachingStates
+		}
+		if len(precachingAttempts) > 0 {
+			status.Precaching.Attempts = precachingAttempts
+		}
+		if len(precachingSummaries) > 0 {
+			status.Precaching.Summaries = precachingSummaries
+		}
+		if len(precachingSpecs) > 0 {
+			status.Precaching.ClusterSpecs = precachingSpecs
+		}
+	}
+	if len(backupClusters) > 0 || len(backupStates) > 0 {
+		if status.Backup == nil {
+			status.Backup = &ranv1alpha1.BackupStatus{}
+		}
+		if len(backupClusters) > 0 {
+			status.Backup.Clusters = sortedNames(backupClusters)
+		}
+		if len(backupStates) > 0 {
+			status.Backup.Status = backupStates
+		}
+	}
+	return nil
+}
+
+/* getClusterStatesConfigMap reads a cluster states ConfigMap from the cache, or from the API server if the
+   cache doesn't have the version written last
+
+   returns: *corev1.ConfigMap
+            error/nil
+*/
+func (r *ClusterGroupUpgradeReconciler) getClusterStatesConfigMap(
+	ctx context.Context, namespace string, ref ranv1alpha1.ClusterStateConfigMap) (*corev1.ConfigMap, error) {
+
+	key := types.NamespacedName{Name: ref.Name, Namespace: namespace}
+	configMap := &corev1.ConfigMap{}
+	err := r.Get(ctx, key, configMap)
+	if err == nil && configMap.ResourceVersion == ref.ResourceVersion {
+		return configMap, nil
+	}
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, err
+	}
+	if err := r.apiReader().Get(ctx, key, configMap); err != nil {
+		return nil, err
+	}
+	return configMap, nil
+}
+
+/* loadClusterStates restores the per-cluster state of the CGU from its cluster states ConfigMaps, if it
+   was stored there
+
+   returns: error/nil
+*/
+func (r *ClusterGroupUpgradeReconciler) loadClusterStates(
+	ctx context.Context, clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade) error {
+
+	if clusterGroupUpgrade.Status.ClusterStates == nil {
+		return nil
+	}
+	var shards []map[string]string
+	for _, ref := range clusterGroupUpgrade.Status.ClusterStates.ConfigMaps {
+		configMap, err := r.getClusterStatesConfigMap(ctx, clusterGroupUpgrade.Namespace, ref)
+		if err != nil {
+			return fmt.Errorf("cannot read the cluster states ConfigMap %s: %s", ref.Name, err)
+		}
+		shards = append(shards, configMap.Data)
+	}
+	return mergeClusterStates(&clusterGroupUpgrade.Status, shards)
+}
+
+/* storeClusterStates writes the per-cluster state of the CGU to its cluster states ConfigMaps when the operator
+   is configured to, or when the CGU already uses them. The ConfigMaps are only written if their data changed.
+   When the operator is configured back to store the state inline, the CGU stops using the ConfigMaps.
+
+   returns: *ranv1alpha1.ClusterGroupUpgradeStatus the status to write in the CGU
+            error
+*/
+func (r *ClusterGroupUpgradeReconciler) storeClusterStates(
+	ctx context.Context, clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade) (*ranv1alpha1.ClusterGroupUpgradeStatus, error) {
+
+	if !r.isClusterStateStorageEnabled() {
+		status := clusterGroupUpgrade.Status.DeepCopy()
+		status.ClusterStates = nil
+		return status, nil
+	}
+
+	var previousRefs []ranv1alpha1.ClusterStateConfigMap
+	if clusterGroupUpgrade.Status.ClusterStates != nil {
+		previousRefs = clusterGroupUpgrade.Status.ClusterStates.ConfigMaps
+	}
+	shardCount := getClusterStatesShardCount(&clusterGroupUpgrade.Status)
+	if shardCount < len(previousRefs) {
+		shardCount = len(previousRefs)
+	}
+
+	status, shards, err := splitClusterStatesBySize(&clusterGroupUpgrade.Status, shardCount)
+	if err != nil {
+		return nil, err
+	}
+	for i, data := range shards {
+		ref := ranv1alpha1.ClusterStateConfigMap{Name: clusterStatesConfigMapName(clusterGroupUpgrade, i)}
+		if i < len(previousRefs) {
+			ref = previousRefs[i]
+		}
+		ref.ResourceVersion, err = r.writeClusterStatesConfigMap(ctx, clusterGroupUpgrade, ref, data)
+		if err != nil {
+			return nil, err
+		}
+		status.ClusterStates.ConfigMaps = append(status.ClusterStates.ConfigMaps, ref)
+	}
+	return &status, nil
+}
+
+/* writeClusterStatesConfigMap creates or updates a cluster states ConfigMap of the CGU with the given data
+
+   returns: string the resourceVersion of the ConfigMap
+            error
+*/
+func (r *ClusterGroupUpgradeReconciler) writeClusterStatesConfigMap(ctx context.Context,
+	clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade, ref ranv1alpha1.ClusterStateConfigMap,
+	data map[string]string) (string, error) {
+
+	configMap, err := r.getClusterStatesConfigMap(ctx, clusterGroupUpgrade.Namespace, ref)
+	if err != nil {
+		if !errors.IsNotFound(err) {
+			return "", err
+		}
+		configMap = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      ref.Name,
+				Namespace: clusterGroupUpgrade.Namespace,
+				Labels: map[string]string{
+					"openshift-cluster-group-upgrades/clusterGroupUpgrade": clusterGroupUpgrade.Name,
+				},
+			},
+			Data: data,
+		}
+		if err := controllerutil.SetControllerReference(clusterGroupUpgrade, configMap, r.Scheme); err != nil {
+			return "", err
+		}
+		if err := r.Create(ctx, configMap); err != nil {
+			return "", err
+		}
+		return configMap.ResourceVersion, nil
+	}
+
+	if !metav1.IsControlledBy(configMap, clusterGroupUpgrade) {
+		return "", fmt.Errorf("the ConfigMap %s is not owned by the ClusterGroupUpgrade", ref.Name)
+	}
+	if equality.Semantic.DeepEqual(configMap.Data, data) {
+		return configMap.ResourceVersion, nil
+	}
+	configMap.Data = data
+	if err := r.Update(ctx, configMap); err != nil {
+		return "", err
+	}
+	return configMap.ResourceVersion, nil
+}
+
+/* deleteUnusedClusterStates deletes the cluster states ConfigMaps the CGU status doesn't reference anymore,
+   after the operator was configured back to store the per-cluster state inline
+
+   returns: error/nil
+*/
+func (r *ClusterGroupUpgradeReconciler) deleteUnusedClusterStates(ctx context.Context,
+	clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade, previous *ranv1alpha1.ClusterStatesStatus) error {
+
+	if previous == nil {
+		return nil
+	}
+	used := make(map[string]bool)
+	if clusterGroupUpgrade.Status.ClusterStates != nil {
+		for _, ref := range clusterGroupUpgrade.Status.ClusterStates.ConfigMaps {
+			used[ref.Name] = true
+		}
+	}
+	for _, ref := range previous.ConfigMaps {
+		if used[ref.Name] {
+			continue
+		}
+		configMap := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: ref.Name, Namespace: clusterGroupUpgrade.Namespace},
+		}
+		if err := r.Delete(ctx, configMap); client.IgnoreNotFound(err) != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,213 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/go-logr/logr"
+	ranv1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func getClusterStatesTestStatus(clusterCount int) ranv1alpha1.ClusterGroupUpgradeStatus {
+	status := ranv1alpha1.ClusterGroupUpgradeStatus{
+		ManagedPoliciesNs: map[string]string{"policy1": "default"},
+		SafeResourceNames: map[string]string{"cgu-policy1-placement": "cgu-policy1-placement-kpqz2"},
+		Precaching: &ranv1alpha1.PrecachingStatus{
+			Specs: map[string]*ranv1alpha1.PrecachingSpec{
+				"spec-1": {PlatformImage: "quay.io/release:4.10"},
+				"spec-2": {PlatformImage: "quay.io/release:4.11"},
+			},
+			Status:       make(map[string]string),
+			ClusterSpecs: make(map[string]string),
+		},
+		Backup: &ranv1alpha1.BackupStatus{Status: make(map[string]string)},
+	}
+	var batch []string
+	for i := 0; i < clusterCount; i++ {
+		cluster := fmt.Sprintf("spoke%d", i)
+		batch = append(batch, cluster)
+		if len(batch) == 10 || i == clusterCount-1 {
+			status.RemediationPlan = append(status.RemediationPlan, batch)
+			batch = nil
+		}
+		status.Precaching.Clusters = append(status.Precaching.Clusters, cluster)
+		status.Precaching.Status[cluster] = PrecacheStateSucceeded
+		status.Precaching.ClusterSpecs[cluster] = fmt.Sprintf("spec-%d", i%2+1)
+		if i%100 == 0 {
+			if status.Precaching.Attempts == nil {
+				status.Precaching.Attempts = make(map[string]*ranv1alpha1.PrecachingAttempts)
+			}
+			status.Precaching.Attempts[cluster] = &ranv1alpha1.PrecachingAttempts{Count: 2}
+		}
+		if i%200 == 0 {
+			if status.Precaching.Summaries == nil {
+				status.Precaching.Summaries = make(map[string]*ranv1alpha1.PrecachingSummary)
+			}
+			status.Precaching.Summaries[cluster] = &ranv1alpha1.PrecachingSummary{Total: 10, Pulled: 10, Bytes: 4096}
+		}
+		if i%3 == 0 {
+			status.Backup.Clusters = append(status.Backup.Clusters, cluster)
+			status.Backup.Status[cluster] = BackupStateActive
+		}
+		status.SafeResourceNames["view-precache-"+cluster] = "view-precache-" + cluster + "-kpqz2"
+	}
+	return status
+}
+
+func TestClusterStates_splitAndMerge(t *testing.T) {
+	status := getClusterStatesTestStatus(1200)
+	shardCount := getClusterStatesShardCount(&status)
+	assert.Equal(t, 3, shardCount)
+
+	inlineStatus, shards, err := splitClusterStates(&status, shardCount)
+	assert.NoError(t, err)
+	assert.Len(t, shards, 3)
+	assert.Nil(t, inlineStatus.RemediationPlan)
+	assert.Nil(t, inlineStatus.SafeResourceNames)
+	assert.Nil(t, inlineStatus.Precaching.Clusters)
+	assert.Nil(t, inlineStatus.Precaching.Status)
+	assert.Nil(t, inlineStatus.Precaching.Attempts)
+	assert.Nil(t, inlineStatus.Precaching.Summaries)
+	assert.Nil(t, inlineStatus.Precaching.ClusterSpecs)
+	assert.Equal(t, status.Precaching.Specs, inlineStatus.Precaching.Specs)
+	assert.Nil(t, inlineStatus.Backup.Status)
+	assert.Equal(t, status.ManagedPoliciesNs, inlineStatus.ManagedPoliciesNs)
+	assert.Equal(t, &ranv1alpha1.ClusterStatesStatus{
+		Clusters:   1200,
+		Batches:    120,
+		Precaching: map[string]int{PrecacheStateSucceeded: 1200},
+		Backup:     map[string]int{BackupStateActive: 400},
+	}, inlineStatus.ClusterStates)
+
+	assert.NoError(t, mergeClusterStates(&inlineStatus, shards))
+	inlineStatus.ClusterStates = nil
+	assert.Equal(t, status, inlineStatus)
+}
+
+func TestClusterStates_splitBySize(t *testing.T) {
+	status := getClusterStatesTestStatus(500)
+	status.Precaching.Summaries = make(map[string]*ranv1alpha1.PrecachingSummary)
+	reason := strings.Repeat("x", 256)
+	for _, cluster := range status.Precaching.Clusters {
+		summary := &ranv1alpha1.PrecachingSummary{Total: 10, Failed: 10}
+		for i := 0; i < 10; i++ {
+			summary.Failures = append(summary.Failures, ranv1alpha1.PrecachingImageFailure{
+				Image: fmt.Sprintf("quay.io/image%d:latest", i), Reason: reason})
+		}
+		status.Precaching.Summaries[cluster] = summary
+	}
+	shardCount := getClusterStatesShardCount(&status)
+	assert.Equal(t, 1, shardCount)
+
+	inlineStatus, shards, err := splitClusterStatesBySize(&status, shardCount)
+	assert.NoError(t, err)
+	assert.Greater(t, len(shards), 2)
+	for i, data := range shards {
+		assert.LessOrEqual(t, getClusterStatesDataSize(data), maxClusterStatesConfigMapBytes, i)
+	}
+	assert.NoError(t, mergeClusterStates(&inlineStatus, shards))
+	inlineStatus.ClusterStates = nil
+	assert.Equal(t, status, inlineStatus)
+
+	// The number of ConfigMaps is only increased when needed
+	_, shards, err = splitClusterStatesBySize(&status, 20)
+	assert.NoError(t, err)
+	assert.Len(t, shards, 20)
+}
+
+func TestClusterStates_updateStatus(t *testing.T) {
+	key := types.NamespacedName{Name: "cgu", Namespace: "default"}
+	cgu := &ranv1alpha1.ClusterGroupUpgrade{
+		ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+	}
+	fakeClient, err := getFakeClientFromObjects(cgu)
+	if err != nil {
+		t.Errorf("error in creating fake client")
+	}
+	r := &ClusterGroupUpgradeReconciler{Client: fakeClient, Log: logr.Discard(), Scheme: testscheme}
+	r.operatorConfig.ClusterStateStorage = ranv1alpha1.ClusterStateStorage.ConfigMaps
+
+	assert.NoError(t, r.getClusterGroupUpgrade(context.TODO(), key, cgu))
+	cgu.Status = getClusterStatesTestStatus(700)
+	expectedStatus := cgu.Status.DeepCopy()
+	assert.NoError(t, r.updateStatus(context.TODO(), cgu))
+
+	// The CGU only keeps the counts of the per-cluster state
+	stored := &ranv1alpha1.ClusterGroupUpgrade{}
+	assert.NoError(t, fakeClient.Get(context.TODO(), key, stored))
+	assert.Nil(t, stored.Status.RemediationPlan)
+	assert.Nil(t, stored.Status.SafeResourceNames)
+	assert.Equal(t, 700, stored.Status.ClusterStates.Clusters)
+	assert.Len(t, stored.Status.ClusterStates.ConfigMaps, 2)
+	for i, ref := range stored.Status.ClusterStates.ConfigMaps {
+		assert.Equal(t, fmt.Sprintf("cgu-cluster-states-%d", i), ref.Name)
+		configMap := &corev1.ConfigMap{}
+		assert.NoError(t, fakeClient.Get(context.TODO(), types.NamespacedName{Name: ref.Name, Namespace: "default"}, configMap))
+		assert.Equal(t, ref.ResourceVersion, configMap.ResourceVersion)
+		assert.True(t, metav1.IsControlledBy(configMap, stored))
+	}
+	// The in-memory status is left whole
+	assert.Equal(t, expectedStatus.RemediationPlan, cgu.Status.RemediationPlan)
+
+	// The status is restored from the ConfigMaps when the CGU is read
+	loaded := &ranv1alpha1.ClusterGroupUpgrade{}
+	assert.NoError(t, r.getClusterGroupUpgrade(context.TODO(), key, loaded))
+	expectedStatus.ClusterStates = stored.Status.ClusterStates
+	assert.Equal(t, *expectedStatus, loaded.Status)
+
+	// Only the ConfigMap of the changed cluster is written
+	versions := stored.Status.ClusterStates.ConfigMaps
+	loaded.Status.Backup.Status["spoke1"] = BackupStateSucceeded
+	assert.NoError(t, r.updateStatus(context.TODO(), loaded))
+	changed := 0
+	for i, ref := range loaded.Status.ClusterStates.ConfigMaps {
+		if ref.ResourceVersion != versions[i].ResourceVersion {
+			changed++
+		}
+	}
+	assert.Equal(t, 1, changed)
+	assert.Equal(t, map[string]int{BackupStateActive: 234, BackupStateSucceeded: 1}, loaded.Status.ClusterStates.Backup)
+
+	// The state moves back into the status when the storage is disabled
+	r.operatorConfig.ClusterStateStorage = ranv1alpha1.ClusterStateStorage.Inline
+	assert.NoError(t, r.updateStatus(context.TODO(), loaded))
+	stored = &ranv1alpha1.ClusterGroupUpgrade{}
+	assert.NoError(t, fakeClient.Get(context.TODO(), key, stored))
+	assert.Nil(t, stored.Status.ClusterStates)
+	assert.Equal(t, expectedStatus.RemediationPlan, stored.Status.RemediationPlan)
+	assert.Equal(t, BackupStateSucceeded, stored.Status.Backup.Status["spoke1"])
+	for _, ref := range versions {
+		err := fakeClient.Get(context.TODO(), types.NamespacedName{Name: ref.Name, Namespace: "default"}, &corev1.ConfigMap{})
+		assert.True(t, errors.IsNotFound(err))
+	}
+}
+
+func TestClusterStates_getClusterStateCounts(t *testing.T) {
+	cgu := &ranv1alpha1.ClusterGroupUpgrade{
+		Status: ranv1alpha1.ClusterGroupUpgradeStatus{
+			Precaching: &ranv1alpha1.PrecachingStatus{
+				Status: map[string]string{"spoke1": PrecacheStateActive, "spoke2": PrecacheStateNotStarted},
+			},
+		},
+	}
+	assert.Equal(t, map[string]int{PrecacheStateActive: 1, PrecacheStateNotStarted: 1},
+		getClusterStateCounts(cgu, capacityPrecaching))
+	assert.Empty(t, getClusterStateCounts(cgu, capacityBackup))
+
+	// A CGU listed from the cache only has the counts
+	cgu.Status.Precaching.Status = nil
+	cgu.Status.ClusterStates = &ranv1alpha1.ClusterStatesStatus{
+		Precaching: map[string]int{PrecacheStateActive: 3},
+		Backup:     map[string]int{BackupStatePreparingToStart: 1},
+	}
+	assert.Equal(t, 3, clustersUsingCapacity(cgu, capacityPrecaching))
+	assert.True(t, isWaitingForCapacity(cgu, capacityBackup))
+	assert.False(t, isWaitingForCapacity(cgu, capacityPrecaching))
+}
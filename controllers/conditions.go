@@ -0,0 +1,215 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"fmt"
+	"sort"
+
+	ranv1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/api/v1alpha1"
+	"github.com/openshift-kni/cluster-group-upgrades-operator/pkg/cguutil"
+	utils "github.com/openshift-kni/cluster-group-upgrades-operator/controllers/utils"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// conditionsOrder is the order in which the conditions are listed in the CGU status,
+// the conditions of other types follow in the order they were added
+var conditionsOrder = []string{
+	ranv1alpha1.ConditionTypes.ClustersSelected,
+	ranv1alpha1.ConditionTypes.Validated,
+	ranv1alpha1.ConditionTypes.PrecachingSucceeded,
+	ranv1alpha1.ConditionTypes.BackupSucceeded,
+	ranv1alpha1.ConditionTypes.Progressing,
+	ranv1alpha1.ConditionTypes.Succeeded,
+	ranv1alpha1.ConditionTypes.Failed,
+}
+
+// setCondition sets a condition of the CGU for its current generation
+func setCondition(clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade,
+	conditionType string, status metav1.ConditionStatus, reason, message string) {
+
+	meta.SetStatusCondition(&clusterGroupUpgrade.Status.Conditions, metav1.Condition{
+		Type:               conditionType,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: clusterGroupUpgrade.Generation,
+	})
+}
+
+// isCguSucceeded returns true if the CGU has completed successfully.
+// CGUs last updated by an older operator version only have the Ready condition.
+func isCguSucceeded(clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade) bool {
+	return cguutil.IsSucceeded(clusterGroupUpgrade)
+}
+
+// isCguFinished returns true if the CGU has either succeeded or failed
+func isCguFinished(clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade) bool {
+	return cguutil.IsTerminal(clusterGroupUpgrade)
+}
+
+// getClustersInStates returns the sorted list of clusters in one of the given states
+func getClustersInStates(clusterStates map[string]string, states ...string) []string {
+	var clusters []string
+	for cluster, clusterState := range clusterStates {
+		for _, state := range states {
+			if clusterState == state {
+				clusters = append(clusters, cluster)
+				break
+			}
+		}
+	}
+	sort.Strings(clusters)
+	return clusters
+}
+
+// updatePrecachingCondition derives the PrecachingSucceeded condition from the pre-caching state
+func updatePrecachingCondition(clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade) {
+	if !clusterGroupUpgrade.Spec.PreCaching {
+		meta.RemoveStatusCondition(&clusterGroupUpgrade.Status.Conditions, ranv1alpha1.ConditionTypes.PrecachingSucceeded)
+		return
+	}
+	if meta.IsStatusConditionTrue(clusterGroupUpgrade.Status.Conditions, "PrecachingDone") {
+		setCondition(clusterGroupUpgrade, ranv1alpha1.ConditionTypes.PrecachingSucceeded, metav1.ConditionTrue,
+			ranv1alpha1.ConditionReasons.PrecachingCompleted, "Precaching is completed for all clusters")
+		return
+	}
+	var failedClusters []string
+	if clusterGroupUpgrade.Status.Precaching != nil {
+		failedClusters = getClustersInStates(clusterGroupUpgrade.Status.Precaching.Status,
+			PrecacheStateTimeout, PrecacheStateError, PrecacheStateInsufficientSpace)
+	}
+	if len(failedClusters) != 0 {
+		setCondition(clusterGroupUpgrade, ranv1alpha1.ConditionTypes.PrecachingSucceeded, metav1.ConditionFalse,
+			ranv1alpha1.ConditionReasons.PrecachingFailed, fmt.Sprintf("Precaching failed for clusters: %v", failedClusters))
+		return
+	}
+	setCondition(clusterGroupUpgrade, ranv1alpha1.ConditionTypes.PrecachingSucceeded, metav1.ConditionFalse,
+		ranv1alpha1.ConditionReasons.PrecachingInProgress, "Precaching is in progress")
+}
+
+// updateBackupCondition derives the BackupSucceeded condition from the backup state
+func updateBackupCondition(clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade) {
+	if !clusterGroupUpgrade.Spec.Backup {
+		meta.RemoveStatusCondition(&clusterGroupUpgrade.Status.Conditions, ranv1alpha1.ConditionTypes.BackupSucceeded)
+		return
+	}
+	if meta.IsStatusConditionTrue(clusterGroupUpgrade.Status.Conditions, BackupStateDone) {
+		setCondition(clusterGroupUpgrade, ranv1alpha1.ConditionTypes.BackupSucceeded, metav1.ConditionTrue,
+			ranv1alpha1.ConditionReasons.BackupCompleted, "Backup is completed for all clusters")
+		return
+	}
+	var failedClusters []string
+	if clusterGroupUpgrade.Status.Backup != nil {
+		failedClusters = getClustersInStates(clusterGroupUpgrade.Status.Backup.Status,
+			BackupStateTimeout, BackupStateError)
+	}
+	if len(failedClusters) != 0 {
+		setCondition(clusterGroupUpgrade, ranv1alpha1.ConditionTypes.BackupSucceeded, metav1.ConditionFalse,
+			ranv1alpha1.ConditionReasons.BackupFailed, fmt.Sprintf("Backup failed for clusters: %v", failedClusters))
+		return
+	}
+	setCondition(clusterGroupUpgrade, ranv1alpha1.ConditionTypes.BackupSucceeded, metav1.ConditionFalse,
+		ranv1alpha1.ConditionReasons.BackupInProgress, "Backup is in progress")
+}
+
+// updateProgressingConditions derives the Progressing, Succeeded and Failed conditions from the Ready condition
+// and from the state of the backup and pre-caching
+func updateProgressingConditions(clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade) {
+	conditions := clusterGroupUpgrade.Status.Conditions
+	readyCondition := meta.FindStatusCondition(conditions, ranv1alpha1.ConditionTypes.Ready)
+	readyReason := ""
+	if readyCondition != nil {
+		readyReason = readyCondition.Reason
+	}
+	var invalidCondition *metav1.Condition
+	for _, conditionType := range []string{ranv1alpha1.ConditionTypes.ClustersSelected, ranv1alpha1.ConditionTypes.Validated} {
+		if condition := meta.FindStatusCondition(conditions, conditionType); condition != nil &&
+			condition.Status == metav1.ConditionFalse {
+			invalidCondition = condition
+			break
+		}
+	}
+
+	switch {
+	case readyReason == "UpgradeCompleted":
+		setCondition(clusterGroupUpgrade, ranv1alpha1.ConditionTypes.Progressing, metav1.ConditionFalse,
+			ranv1alpha1.ConditionReasons.Completed, readyCondition.Message)
+		setCondition(clusterGroupUpgrade, ranv1alpha1.ConditionTypes.Succeeded, metav1.ConditionTrue,
+			ranv1alpha1.ConditionReasons.Completed, readyCondition.Message)
+		return
+	case readyReason == "UpgradeTimedOut":
+		setCondition(clusterGroupUpgrade, ranv1alpha1.ConditionTypes.Progressing, metav1.ConditionFalse,
+			ranv1alpha1.ConditionReasons.TimedOut, readyCondition.Message)
+		setCondition(clusterGroupUpgrade, ranv1alpha1.ConditionTypes.Failed, metav1.ConditionTrue,
+			ranv1alpha1.ConditionReasons.TimedOut, readyCondition.Message)
+		return
+	case readyReason == ranv1alpha1.ConditionReasons.PrecachingFailed:
+		setCondition(clusterGroupUpgrade, ranv1alpha1.ConditionTypes.Progressing, metav1.ConditionFalse,
+			ranv1alpha1.ConditionReasons.PrecachingFailed, readyCondition.Message)
+		setCondition(clusterGroupUpgrade, ranv1alpha1.ConditionTypes.Failed, metav1.ConditionTrue,
+			ranv1alpha1.ConditionReasons.PrecachingFailed, readyCondition.Message)
+		return
+	case readyReason == "UpgradeNotCompleted":
+		setCondition(clusterGroupUpgrade, ranv1alpha1.ConditionTypes.Progressing, metav1.ConditionTrue,
+			ranv1alpha1.ConditionReasons.InProgress, "Remediating non-compliant policies")
+	case invalidCondition != nil:
+		setCondition(clusterGroupUpgrade, ranv1alpha1.ConditionTypes.Progressing, metav1.ConditionFalse,
+			ranv1alpha1.ConditionReasons.ValidationFailed, invalidCondition.Message)
+	case clusterGroupUpgrade.Spec.Backup && !meta.IsStatusConditionTrue(conditions, BackupStateDone):
+		setCondition(clusterGroupUpgrade, ranv1alpha1.ConditionTypes.Progressing, metav1.ConditionTrue,
+			ranv1alpha1.ConditionReasons.BackupInProgress, "Backup is in progress")
+	case clusterGroupUpgrade.Spec.PreCaching && !meta.IsStatusConditionTrue(conditions, "PrecachingDone"):
+		setCondition(clusterGroupUpgrade, ranv1alpha1.ConditionTypes.Progressing, metav1.ConditionTrue,
+			ranv1alpha1.ConditionReasons.PrecachingInProgress, "Precaching is in progress")
+	case readyReason == utils.CannotStart:
+		setCondition(clusterGroupUpgrade, ranv1alpha1.ConditionTypes.Progressing, metav1.ConditionFalse,
+			ranv1alpha1.ConditionReasons.Blocked, readyCondition.Message)
+	case readyReason == "UpgradeNotStarted":
+		if clusterGroupUpgrade.Spec.Enable != nil && *clusterGroupUpgrade.Spec.Enable {
+			setCondition(clusterGroupUpgrade, ranv1alpha1.ConditionTypes.Progressing, metav1.ConditionFalse,
+				ranv1alpha1.ConditionReasons.NotStarted, "The ClusterGroupUpgrade CR has not started yet")
+		} else {
+			setCondition(clusterGroupUpgrade, ranv1alpha1.ConditionTypes.Progressing, metav1.ConditionFalse,
+				ranv1alpha1.ConditionReasons.NotEnabled, "The ClusterGroupUpgrade CR is not enabled")
+		}
+	}
+	meta.RemoveStatusCondition(&clusterGroupUpgrade.Status.Conditions, ranv1alpha1.ConditionTypes.Succeeded)
+	meta.RemoveStatusCondition(&clusterGroupUpgrade.Status.Conditions, ranv1alpha1.ConditionTypes.Failed)
+}
+
+// updateConditions derives the PrecachingSucceeded, BackupSucceeded, Progressing, Succeeded and Failed conditions
+// from the current state of the CGU and sorts the conditions in a stable order
+func updateConditions(clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade) {
+	updatePrecachingCondition(clusterGroupUpgrade)
+	updateBackupCondition(clusterGroupUpgrade)
+	updateProgressingConditions(clusterGroupUpgrade)
+
+	conditions := make([]metav1.Condition, 0, len(clusterGroupUpgrade.Status.Conditions))
+	for _, conditionType := range conditionsOrder {
+		if condition := meta.FindStatusCondition(clusterGroupUpgrade.Status.Conditions, conditionType); condition != nil {
+			conditions = append(conditions, *condition)
+		}
+	}
+	for _, condition := range clusterGroupUpgrade.Status.Conditions {
+		if meta.FindStatusCondition(conditions, condition.Type) == nil {
+			conditions = append(conditions, condition)
+		}
+	}
+	clusterGroupUpgrade.Status.Conditions = conditions
+}
@@ -0,0 +1,167 @@
+package controllers
+
+import (
+	"testing"
+
+	ranv1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestConditions_updateConditions(t *testing.T) {
+	enable := true
+	disable := false
+	testcases := []struct {
+		name                string
+		enable              *bool
+		backup              bool
+		precaching          bool
+		conditions          []metav1.Condition
+		precachingStatus    map[string]string
+		expectedTypes       []string
+		expectedProgressing string
+		expectedPrecaching  string
+	}{
+		{
+			name:   "not enabled",
+			enable: &disable,
+			conditions: []metav1.Condition{
+				{Type: "Ready", Status: metav1.ConditionFalse, Reason: "UpgradeNotStarted"},
+				{Type: "ClustersSelected", Status: metav1.ConditionTrue, Reason: "ClusterSelectionCompleted"},
+			},
+			expectedTypes:       []string{"ClustersSelected", "Progressing", "Ready"},
+			expectedProgressing: ranv1alpha1.ConditionReasons.NotEnabled,
+		},
+		{
+			name:   "blocked",
+			enable: &enable,
+			conditions: []metav1.Condition{
+				{Type: "Ready", Status: metav1.ConditionFalse, Reason: "UpgradeCannotStart", Message: "blocked"},
+				{Type: "Validated", Status: metav1.ConditionTrue, Reason: "ValidationCompleted"},
+			},
+			expectedTypes:       []string{"Validated", "Progressing", "Ready"},
+			expectedProgressing: ranv1alpha1.ConditionReasons.Blocked,
+		},
+		{
+			name:   "missing managed policies",
+			enable: &enable,
+			conditions: []metav1.Condition{
+				{Type: "Ready", Status: metav1.ConditionFalse, Reason: "UpgradeCannotStart"},
+				{Type: "Validated", Status: metav1.ConditionFalse, Reason: "NotAllManagedPoliciesExist"},
+			},
+			expectedTypes:       []string{"Validated", "Progressing", "Ready"},
+			expectedProgressing: ranv1alpha1.ConditionReasons.ValidationFailed,
+		},
+		{
+			name:       "precaching in progress",
+			enable:     &disable,
+			precaching: true,
+			conditions: []metav1.Condition{
+				{Type: "Ready", Status: metav1.ConditionFalse, Reason: "PrecachingRequired"},
+				{Type: "PrecachingDone", Status: metav1.ConditionFalse, Reason: "PrecachingNotDone"},
+			},
+			precachingStatus:    map[string]string{"spoke1": PrecacheStateActive, "spoke2": PrecacheStateSucceeded},
+			expectedTypes:       []string{"PrecachingSucceeded", "Progressing", "Ready", "PrecachingDone"},
+			expectedProgressing: ranv1alpha1.ConditionReasons.PrecachingInProgress,
+			expectedPrecaching:  ranv1alpha1.ConditionReasons.PrecachingInProgress,
+		},
+		{
+			name:       "precaching failed",
+			enable:     &disable,
+			precaching: true,
+			conditions: []metav1.Condition{
+				{Type: "Ready", Status: metav1.ConditionFalse, Reason: "PrecachingRequired"},
+				{Type: "PrecachingDone", Status: metav1.ConditionFalse, Reason: "PrecachingNotDone"},
+			},
+			precachingStatus:    map[string]string{"spoke1": PrecacheStateTimeout, "spoke2": PrecacheStateSucceeded},
+			expectedTypes:       []string{"PrecachingSucceeded", "Progressing", "Ready", "PrecachingDone"},
+			expectedProgressing: ranv1alpha1.ConditionReasons.PrecachingInProgress,
+			expectedPrecaching:  ranv1alpha1.ConditionReasons.PrecachingFailed,
+		},
+		{
+			name:       "standalone precaching failed",
+			enable:     &disable,
+			precaching: true,
+			conditions: []metav1.Condition{
+				{Type: "Ready", Status: metav1.ConditionFalse, Reason: "PrecachingFailed"},
+				{Type: "PrecachingDone", Status: metav1.ConditionFalse, Reason: "PrecachingNotDone"},
+			},
+			precachingStatus:    map[string]string{"spoke1": PrecacheStateError, "spoke2": PrecacheStateSucceeded},
+			expectedTypes:       []string{"PrecachingSucceeded", "Progressing", "Failed", "Ready", "PrecachingDone"},
+			expectedProgressing: ranv1alpha1.ConditionReasons.PrecachingFailed,
+			expectedPrecaching:  ranv1alpha1.ConditionReasons.PrecachingFailed,
+		},
+		{
+			name:   "backup in progress",
+			enable: &enable,
+			backup: true,
+			conditions: []metav1.Condition{
+				{Type: "BackupDone", Status: metav1.ConditionFalse, Reason: "BackupNotDone"},
+			},
+			expectedTypes:       []string{"BackupSucceeded", "Progressing", "BackupDone"},
+			expectedProgressing: ranv1alpha1.ConditionReasons.BackupInProgress,
+		},
+		{
+			name:   "in progress",
+			enable: &enable,
+			conditions: []metav1.Condition{
+				{Type: "Ready", Status: metav1.ConditionFalse, Reason: "UpgradeNotCompleted"},
+				{Type: "Progressing", Status: metav1.ConditionFalse, Reason: "NotEnabled"},
+			},
+			expectedTypes:       []string{"Progressing", "Ready"},
+			expectedProgressing: ranv1alpha1.ConditionReasons.InProgress,
+		},
+		{
+			name:   "completed",
+			enable: &enable,
+			conditions: []metav1.Condition{
+				{Type: "Ready", Status: metav1.ConditionTrue, Reason: "UpgradeCompleted"},
+			},
+			expectedTypes:       []string{"Progressing", "Succeeded", "Ready"},
+			expectedProgressing: ranv1alpha1.ConditionReasons.Completed,
+		},
+		{
+			name:   "timed out",
+			enable: &enable,
+			conditions: []metav1.Condition{
+				{Type: "Ready", Status: metav1.ConditionFalse, Reason: "UpgradeTimedOut"},
+			},
+			expectedTypes:       []string{"Progressing", "Failed", "Ready"},
+			expectedProgressing: ranv1alpha1.ConditionReasons.TimedOut,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			cgu := &ranv1alpha1.ClusterGroupUpgrade{
+				ObjectMeta: metav1.ObjectMeta{Name: "test", Namespace: "default", Generation: 2},
+				Spec: ranv1alpha1.ClusterGroupUpgradeSpec{
+					Enable:     tc.enable,
+					Backup:     tc.backup,
+					PreCaching: tc.precaching,
+				},
+				Status: ranv1alpha1.ClusterGroupUpgradeStatus{Conditions: tc.conditions},
+			}
+			if tc.precachingStatus != nil {
+				cgu.Status.Precaching = &ranv1alpha1.PrecachingStatus{Status: tc.precachingStatus}
+			}
+			updateConditions(cgu)
+
+			var types []string
+			for _, condition := range cgu.Status.Conditions {
+				types = append(types, condition.Type)
+			}
+			assert.Equal(t, tc.expectedTypes, types)
+
+			progressing := meta.FindStatusCondition(cgu.Status.Conditions, ranv1alpha1.ConditionTypes.Progressing)
+			assert.NotNil(t, progressing)
+			assert.Equal(t, tc.expectedProgressing, progressing.Reason)
+			assert.Equal(t, int64(2), progressing.ObservedGeneration)
+			if tc.expectedPrecaching != "" {
+				precaching := meta.FindStatusCondition(cgu.Status.Conditions, ranv1alpha1.ConditionTypes.PrecachingSucceeded)
+				assert.NotNil(t, precaching)
+				assert.Equal(t, tc.expectedPrecaching, precaching.Reason)
+			}
+		})
+	}
+}
@@ -0,0 +1,180 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	ranv1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+// toJSON renders a value of the template data as JSON, which is valid inline YAML
+// returns: string, error
+func toJSON(v interface{}) (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// privilegedSCCRule allows the job pods to run privileged with the host filesystem mounted
+var privilegedSCCRule = rbacv1.PolicyRule{
+	APIGroups:     []string{"security.openshift.io"},
+	Resources:     []string{"securitycontextconstraints"},
+	ResourceNames: []string{"privileged"},
+	Verbs:         []string{"use"},
+}
+
+// defaultPrecacheClusterRoleRules are the rules of the pre-caching job ClusterRole when the job settings
+// define none. The pre-cache-summary ConfigMap is written through a Role of the pre-caching namespace.
+var defaultPrecacheClusterRoleRules = []rbacv1.PolicyRule{privilegedSCCRule}
+
+// defaultBackupClusterRoleRules are the rules of the backup job ClusterRole when the job settings define
+// none. The backup reads the files of the MachineConfigs.
+var defaultBackupClusterRoleRules = []rbacv1.PolicyRule{
+	privilegedSCCRule,
+	{
+		APIGroups: []string{"machineconfiguration.openshift.io"},
+		Resources: []string{"machineconfigs"},
+		Verbs:     []string{"get", "list"},
+	},
+}
+
+// setJobSettings sets the job settings of the operator configuration in the template data.
+// The active deadline of the settings replaces the one derived from the CGU timeout, and the default
+// ClusterRole rules of the job are used unless the settings define rules or bind cluster-admin.
+func (data *templateData) setJobSettings(job *ranv1alpha1.JobSettings, defaultRules []rbacv1.PolicyRule) {
+	if job != nil {
+		data.Job = *job.DeepCopy()
+		if job.ActiveDeadlineSeconds != nil {
+			data.JobTimeout = uint64(*job.ActiveDeadlineSeconds)
+		}
+	}
+	if !data.Job.ClusterAdmin && len(data.Job.ClusterRoleRules) == 0 {
+		data.Job.ClusterRoleRules = defaultRules
+	}
+}
+
+// withClusterRoleTemplate prepends the ClusterRole template to the dependencies of a job, unless its
+// settings bind cluster-admin
+// returns: []resourceTemplate
+func withClusterRoleTemplate(
+	data *templateData, clusterRole resourceTemplate, dependencies []resourceTemplate) []resourceTemplate {
+
+	if data.Job.ClusterAdmin {
+		return dependencies
+	}
+	return append([]resourceTemplate{clusterRole}, dependencies...)
+}
+
+/* validateJobSettings validates the job settings rendered in the job templates, so that a misconfigured
+   operator configuration is reported by the operator rather than by the spoke clusters
+   returns: error
+*/
+func validateJobSettings(job *ranv1alpha1.JobSettings) error {
+	if job.PriorityClassName != "" {
+		if errs := validation.IsDNS1123Subdomain(job.PriorityClassName); len(errs) != 0 {
+			return fmt.Errorf("invalid priorityClassName %s: %s", job.PriorityClassName, strings.Join(errs, ", "))
+		}
+	}
+	for key, value := range job.NodeSelector {
+		if errs := validation.IsQualifiedName(key); len(errs) != 0 {
+			return fmt.Errorf("invalid nodeSelector key %s: %s", key, strings.Join(errs, ", "))
+		}
+		if errs := validation.IsValidLabelValue(value); len(errs) != 0 {
+			return fmt.Errorf("invalid nodeSelector value %s: %s", value, strings.Join(errs, ", "))
+		}
+	}
+	for i := range job.Tolerations {
+		if err := validateToleration(&job.Tolerations[i]); err != nil {
+			return fmt.Errorf("invalid toleration %d: %v", i, err)
+		}
+	}
+	envNames := make(map[string]bool)
+	for _, env := range job.Env {
+		if errs := validation.IsEnvVarName(env.Name); len(errs) != 0 {
+			return fmt.Errorf("invalid env name %s: %s", env.Name, strings.Join(errs, ", "))
+		}
+		if envNames[env.Name] {
+			return fmt.Errorf("duplicate env name %s", env.Name)
+		}
+		envNames[env.Name] = true
+	}
+	if job.Resources != nil {
+		for name, request := range job.Resources.Requests {
+			limit, ok := job.Resources.Limits[name]
+			if ok && request.Cmp(limit) > 0 {
+				return fmt.Errorf("the %s request %s exceeds its limit %s", name, request.String(), limit.String())
+			}
+		}
+	}
+	if job.ActiveDeadlineSeconds != nil && *job.ActiveDeadlineSeconds < 1 {
+		return fmt.Errorf("activeDeadlineSeconds must be positive")
+	}
+	if job.ClusterAdmin && len(job.ClusterRoleRules) != 0 {
+		return fmt.Errorf("clusterRoleRules can't be set along with clusterAdmin")
+	}
+	for i, rule := range job.ClusterRoleRules {
+		if len(rule.Verbs) == 0 {
+			return fmt.Errorf("clusterRoleRules %d has no verbs", i)
+		}
+		if len(rule.Resources) == 0 && len(rule.NonResourceURLs) == 0 {
+			return fmt.Errorf("clusterRoleRules %d has no resources or nonResourceURLs", i)
+		}
+	}
+	return nil
+}
+
+// validateToleration validates a toleration the way the API server does for a pod
+// returns: error
+func validateToleration(toleration *corev1.Toleration) error {
+	if toleration.Key != "" {
+		if errs := validation.IsQualifiedName(toleration.Key); len(errs) != 0 {
+			return fmt.Errorf("invalid key %s: %s", toleration.Key, strings.Join(errs, ", "))
+		}
+	}
+	switch toleration.Operator {
+	case corev1.TolerationOpExists:
+		if toleration.Value != "" {
+			return fmt.Errorf("value must be empty when operator is Exists")
+		}
+	case corev1.TolerationOpEqual, "":
+		if toleration.Key == "" {
+			return fmt.Errorf("operator must be Exists when key is empty")
+		}
+		if errs := validation.IsValidLabelValue(toleration.Value); len(errs) != 0 {
+			return fmt.Errorf("invalid value %s: %s", toleration.Value, strings.Join(errs, ", "))
+		}
+	default:
+		return fmt.Errorf("unsupported operator %s", toleration.Operator)
+	}
+	switch toleration.Effect {
+	case "", corev1.TaintEffectNoSchedule, corev1.TaintEffectPreferNoSchedule, corev1.TaintEffectNoExecute:
+	default:
+		return fmt.Errorf("unsupported effect %s", toleration.Effect)
+	}
+	if toleration.TolerationSeconds != nil && toleration.Effect != corev1.TaintEffectNoExecute {
+		return fmt.Errorf("tolerationSeconds requires the NoExecute effect")
+	}
+	return nil
+}
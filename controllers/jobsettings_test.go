@@ -0,0 +1,225 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	ranv1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/api/v1alpha1"
+	"github.com/openshift-kni/cluster-group-upgrades-operator/controllers/templates"
+	utils "github.com/openshift-kni/cluster-group-upgrades-operator/controllers/utils"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestJobSettings_validate(t *testing.T) {
+	zero := int64(0)
+	seconds := int64(300)
+	testcases := []struct {
+		name  string
+		job   ranv1alpha1.JobSettings
+		valid bool
+	}{
+		{
+			name:  "empty settings",
+			valid: true,
+		},
+		{
+			name: "valid settings",
+			job: ranv1alpha1.JobSettings{
+				Resources: &corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("256Mi")},
+					Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+				},
+				PriorityClassName: "system-node-critical",
+				NodeSelector:      map[string]string{"node-role.kubernetes.io/master": ""},
+				Tolerations: []corev1.Toleration{
+					{Operator: corev1.TolerationOpExists},
+					{Key: "dedicated", Value: "du", Effect: corev1.TaintEffectNoExecute, TolerationSeconds: &seconds},
+				},
+				Env:                   []corev1.EnvVar{{Name: "MAX_PULL_THREADS", Value: "4"}},
+				ActiveDeadlineSeconds: &seconds,
+				ClusterRoleRules: []rbacv1.PolicyRule{
+					{APIGroups: []string{""}, Resources: []string{"configmaps"}, Verbs: []string{"get"}}},
+			},
+			valid: true,
+		},
+		{
+			name:  "invalid priority class name",
+			job:   ranv1alpha1.JobSettings{PriorityClassName: "System_Critical"},
+			valid: false,
+		},
+		{
+			name:  "invalid node selector key",
+			job:   ranv1alpha1.JobSettings{NodeSelector: map[string]string{"role=master": ""}},
+			valid: false,
+		},
+		{
+			name:  "invalid node selector value",
+			job:   ranv1alpha1.JobSettings{NodeSelector: map[string]string{"role": "master: true"}},
+			valid: false,
+		},
+		{
+			name:  "toleration value with the Exists operator",
+			job:   ranv1alpha1.JobSettings{Tolerations: []corev1.Toleration{{Key: "a", Operator: "Exists", Value: "b"}}},
+			valid: false,
+		},
+		{
+			name:  "toleration without key with the Equal operator",
+			job:   ranv1alpha1.JobSettings{Tolerations: []corev1.Toleration{{Value: "b"}}},
+			valid: false,
+		},
+		{
+			name:  "toleration seconds without the NoExecute effect",
+			job:   ranv1alpha1.JobSettings{Tolerations: []corev1.Toleration{{Key: "a", TolerationSeconds: &seconds}}},
+			valid: false,
+		},
+		{
+			name:  "unsupported toleration effect",
+			job:   ranv1alpha1.JobSettings{Tolerations: []corev1.Toleration{{Key: "a", Effect: "NoRun"}}},
+			valid: false,
+		},
+		{
+			name:  "invalid env name",
+			job:   ranv1alpha1.JobSettings{Env: []corev1.EnvVar{{Name: "MAX PULL THREADS", Value: "4"}}},
+			valid: false,
+		},
+		{
+			name: "duplicate env name",
+			job: ranv1alpha1.JobSettings{Env: []corev1.EnvVar{
+				{Name: "MAX_PULL_THREADS", Value: "4"}, {Name: "MAX_PULL_THREADS", Value: "8"}}},
+			valid: false,
+		},
+		{
+			name: "request exceeding its limit",
+			job: ranv1alpha1.JobSettings{Resources: &corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")},
+				Limits:   corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("500m")},
+			}},
+			valid: false,
+		},
+		{
+			name:  "zero active deadline",
+			job:   ranv1alpha1.JobSettings{ActiveDeadlineSeconds: &zero},
+			valid: false,
+		},
+		{
+			name:  "cluster role rule without verbs",
+			job:   ranv1alpha1.JobSettings{ClusterRoleRules: []rbacv1.PolicyRule{{Resources: []string{"pods"}}}},
+			valid: false,
+		},
+		{
+			name: "cluster role rules along with cluster-admin",
+			job: ranv1alpha1.JobSettings{
+				ClusterAdmin:     true,
+				ClusterRoleRules: []rbacv1.PolicyRule{{Resources: []string{"pods"}, Verbs: []string{"get"}}},
+			},
+			valid: false,
+		},
+		{
+			name:  "cluster role rule without resources",
+			job:   ranv1alpha1.JobSettings{ClusterRoleRules: []rbacv1.PolicyRule{{Verbs: []string{"get"}}}},
+			valid: false,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateJobSettings(&tc.job)
+			if tc.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestJobSettings_renderInvalidSettings(t *testing.T) {
+	r := &ClusterGroupUpgradeReconciler{Log: logr.Discard()}
+	data := templateData{
+		Cluster: "test",
+		Job:     ranv1alpha1.JobSettings{Env: []corev1.EnvVar{{Name: "1THREADS", Value: "4"}}},
+	}
+	_, err := r.renderYamlTemplate("test-job-create", templates.MngClusterActCreateJob, data)
+	assert.ErrorContains(t, err, "invalid env name 1THREADS")
+}
+
+func TestJobSettings_getJobTemplateData(t *testing.T) {
+	deadline := int64(900)
+	config := &ranv1alpha1.ClusterGroupUpgradeOperatorConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: utils.OperatorConfigName},
+		Spec: ranv1alpha1.ClusterGroupUpgradeOperatorConfigSpec{
+			OperatorSettings: ranv1alpha1.OperatorSettings{
+				PrecacheImage: "quay.io/precache:latest",
+				RecoveryImage: "quay.io/recovery:latest",
+				PrecacheJob: &ranv1alpha1.JobSettings{
+					Resources: &corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")},
+					},
+				},
+				BackupJob: &ranv1alpha1.JobSettings{
+					PriorityClassName: "system-node-critical",
+					ClusterRoleRules: []rbacv1.PolicyRule{
+						{APIGroups: []string{""}, Resources: []string{"nodes"}, Verbs: []string{"get"}}},
+				},
+			},
+			NamespaceOverrides: []ranv1alpha1.NamespaceOverrides{{
+				Namespace: "ztp-install",
+				OperatorSettings: ranv1alpha1.OperatorSettings{
+					PrecacheJob: &ranv1alpha1.JobSettings{
+						Env:                   []corev1.EnvVar{{Name: "MAX_PULL_THREADS", Value: "4"}},
+						ActiveDeadlineSeconds: &deadline,
+					},
+				},
+			}},
+		},
+	}
+	fakeClient, err := getFakeClientFromObjects(config)
+	if err != nil {
+		t.Errorf("error in creating fake client")
+	}
+	r := &ClusterGroupUpgradeReconciler{Client: fakeClient, Log: logr.Discard(), Scheme: testscheme}
+
+	cgu := &ranv1alpha1.ClusterGroupUpgrade{
+		ObjectMeta: metav1.ObjectMeta{Name: "cgu", Namespace: "ztp-install"},
+		Spec: ranv1alpha1.ClusterGroupUpgradeSpec{
+			RemediationStrategy: &ranv1alpha1.RemediationStrategySpec{Timeout: 240},
+		},
+	}
+	data, err := r.getPrecacheJobTemplateData(context.TODO(), cgu, "spoke1")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(900), data.JobTimeout)
+	assert.Equal(t, []corev1.EnvVar{{Name: "MAX_PULL_THREADS", Value: "4"}}, data.Job.Env)
+	// The job settings of the namespace replace the cluster-wide ones
+	assert.Nil(t, data.Job.Resources)
+
+	data, err = r.getBackupJobTemplateData(context.TODO(), cgu, "spoke1")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(240), data.JobTimeout)
+	assert.Equal(t, "system-node-critical", data.Job.PriorityClassName)
+	assert.Equal(t, []resourceTemplate{backupClusterRoleCreateTemplate, backupDependenciesCreateTemplates[0]},
+		withClusterRoleTemplate(data, backupClusterRoleCreateTemplate, backupDependenciesCreateTemplates[:1]))
+
+	cgu.Namespace = "default"
+	data, err = r.getPrecacheJobTemplateData(context.TODO(), cgu, "spoke1")
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(240*60), data.JobTimeout)
+	assert.Empty(t, data.Job.Env)
+	assert.Equal(t, config.Spec.PrecacheJob.Resources, data.Job.Resources)
+	// The ClusterRole of the job gets its default rules
+	assert.Equal(t, defaultPrecacheClusterRoleRules, data.Job.ClusterRoleRules)
+	assert.Equal(t, append([]resourceTemplate{precacheClusterRoleCreateTemplate}, precacheDependenciesCreateTemplates...),
+		withClusterRoleTemplate(data, precacheClusterRoleCreateTemplate, precacheDependenciesCreateTemplates))
+
+	// cluster-admin is only bound when the settings opt in
+	config.Spec.BackupJob = &ranv1alpha1.JobSettings{ClusterAdmin: true}
+	assert.NoError(t, fakeClient.Update(context.TODO(), config))
+	data, err = r.getBackupJobTemplateData(context.TODO(), cgu, "spoke1")
+	assert.NoError(t, err)
+	assert.Empty(t, data.Job.ClusterRoleRules)
+	assert.Equal(t, backupDependenciesCreateTemplates,
+		withClusterRoleTemplate(data, backupClusterRoleCreateTemplate, backupDependenciesCreateTemplates))
+}
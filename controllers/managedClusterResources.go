@@ -42,15 +42,24 @@ type templateData struct {
 	ResourceName          string
 	PlatformImage         string
 	Operators             operatorsData
+	AdditionalImages      []string
+	ExcludePatterns       []string
+	SpaceRequired         int64
 	WorkloadImage         string
 	JobTimeout            uint64
+	PullRateLimit         int
 	ViewUpdateIntervalSec int
+	// Job customizes the precaching or backup job, see setJobSettings
+	Job ranv1alpha1.JobSettings
+	// Owner is the namespace/name of the CGU the resources are created for
+	Owner string
 }
 
 // operatorsData provides operators data for template rendering
 type operatorsData struct {
 	Indexes             []string
 	PackagesAndChannels []string
+	InstalledCSVs       []string
 }
 
 // resourceTemplate define a resource template structure
@@ -61,13 +70,27 @@ type resourceTemplate struct {
 	template string
 }
 
+// templateFuncs are the functions available to the templates
+var templateFuncs = template.FuncMap{
+	"toJSON": toJSON,
+}
+
 var precacheDependenciesCreateTemplates = []resourceTemplate{
 	{"precache-ns-create", templates.MngClusterActCreatePrecachingNS},
-	{"precache-spec-cm-create", templates.MngClusterActCreatePrecachingSpecCM},
 	{"precache-sa-create", templates.MngClusterActCreateServiceAcct},
+	{"precache-role-create", templates.MngClusterActCreateRole},
+	{"precache-rb-create", templates.MngClusterActCreateRoleBinding},
 	{"precache-crb-create", templates.MngClusterActCreateClusterRoleBinding},
 }
 
+// precacheClusterRoleCreateTemplate is not created when the job settings bind cluster-admin
+var precacheClusterRoleCreateTemplate = resourceTemplate{
+	"precache-cr-create", templates.MngClusterActCreateClusterRole}
+
+// precacheSpecConfigMapTemplate is rendered once per software spec, see createPrecachingSpecConfigMap
+var precacheSpecConfigMapTemplate = resourceTemplate{
+	"precache-spec-cm-create", templates.MngClusterActCreatePrecachingSpecCM}
+
 var precacheDependenciesViewTemplates = []resourceTemplate{
 	{"view-precache-spec-configmap", templates.MngClusterViewConfigMap},
 	{"view-precache-service-acct", templates.MngClusterViewServiceAcct},
@@ -77,12 +100,18 @@ var precacheDependenciesViewTemplates = []resourceTemplate{
 var precacheCreateTemplates = []resourceTemplate{
 	{"precache-job-create", templates.MngClusterActCreateJob},
 	{"view-precache-job", templates.MngClusterViewJob},
+	{"view-precache-summary", templates.MngClusterViewSummaryConfigMap},
 }
 var precacheJobView = []resourceTemplate{
 	{"view-precache-job", templates.MngClusterViewJob},
 }
+var precacheSummaryView = []resourceTemplate{
+	{"view-precache-summary", templates.MngClusterViewSummaryConfigMap},
+}
 var precacheDeleteTemplates = []resourceTemplate{
 	{"precache-ns-delete", templates.MngClusterActDeletePrecachingNS},
+	{"precache-crb-delete", templates.MngClusterActDeleteClusterRoleBinding},
+	{"precache-cr-delete", templates.MngClusterActDeleteClusterRole},
 }
 
 var precacheNSViewTemplates = []resourceTemplate{
@@ -95,6 +124,7 @@ var precacheAllViews = []resourceTemplate{ // only used for deleting, hence empt
 	{"view-precache-spec-configmap", ""},
 	{"view-precache-service-acct", ""},
 	{"view-precache-cluster-role-binding", ""},
+	{"view-precache-summary", ""},
 }
 
 var backupDependenciesCreateTemplates = []resourceTemplate{
@@ -104,6 +134,10 @@ var backupDependenciesCreateTemplates = []resourceTemplate{
 	{"view-backup-namespace", templates.MngClusterViewBackupNS},
 }
 
+// backupClusterRoleCreateTemplate is not created when the job settings bind cluster-admin
+var backupClusterRoleCreateTemplate = resourceTemplate{
+	"backup-cr-create", templates.MngClusterActCreateBackupClusterRole}
+
 var backupCreateTemplates = []resourceTemplate{
 	{"backup-job-create", templates.MngClusterActCreateBackupJob},
 	{"view-backup-job", templates.MngClusterViewBackupJob},
@@ -119,6 +153,8 @@ var backupNSView = []resourceTemplate{
 
 var backupDeleteTemplates = []resourceTemplate{
 	{"backup-ns-delete", templates.MngClusterActDeleteBackupNS},
+	{"backup-crb-delete", templates.MngClusterActDeleteBackupRB},
+	{"backup-cr-delete", templates.MngClusterActDeleteBackupClusterRole},
 }
 
 var backupView = []resourceTemplate{ // only used for deleting, hence empty templates
@@ -127,12 +163,18 @@ var backupView = []resourceTemplate{ // only used for deleting, hence empty temp
 }
 
 var (
-	jobsInitialStatus = []string{"status", "conditions"}
-	jobsFinalStatus   = []string{"status", "result", "status"}
-	precache          = "precache"
-	backup            = "backup"
+	jobsInitialStatus   = []string{"status", "conditions"}
+	jobsFinalStatus     = []string{"status", "result", "status"}
+	precacheSummaryData = []string{"status", "result", "data", "summary"}
+	precache            = "precache"
+	backup              = "backup"
 )
 
+// getOwnerRef returns the namespace/name of the CGU, set on the resources created for it
+func getOwnerRef(clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade) string {
+	return clusterGroupUpgrade.Namespace + "/" + clusterGroupUpgrade.Name
+}
+
 // createResourceFromTemplate creates managedclusteraction or managedclusterview
 //      resources from templates
 // returns:   error
@@ -141,15 +183,7 @@ func (r *ClusterGroupUpgradeReconciler) createResourcesFromTemplates(
 
 	for _, item := range templates {
 		r.Log.Info("[createResourcesFromTemplates]", "cluster", data.Cluster, "template", item.resourceName)
-		obj := &unstructured.Unstructured{}
-		w, err := r.renderYamlTemplate(item.resourceName, item.template, *data)
-		if err != nil {
-			return err
-		}
-
-		// decode YAML into unstructured.Unstructured
-		dec := yaml.NewDecodingSerializer(unstructured.UnstructuredJSONScheme)
-		_, _, err = dec.Decode(w.Bytes(), nil, obj)
+		obj, err := r.renderResourceFromTemplate(data, item)
 		if err != nil {
 			return err
 		}
@@ -166,6 +200,34 @@ func (r *ClusterGroupUpgradeReconciler) createResourcesFromTemplates(
 	return nil
 }
 
+// renderResourceFromTemplate renders a resource template and decodes it, annotated with its owner
+// returns: *unstructured.Unstructured, error
+func (r *ClusterGroupUpgradeReconciler) renderResourceFromTemplate(
+	data *templateData, item resourceTemplate) (*unstructured.Unstructured, error) {
+
+	obj := &unstructured.Unstructured{}
+	w, err := r.renderYamlTemplate(item.resourceName, item.template, *data)
+	if err != nil {
+		return nil, err
+	}
+
+	// decode YAML into unstructured.Unstructured
+	dec := yaml.NewDecodingSerializer(unstructured.UnstructuredJSONScheme)
+	_, _, err = dec.Decode(w.Bytes(), nil, obj)
+	if err != nil {
+		return nil, err
+	}
+	if data.Owner != "" {
+		annotations := obj.GetAnnotations()
+		if annotations == nil {
+			annotations = make(map[string]string)
+		}
+		annotations[utils.CguOwnerAnnotation] = data.Owner
+		obj.SetAnnotations(annotations)
+	}
+	return obj, nil
+}
+
 // deleteManagedClusterViewResource deletes view by name and namespace
 // returns: error
 func (r *ClusterGroupUpgradeReconciler) deleteManagedClusterViewResource(
@@ -255,7 +317,11 @@ func (r *ClusterGroupUpgradeReconciler) renderYamlTemplate(
 	data templateData) (*bytes.Buffer, error) {
 
 	w := new(bytes.Buffer)
-	template, err := template.New(resourceName).Parse(templates.CommonTemplates + templateBody)
+	err := validateJobSettings(&data.Job)
+	if err != nil {
+		return w, fmt.Errorf("invalid job settings for template %s: %v", resourceName, err)
+	}
+	template, err := template.New(resourceName).Funcs(templateFuncs).Parse(templates.CommonTemplates + templateBody)
 	if err != nil {
 		return w, fmt.Errorf("failed to parse template %s: %v", resourceName, err)
 	}
@@ -309,6 +375,7 @@ func (r *ClusterGroupUpgradeReconciler) jobAndViewCleanup(
 		}
 		data := templateData{
 			Cluster: cluster,
+			Owner:   getOwnerRef(clusterGroupUpgrade),
 		}
 		err = r.createResourcesFromTemplates(ctx, &data, deleteTemplate)
 		if err != nil {
@@ -471,6 +538,34 @@ func (r *ClusterGroupUpgradeReconciler) getActiveConditions(
 	return r.getJobStatus(jobView)
 }
 
+// getPrecachingSummary gets the summary published by the pre-caching job from its view
+// returns: *ranv1alpha1.PrecachingSummary (nil if not published yet)
+//			bool (the job is done and won't update the summary anymore)
+//			error
+func (r *ClusterGroupUpgradeReconciler) getPrecachingSummary(
+	ctx context.Context, cluster string) (
+	*ranv1alpha1.PrecachingSummary, bool, error) {
+
+	summaryView, present, err := r.getView(ctx, precacheSummaryView[0].resourceName, cluster)
+	if err != nil || !present {
+		return nil, false, err
+	}
+	data, exists, err := unstructured.NestedString(summaryView.Object, precacheSummaryData...)
+	if err != nil || !exists {
+		return nil, false, err
+	}
+	published := struct {
+		ranv1alpha1.PrecachingSummary
+		Done bool `json:"done,omitempty"`
+	}{}
+	err = json.Unmarshal([]byte(data), &published)
+	if err != nil {
+		r.Log.Info("[getPrecachingSummary] Ignoring invalid summary", "cluster", cluster, "error", err.Error())
+		return nil, false, nil
+	}
+	return &published.PrecachingSummary, published.Done, nil
+}
+
 // checkDependenciesViews check all precache job dependencies views
 //		have been deployed
 // returns: available (bool)
@@ -543,32 +638,58 @@ func (r *ClusterGroupUpgradeReconciler) getPrecacheJobTemplateData(
 	rv := new(templateData)
 
 	rv.Cluster = clusterName
+	rv.Owner = getOwnerRef(clusterGroupUpgrade)
 	rv.JobTimeout = uint64(
-		clusterGroupUpgrade.Spec.RemediationStrategy.Timeout) * 60
+		getCguTimeout(clusterGroupUpgrade)) * 60
 	image, err := r.getPrecacheimagePullSpec(ctx, clusterGroupUpgrade)
 	if err != nil {
 		return rv, err
 	}
 	rv.WorkloadImage = image
+	if clusterGroupUpgrade.Spec.PreCachingConfig != nil {
+		rv.PullRateLimit = clusterGroupUpgrade.Spec.PreCachingConfig.PullRateLimit
+	}
+
+	settings, err := r.getOperatorSettings(ctx, clusterGroupUpgrade.Namespace)
+	if err != nil {
+		return rv, err
+	}
+	rv.setJobSettings(settings.PrecacheJob, defaultPrecacheClusterRoleRules)
 	return rv, nil
 }
 
 // getBackupJobTemplateData initializes template data for the backup job creation
 // returns: 	*templateData
 //				error
-func (r *ClusterGroupUpgradeReconciler) getBackupJobTemplateData(clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade, clusterName string) (*templateData, error) {
+func (r *ClusterGroupUpgradeReconciler) getBackupJobTemplateData(
+	ctx context.Context,
+	clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade, clusterName string) (*templateData, error) {
 
 	rv := new(templateData)
 	rv.Cluster = clusterName
+	rv.Owner = getOwnerRef(clusterGroupUpgrade)
 	rv.JobTimeout = uint64(
-		clusterGroupUpgrade.Spec.RemediationStrategy.Timeout)
+		getCguTimeout(clusterGroupUpgrade))
+
+	settings, err := r.getOperatorSettings(ctx, clusterGroupUpgrade.Namespace)
+	if err != nil {
+		return rv, err
+	}
+	rv.setJobSettings(settings.BackupJob, defaultBackupClusterRoleRules)
 
-	rv.WorkloadImage = os.Getenv("RECOVERY_IMG")
+	overrides, err := r.getOverrides(ctx, clusterGroupUpgrade)
+	if err != nil {
+		return rv, err
+	}
+	rv.WorkloadImage = overrides["recovery.image"]
+	if rv.WorkloadImage == "" {
+		rv.WorkloadImage = os.Getenv("RECOVERY_IMG")
+	}
 	r.Log.Info("[getBackupJobTemplateData]", "workload image", rv.WorkloadImage)
 	// if RECOVERY_IMG is not set or empty
 	if rv.WorkloadImage == "" {
 		return rv, fmt.Errorf(
-			"can't find recovery image pull spec in environment")
+			"can't find recovery image pull spec in environment or overrides")
 	}
 	return rv, nil
 }
@@ -594,7 +715,7 @@ func (r *ClusterGroupUpgradeReconciler) deployWorkload(
 			cluster, "status", "success")
 
 	case backup:
-		spec, err = r.getBackupJobTemplateData(clusterGroupUpgrade, cluster)
+		spec, err = r.getBackupJobTemplateData(ctx, clusterGroupUpgrade, cluster)
 		if err != nil {
 			return err
 		}
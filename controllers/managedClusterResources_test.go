@@ -7,8 +7,13 @@ import (
 	"testing"
 
 	"github.com/go-logr/logr"
+	ranv1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/api/v1alpha1"
 	"github.com/openshift-kni/cluster-group-upgrades-operator/controllers/templates"
+	utils "github.com/openshift-kni/cluster-group-upgrades-operator/controllers/utils"
 	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/runtime/serializer/yaml"
@@ -104,11 +109,92 @@ spec:
       roleRef:
         apiGroup: rbac.authorization.k8s.io
         kind: ClusterRole
-        name: cluster-admin
+        name: pre-cache-agent
       subjects:
       - kind: ServiceAccount
         name: pre-cache-agent
         namespace: openshift-talo-pre-cache`,
+		},
+		{
+			name:         "create role",
+			resourceName: "test-role-create",
+			data: templateData{
+				Cluster: "test",
+			},
+			template: templates.MngClusterActCreateRole,
+			result: `
+apiVersion: action.open-cluster-management.io/v1beta1
+kind: ManagedClusterAction
+metadata:
+  name: test-role-create
+  namespace: test
+spec:
+  actionType: Create
+  kube:
+    resource: role
+    namespace: openshift-talo-pre-cache
+    template:
+      apiVersion: rbac.authorization.k8s.io/v1
+      kind: Role
+      metadata:
+        name: pre-cache-agent
+        namespace: openshift-talo-pre-cache
+      rules:
+      - apiGroups: [""]
+        resources: [configmaps]
+        verbs: [get, create, update]`,
+		},
+		{
+			name:         "create role binding",
+			resourceName: "test-rb-create",
+			data: templateData{
+				Cluster: "test",
+			},
+			template: templates.MngClusterActCreateRoleBinding,
+			result: `
+apiVersion: action.open-cluster-management.io/v1beta1
+kind: ManagedClusterAction
+metadata:
+  name: test-rb-create
+  namespace: test
+spec:
+  actionType: Create
+  kube:
+    resource: rolebinding
+    namespace: openshift-talo-pre-cache
+    template:
+      apiVersion: rbac.authorization.k8s.io/v1
+      kind: RoleBinding
+      metadata:
+        name: pre-cache-agent
+        namespace: openshift-talo-pre-cache
+      roleRef:
+        apiGroup: rbac.authorization.k8s.io
+        kind: Role
+        name: pre-cache-agent
+      subjects:
+      - kind: ServiceAccount
+        name: pre-cache-agent
+        namespace: openshift-talo-pre-cache`,
+		},
+		{
+			name:         "delete cluster role",
+			resourceName: "test-cr-delete",
+			data: templateData{
+				Cluster: "test",
+			},
+			template: templates.MngClusterActDeleteClusterRole,
+			result: `
+apiVersion: action.open-cluster-management.io/v1beta1
+kind: ManagedClusterAction
+metadata:
+  name: test-cr-delete
+  namespace: test
+spec:
+  actionType: Delete
+  kube:
+    resource: clusterrole
+    name: pre-cache-agent`,
 		},
 		{
 			name:         "create job",
@@ -186,6 +272,332 @@ spec:
                       path: /
                       type: Directory
                     name: host
+`,
+		},
+		{
+			name:         "create job with a pull rate limit",
+			resourceName: "test-job-create",
+			data: templateData{
+				Cluster:       "test",
+				ResourceName:  "test-crb",
+				WorkloadImage: "test-image",
+				JobTimeout:    12,
+				PullRateLimit: 30,
+			},
+			template: templates.MngClusterActCreateJob,
+			result: `
+      apiVersion: action.open-cluster-management.io/v1beta1
+      kind: ManagedClusterAction
+      metadata:
+        name: test-job-create
+        namespace: test
+      spec:
+        actionType: Create
+        kube:
+          resource: job
+          namespace: openshift-talo-pre-cache
+          template:
+            apiVersion: batch/v1
+            kind: Job
+            metadata:
+              name: pre-cache
+              namespace: openshift-talo-pre-cache
+              annotations:
+                target.workload.openshift.io/management: '{"effect":"PreferredDuringScheduling"}'
+            spec:
+              activeDeadlineSeconds: 12
+              backoffLimit: 0
+              template:
+                metadata:
+                  name: pre-cache
+                  annotations:
+                    target.workload.openshift.io/management: '{"effect":"PreferredDuringScheduling"}'
+                spec:
+                  containers:
+                  - args:
+                    - /opt/precache/precache.sh
+                    command:
+                    - /bin/bash
+                    - -c
+                    env:
+                    - name: config_volume_path
+                      value: /etc/config
+                    - name: PULL_RATE_LIMIT
+                      value: "30"
+                    image: test-image
+                    name: pre-cache-container
+                    resources: {}
+                    securityContext:
+                      privileged: true
+                      runAsUser: 0
+                    terminationMessagePath: /dev/termination-log
+                    terminationMessagePolicy: File
+                    volumeMounts:
+                    - mountPath: /host
+                      name: host 
+                    - mountPath: /etc/config
+                      name: config-volume
+                      readOnly: true
+                  dnsPolicy: ClusterFirst
+                  restartPolicy: Never
+                  schedulerName: default-scheduler
+                  securityContext: {}
+                  serviceAccountName: pre-cache-agent
+                  priorityClassName: system-cluster-critical
+                  volumes:
+                  - configMap:
+                      defaultMode: 420
+                      name: pre-cache-spec
+                    name: config-volume
+                  - hostPath:
+                      path: /
+                      type: Directory
+                    name: host
+`,
+		},
+		{
+			name:         "create job with job settings",
+			resourceName: "test-job-create",
+			data: templateData{
+				Cluster:       "test",
+				WorkloadImage: "test-image",
+				JobTimeout:    12,
+				Job: ranv1alpha1.JobSettings{
+					Resources: &corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("100m")},
+					},
+					PriorityClassName: "openshift-user-critical",
+					NodeSelector:      map[string]string{"node-role.kubernetes.io/master": ""},
+					Tolerations: []corev1.Toleration{{
+						Key: "node-role.kubernetes.io/master", Operator: corev1.TolerationOpExists,
+						Effect: corev1.TaintEffectNoSchedule}},
+					Env: []corev1.EnvVar{{Name: "MAX_PULL_THREADS", Value: "4"}},
+				},
+			},
+			template: templates.MngClusterActCreateJob,
+			result: `
+      apiVersion: action.open-cluster-management.io/v1beta1
+      kind: ManagedClusterAction
+      metadata:
+        name: test-job-create
+        namespace: test
+      spec:
+        actionType: Create
+        kube:
+          resource: job
+          namespace: openshift-talo-pre-cache
+          template:
+            apiVersion: batch/v1
+            kind: Job
+            metadata:
+              name: pre-cache
+              namespace: openshift-talo-pre-cache
+              annotations:
+                target.workload.openshift.io/management: '{"effect":"PreferredDuringScheduling"}'
+            spec:
+              activeDeadlineSeconds: 12
+              backoffLimit: 0
+              template:
+                metadata:
+                  name: pre-cache
+                  annotations:
+                    target.workload.openshift.io/management: '{"effect":"PreferredDuringScheduling"}'
+                spec:
+                  containers:
+                  - args:
+                    - /opt/precache/precache.sh
+                    command:
+                    - /bin/bash
+                    - -c
+                    env:
+                    - name: config_volume_path
+                      value: /etc/config
+                    - name: MAX_PULL_THREADS
+                      value: "4"
+                    image: test-image
+                    name: pre-cache-container
+                    resources:
+                      requests:
+                        cpu: 100m
+                    securityContext:
+                      privileged: true
+                      runAsUser: 0
+                    terminationMessagePath: /dev/termination-log
+                    terminationMessagePolicy: File
+                    volumeMounts:
+                    - mountPath: /host
+                      name: host 
+                    - mountPath: /etc/config
+                      name: config-volume
+                      readOnly: true
+                  dnsPolicy: ClusterFirst
+                  restartPolicy: Never
+                  schedulerName: default-scheduler
+                  securityContext: {}
+                  serviceAccountName: pre-cache-agent
+                  priorityClassName: openshift-user-critical
+                  nodeSelector:
+                    node-role.kubernetes.io/master: ""
+                  tolerations:
+                  - key: node-role.kubernetes.io/master
+                    operator: Exists
+                    effect: NoSchedule
+                  volumes:
+                  - configMap:
+                      defaultMode: 420
+                      name: pre-cache-spec
+                    name: config-volume
+                  - hostPath:
+                      path: /
+                      type: Directory
+                    name: host
+`,
+		},
+		{
+			name:         "create cluster role",
+			resourceName: "test-cr-create",
+			data: templateData{
+				Cluster: "test",
+				Job: ranv1alpha1.JobSettings{
+					ClusterRoleRules: []rbacv1.PolicyRule{{
+						APIGroups: []string{""}, Resources: []string{"configmaps"}, Verbs: []string{"get", "create"}}},
+				},
+			},
+			template: templates.MngClusterActCreateClusterRole,
+			result: `
+apiVersion: action.open-cluster-management.io/v1beta1
+kind: ManagedClusterAction
+metadata:
+  name: test-cr-create
+  namespace: test
+spec:
+  actionType: Create
+  kube:
+    resource: clusterrole
+    template:
+      apiVersion: rbac.authorization.k8s.io/v1
+      kind: ClusterRole
+      metadata:
+        name: pre-cache-agent
+      rules:
+      - apiGroups: [""]
+        resources: [configmaps]
+        verbs: [get, create]
+`,
+		},
+		{
+			name:         "create backup crb bound to cluster-admin",
+			resourceName: "test-crb-create",
+			data: templateData{
+				Cluster: "test",
+				Job:     ranv1alpha1.JobSettings{ClusterAdmin: true},
+			},
+			template: templates.MngClusterActCreateRB,
+			result: `
+apiVersion: action.open-cluster-management.io/v1beta1
+kind: ManagedClusterAction
+metadata:
+  name: test-crb-create
+  namespace: test
+spec:
+  actionType: Create
+  kube:
+    resource: clusterrolebinding
+    template:
+      apiVersion: rbac.authorization.k8s.io/v1
+      kind: ClusterRoleBinding
+      metadata:
+        name: backup-agent
+      roleRef:
+        apiGroup: rbac.authorization.k8s.io
+        kind: ClusterRole
+        name: cluster-admin
+      subjects:
+        - kind: ServiceAccount
+          name: backup-agent
+          namespace: openshift-talo-backup
+`,
+		},
+		{
+			name:         "create backup job with job settings",
+			resourceName: "test-backup-job-create",
+			data: templateData{
+				Cluster:       "test",
+				WorkloadImage: "test-image",
+				JobTimeout:    600,
+				Job: ranv1alpha1.JobSettings{
+					Resources: &corev1.ResourceRequirements{
+						Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+					},
+					PriorityClassName: "system-node-critical",
+					NodeSelector:      map[string]string{"kubernetes.io/os": "linux"},
+					Tolerations:       []corev1.Toleration{{Operator: corev1.TolerationOpExists}},
+					Env:               []corev1.EnvVar{{Name: "DEBUG", Value: "true"}},
+				},
+			},
+			template: templates.MngClusterActCreateBackupJob,
+			result: `
+apiVersion: action.open-cluster-management.io/v1beta1
+kind: ManagedClusterAction
+metadata:
+  name: test-backup-job-create
+  namespace: test
+spec:
+  actionType: Create
+  kube:
+    namespace: openshift-talo-backup
+    resource: job
+    template:
+      apiVersion: batch/v1
+      kind: Job
+      metadata:
+        name: backup-agent
+        namespace: openshift-talo-backup
+        annotations:
+          target.workload.openshift.io/management: '{"effect":"PreferredDuringScheduling"}'
+      spec:
+        activeDeadlineSeconds: 600
+        backoffLimit: 0
+        template:
+          metadata:
+            name: backup-agent
+            annotations:
+              target.workload.openshift.io/management: '{"effect":"PreferredDuringScheduling"}'
+          spec:
+            containers:
+              -
+                args:
+                  - launchBackup
+                env:
+                  - name: DEBUG
+                    value: "true"
+                image: test-image
+                name: container-image
+                resources:
+                  limits:
+                    memory: 1Gi
+                securityContext:
+                  privileged: true
+                  runAsUser: 0
+                tty: true
+                volumeMounts:
+                  -
+                    mountPath: /host
+                    name: backup
+            restartPolicy: Never
+            hostNetwork: true
+            serviceAccountName: backup-agent
+            priorityClassName: system-node-critical
+            nodeSelector:
+              kubernetes.io/os: linux
+            tolerations:
+              - operator: Exists
+            volumes:
+              -
+                hostPath:
+                  path: /
+                  type: Directory
+                name: backup
 `,
 		},
 		{
@@ -232,6 +644,28 @@ spec:
     name: pre-cache-spec
     namespace: openshift-talo-pre-cache
     updateIntervalSeconds: 134`,
+		},
+		{
+			name:         "create summary view",
+			resourceName: "test-summary-view",
+			data: templateData{
+				Cluster:               "test",
+				ResourceName:          "test-summary-view",
+				ViewUpdateIntervalSec: 135,
+			},
+			template: templates.MngClusterViewSummaryConfigMap,
+			result: `
+apiVersion: view.open-cluster-management.io/v1beta1
+kind: ManagedClusterView
+metadata:
+  name: test-summary-view
+  namespace: test
+spec:
+  scope:
+    resource: configmap
+    name: pre-cache-summary
+    namespace: openshift-talo-pre-cache
+    updateIntervalSeconds: 135`,
 		},
 		{
 			name:         "create sa view",
@@ -432,6 +866,15 @@ func TestMCR_createResourcesFromTemplates(t *testing.T) {
 			},
 			templates: precacheDependenciesViewTemplates,
 		},
+		{
+
+			name: "Create objects with an owner CGU",
+			data: templateData{
+				Cluster: "test",
+				Owner:   "default/cgu",
+			},
+			templates: precacheDependenciesViewTemplates,
+		},
 	}
 	for _, tc := range testcases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -466,7 +909,49 @@ func TestMCR_createResourcesFromTemplates(t *testing.T) {
 					Namespace: tc.data.Cluster,
 				}, obj)
 				assert.Equal(t, err, nil)
+				owner, ok := obj.GetAnnotations()[utils.CguOwnerAnnotation]
+				assert.Equal(t, tc.data.Owner != "", ok)
+				assert.Equal(t, tc.data.Owner, owner)
 			}
 		})
 	}
 }
+
+func TestMCR_renderPrecachingSpecConfigMap(t *testing.T) {
+	r := &ClusterGroupUpgradeReconciler{Log: logr.Discard()}
+	data := templateData{
+		Cluster:       "test",
+		PlatformImage: "quay.io/release",
+		Operators: operatorsData{Indexes: []string{"quay.io/index"}, PackagesAndChannels: []string{"sriov:stable"},
+			InstalledCSVs: []string{"sriov:sriov.v4.10.0"}},
+		AdditionalImages: []string{"quay.io/cnf:v1", "quay.io/cnf:v2"},
+		ExcludePatterns:  []string{"aws", "azure-.*"},
+		SpaceRequired:    42949672960,
+	}
+	w, err := r.renderYamlTemplate("test-spec-cm", templates.MngClusterActCreatePrecachingSpecCM, data)
+	assert.NoError(t, err)
+	obj := &unstructured.Unstructured{}
+	dec := yaml.NewDecodingSerializer(unstructured.UnstructuredJSONScheme)
+	_, _, err = dec.Decode(w.Bytes(), nil, obj)
+	assert.NoError(t, err)
+	configMapData, _, err := unstructured.NestedStringMap(obj.Object, "spec", "kube", "template", "data")
+	assert.NoError(t, err)
+
+	// The workload reads the lists line by line and ignores the spaces
+	trimLines := func(value string) []string {
+		var lines []string
+		for _, line := range strings.Split(value, "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				lines = append(lines, line)
+			}
+		}
+		return lines
+	}
+	assert.Equal(t, "quay.io/release", configMapData["platform.image"])
+	assert.Equal(t, []string{"quay.io/index"}, trimLines(configMapData["operators.indexes"]))
+	assert.Equal(t, []string{"sriov:stable"}, trimLines(configMapData["operators.packagesAndChannels"]))
+	assert.Equal(t, []string{"sriov:sriov.v4.10.0"}, trimLines(configMapData["operators.installedCSVs"]))
+	assert.Equal(t, []string{"quay.io/cnf:v1", "quay.io/cnf:v2"}, trimLines(configMapData["additional.images"]))
+	assert.Equal(t, []string{"aws", "azure-.*"}, trimLines(configMapData["exclude.patterns"]))
+	assert.Equal(t, "42949672960", configMapData["space.required"])
+}
@@ -22,6 +22,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
@@ -29,6 +30,7 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -36,7 +38,10 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	policiesv1 "github.com/open-cluster-management/governance-policy-propagator/api/v1"
 	ranv1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/api/v1alpha1"
@@ -50,6 +55,7 @@ const (
 	ztpDeployWaveAnnotation      = "ran.openshift.io/ztp-deploy-wave"
 	ztpRunningLabel              = "ztp-running"
 	ztpDoneLabel                 = "ztp-done"
+	ztpDay2Suffix                = "-day2"
 )
 
 // ManagedClusterForCguReconciler reconciles a ManagedCluster object to auto create the ClusterGroupUpgrade
@@ -57,6 +63,14 @@ type ManagedClusterForCguReconciler struct {
 	client.Client
 	Log    logr.Logger
 	Scheme *runtime.Scheme
+	// Shards runs the controller on the replica owning shard 0 only; nil runs it on this replica
+	Shards *ShardLeases
+
+	// ztpGroups holds the clusters waiting for their shared ClusterGroupUpgrade by group key, and
+	// ztpGroupedClusters the time the clusters were last added to one
+	ztpGroupsLock      sync.Mutex
+	ztpGroups          map[string]*ztpClusterGroup
+	ztpGroupedClusters map[string]time.Time
 }
 
 //+kubebuilder:rbac:groups=cluster.open-cluster-management.io,resources=managedclusters,verbs=get;list;watch
@@ -66,15 +80,23 @@ type ManagedClusterForCguReconciler struct {
 
 // Reconcile the managed cluster auto create ClusterGroupUpgrade
 // - Controller watches for create event of managed cluster object. Reconciliation
-//   is triggered when a new managed cluster is created
+//   is triggered when a new managed cluster is created, and when a child policy is
+//   created for it
 // - When a new managed cluster is created, create ClusterGroupUpgrade CR for the
 //   cluster only when it's ready and its child policies are available
 // - As created ClusterGroupUpgrade has ownReference set to its managed cluster,
 //   when the managed cluster is deleted, the ClusterGroupUpgrade will be auto-deleted
+// - When enabled, a follow-up ClusterGroupUpgrade is created for a cluster with the
+//   ztp-done label when one of its wave-annotated child policies turns NonCompliant
+// - When the aggregation is enabled, the ready clusters with the same policies are
+//   collected during the settling time into a shared ClusterGroupUpgrade
 //
 // Note: The Controller will requeue the Request to be processed again if the returned error is non-nil or
 // Result.Requeue is true, otherwise upon completion it will remove the work from the queue.
 func (r *ManagedClusterForCguReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	if !r.Shards.OwnsShard(0) {
+		return ctrl.Result{}, nil
+	}
 	reqLogger := r.Log.WithValues("Request.Name", req.Name)
 	reqLogger.Info("Reconciling managedCluster to create clusterGroupUpgrade")
 
@@ -88,14 +110,31 @@ func (r *ManagedClusterForCguReconciler) Reconcile(ctx context.Context, req ctrl
 		return ctrl.Result{}, err
 	}
 
-	// Stop creating UOCR if ztp of this cluster is done already
-	if _, found := managedCluster.Labels[ztpDoneLabel]; found {
-		r.Log.Info("ZTP for the cluster has completed. "+ztpDoneLabel+" label found.", "Name", managedCluster.Name)
+	config, err := getOperatorConfig(ctx, r.Client)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	ztpSettings := getZtpSettings(config)
+	selected, err := isZtpCluster(&ztpSettings, managedCluster)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if !selected {
+		r.Log.Info("Cluster not selected by the ZTP cluster selector", "Name", managedCluster.Name)
 		return ctrl.Result{}, nil
 	}
+	if err := applyZtpClusterOverrides(&ztpSettings, managedCluster); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Stop creating UOCR if ztp of this cluster is done already
+	if _, found := managedCluster.Labels[ztpSettings.DoneLabel]; found {
+		r.Log.Info("ZTP for the cluster has completed. "+ztpSettings.DoneLabel+" label found.", "Name", managedCluster.Name)
+		return ctrl.Result{}, r.reconcileDay2(ctx, managedCluster, &ztpSettings)
+	}
 
 	clusterGroupUpgrade := &ranv1alpha1.ClusterGroupUpgrade{}
-	if err := r.Get(ctx, types.NamespacedName{Name: req.Name, Namespace: ztpInstallNS}, clusterGroupUpgrade); err != nil {
+	if err := r.Get(ctx, types.NamespacedName{Name: req.Name, Namespace: ztpSettings.Namespace}, clusterGroupUpgrade); err != nil {
 		if !errors.IsNotFound(err) {
 			// Error reading clusterGroupUpgrade, requeue the request
 			return ctrl.Result{}, err
@@ -105,6 +144,16 @@ func (r *ManagedClusterForCguReconciler) Reconcile(ctx context.Context, req ctrl
 		r.Log.Info("clusterGroupUpgrade found", "Name", clusterGroupUpgrade.Name, "Namespace", clusterGroupUpgrade.Namespace)
 		return ctrl.Result{}, nil
 	}
+	if ztpSettings.Aggregation != nil {
+		grouped, err := r.isClusterInZtpGroup(ctx, managedCluster.Name, &ztpSettings)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if grouped {
+			r.Log.Info("Cluster already in a shared clusterGroupUpgrade", "Name", managedCluster.Name)
+			return ctrl.Result{}, nil
+		}
+	}
 
 	// clusterGroupUpgrade CR doesn't exist
 	availableCondition := meta.FindStatusCondition(managedCluster.Status.Conditions, clusterv1.ManagedClusterConditionAvailable)
@@ -124,13 +173,19 @@ func (r *ManagedClusterForCguReconciler) Reconcile(ctx context.Context, req ctrl
 			return ctrl.Result{}, err
 		}
 		if len(policies) == 0 {
-			// likely no policies were created, so no child policies found
-			r.Log.Info("WARN: No child policies found for cluster", "Name", managedCluster.Name)
-			return ctrl.Result{}, nil
+			// likely no policies were created yet, so no child policies found. Their creation is
+			// watched, but check again in case the watch event is missed
+			r.Log.Info("WARN: No child policies found for cluster", "Name", managedCluster.Name,
+				"RequeueAfter:", clusterStatusCheckRetryDelay)
+			return ctrl.Result{RequeueAfter: clusterStatusCheckRetryDelay}, nil
+		}
+
+		if ztpSettings.Aggregation != nil {
+			return r.aggregateClusterGroupUpgrade(ctx, managedCluster, policies, &ztpSettings)
 		}
 
 		// create clusterGroupUpgrade
-		if err := r.newClusterGroupUpgrade(ctx, managedCluster, policies); err != nil {
+		if err := r.newClusterGroupUpgrade(ctx, managedCluster, policies, &ztpSettings); err != nil {
 			return ctrl.Result{}, err
 		}
 	} else {
@@ -159,10 +214,9 @@ func sortMapByValue(sortMap map[string]int) []string {
 	return keys
 }
 
-// Create a clusterGroupUpgrade
-func (r *ManagedClusterForCguReconciler) newClusterGroupUpgrade(
-	ctx context.Context, cluster *clusterv1.ManagedCluster, childPolicies []policiesv1.Policy) (err error) {
-
+// getWaveOrderedPolicies returns the names of the root policies of the given child policies with a ztp-deploy-wave
+// annotation, ordered by wave. The policies with remediationAction enforce are ignored.
+func (r *ManagedClusterForCguReconciler) getWaveOrderedPolicies(childPolicies []policiesv1.Policy) ([]string, error) {
 	var policyWaveMap = make(map[string]int)
 
 	// Generate a list of ordered managed policies based on the deploy wave.
@@ -186,23 +240,36 @@ func (r *ManagedClusterForCguReconciler) newClusterGroupUpgrade(
 			deployWaveInt, err := strconv.Atoi(deployWave)
 			if err != nil {
 				// err convert from string to int
-				return fmt.Errorf("%s in policy %s is not an interger: %s", ztpDeployWaveAnnotation, cPolicy.GetName(), err)
+				return nil, fmt.Errorf("%s in policy %s is not an interger: %s", ztpDeployWaveAnnotation, cPolicy.GetName(), err)
 			}
 			policyName := utils.GetParentPolicyNameAndNamespace(cPolicy.GetName())[1]
 			policyWaveMap[policyName] = deployWaveInt
 		}
 	}
+	return sortMapByValue(policyWaveMap), nil
+}
 
-	if len(policyWaveMap) == 0 {
+// Create a clusterGroupUpgrade
+func (r *ManagedClusterForCguReconciler) newClusterGroupUpgrade(ctx context.Context, cluster *clusterv1.ManagedCluster,
+	childPolicies []policiesv1.Policy, ztpSettings *ranv1alpha1.ZTPSettings) (err error) {
+
+	sortedManagedPolicies, err := r.getWaveOrderedPolicies(childPolicies)
+	if err != nil {
+		return err
+	}
+	if len(sortedManagedPolicies) == 0 {
 		r.Log.Info("No policies need to be managed by ClusterGroupUpgrade operator")
 		return nil
 	}
 
-	sortedManagedPolicies := sortMapByValue(policyWaveMap)
 	cguMeta := metav1.ObjectMeta{
 		Name:      cluster.Name,
-		Namespace: ztpInstallNS,
+		Namespace: ztpSettings.Namespace,
 	}
+	if err := r.ensureNamespace(ctx, ztpSettings.Namespace); err != nil {
+		return err
+	}
+
 	enable := true // default
 	cguSpec := ranv1alpha1.ClusterGroupUpgradeSpec{
 		Enable:          &enable,
@@ -210,22 +277,13 @@ func (r *ManagedClusterForCguReconciler) newClusterGroupUpgrade(
 		ManagedPolicies: sortedManagedPolicies,
 		RemediationStrategy: &ranv1alpha1.RemediationStrategySpec{
 			MaxConcurrency: 1,
+			Timeout:        ztpSettings.Timeout,
+			ParallelWaves:  ztpSettings.ParallelWaves,
 		},
-		Actions: ranv1alpha1.Actions{
-			BeforeEnable: ranv1alpha1.BeforeEnable{
-				AddClusterLabels: map[string]string{
-					ztpRunningLabel: "",
-				},
-			},
-			AfterCompletion: ranv1alpha1.AfterCompletion{
-				AddClusterLabels: map[string]string{
-					ztpDoneLabel: "",
-				},
-				DeleteClusterLabels: map[string]string{
-					ztpRunningLabel: "",
-				},
-			},
-		},
+		Backup:             ztpSettings.Backup,
+		PreCaching:         ztpSettings.PreCaching,
+		BatchTimeoutAction: ztpSettings.BatchTimeoutAction,
+		Actions:            getZtpActions(ztpSettings),
 	}
 	clusterGroupUpgrade := &ranv1alpha1.ClusterGroupUpgrade{
 		ObjectMeta: cguMeta,
@@ -239,46 +297,188 @@ func (r *ManagedClusterForCguReconciler) newClusterGroupUpgrade(
 	}
 
 	if err := r.Create(ctx, clusterGroupUpgrade); err != nil {
-		r.Log.Error(err, "Fail to create clusterGroupUpgrade", "name", cluster.Name, "namespace", ztpInstallNS)
+		r.Log.Error(err, "Fail to create clusterGroupUpgrade", "name", cluster.Name, "namespace", ztpSettings.Namespace)
 		return err
 	}
 
-	r.Log.Info("Found ManagedCluster "+cluster.Name+" without "+ztpDoneLabel+" label. Created clusterGroupUpgrade.",
-		"name", cluster.Name, "namespace", ztpInstallNS)
+	r.Log.Info("Found ManagedCluster "+cluster.Name+" without "+ztpSettings.DoneLabel+" label. Created clusterGroupUpgrade.",
+		"name", cluster.Name, "namespace", ztpSettings.Namespace)
 	return nil
 }
 
-// SetupWithManager sets up the controller with the Manager.
-func (r *ManagedClusterForCguReconciler) SetupWithManager(mgr ctrl.Manager) error {
+// ensureNamespace creates the namespace of the ZTP ClusterGroupUpgrades if it doesn't exist
+func (r *ManagedClusterForCguReconciler) ensureNamespace(ctx context.Context, name string) error {
 	namespace := &corev1.Namespace{
 		ObjectMeta: metav1.ObjectMeta{
-			Name: ztpInstallNS,
+			Name: name,
 		},
 	}
-	if err := r.Create(context.TODO(), namespace); err != nil {
+	if err := r.Create(ctx, namespace); err != nil {
 		// fail to create namespace
 		if !errors.IsAlreadyExists(err) {
 			return err
 		}
 	}
+	return nil
+}
+
+/* reconcileDay2 creates a follow-up ClusterGroupUpgrade remediating the NonCompliant wave-annotated child policies
+   of a cluster with the done label, when the day-2 remediation is enabled in the ZTP settings.
+   A cluster has at most one follow-up ClusterGroupUpgrade, named <cluster>-day2: a succeeded one is deleted and
+   created again on the next reconcile, triggered by its deletion. An unfinished one is left alone, and so is a
+   timed out one until it is deleted.
+
+   returns: error/nil
+*/
+func (r *ManagedClusterForCguReconciler) reconcileDay2(
+	ctx context.Context, cluster *clusterv1.ManagedCluster, ztpSettings *ranv1alpha1.ZTPSettings) error {
+
+	if !ztpSettings.Day2Remediation {
+		return nil
+	}
 
-	return ctrl.NewControllerManagedBy(mgr).
+	policies, err := utils.GetChildPolicies(ctx, r.Client, []string{cluster.Name})
+	if err != nil {
+		return err
+	}
+	var nonCompliantPolicies []policiesv1.Policy
+	for _, policy := range policies {
+		if policy.Status.ComplianceState == policiesv1.NonCompliant {
+			nonCompliantPolicies = append(nonCompliantPolicies, policy)
+		}
+	}
+	managedPolicies, err := r.getWaveOrderedPolicies(nonCompliantPolicies)
+	if err != nil {
+		return err
+	}
+	if len(managedPolicies) == 0 {
+		return nil
+	}
+
+	name := cluster.Name + ztpDay2Suffix
+	clusterGroupUpgrade := &ranv1alpha1.ClusterGroupUpgrade{}
+	err = r.Get(ctx, types.NamespacedName{Name: name, Namespace: ztpSettings.Namespace}, clusterGroupUpgrade)
+	if err == nil {
+		if !isCguSucceeded(clusterGroupUpgrade) {
+			r.Log.Info("Follow-up clusterGroupUpgrade has not succeeded, not replacing it", "name", name,
+				"namespace", ztpSettings.Namespace)
+			return nil
+		}
+		r.Log.Info("Replacing the succeeded follow-up clusterGroupUpgrade", "name", name, "namespace", ztpSettings.Namespace)
+		if err := r.Delete(ctx, clusterGroupUpgrade); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+		return nil
+	}
+	if !errors.IsNotFound(err) {
+		return err
+	}
+
+	enable := true
+	clusterGroupUpgrade = &ranv1alpha1.ClusterGroupUpgrade{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: ztpSettings.Namespace,
+		},
+		Spec: ranv1alpha1.ClusterGroupUpgradeSpec{
+			Enable:          &enable,
+			Clusters:        []string{cluster.Name},
+			ManagedPolicies: managedPolicies,
+			RemediationStrategy: &ranv1alpha1.RemediationStrategySpec{
+				MaxConcurrency: 1,
+				Timeout:        ztpSettings.Timeout,
+				ParallelWaves:  ztpSettings.ParallelWaves,
+			},
+			BatchTimeoutAction: ztpSettings.BatchTimeoutAction,
+		},
+	}
+	if err := controllerutil.SetControllerReference(cluster, clusterGroupUpgrade, r.Scheme); err != nil {
+		return err
+	}
+	if err := r.Create(ctx, clusterGroupUpgrade); err != nil {
+		r.Log.Error(err, "Fail to create follow-up clusterGroupUpgrade", "name", name, "namespace", ztpSettings.Namespace)
+		return err
+	}
+	r.Log.Info("Created follow-up clusterGroupUpgrade for the NonCompliant policies", "name", name,
+		"namespace", ztpSettings.Namespace, "policies", managedPolicies)
+	return nil
+}
+
+// ztpChildPolicyPredicate passes the creation of the child policies, for the clusters waiting for them, and the
+// wave-annotated child policies turning NonCompliant, for the day-2 remediation
+var ztpChildPolicyPredicate = predicate.Funcs{
+	GenericFunc: func(e event.GenericEvent) bool { return false },
+	CreateFunc:  func(e event.CreateEvent) bool { return utils.IsUserChildPolicy(e.Object) },
+	DeleteFunc:  func(e event.DeleteEvent) bool { return false },
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldPolicy, okOld := e.ObjectOld.(*policiesv1.Policy)
+		newPolicy, okNew := e.ObjectNew.(*policiesv1.Policy)
+		if !okOld || !okNew || !utils.IsUserChildPolicy(newPolicy) {
+			return false
+		}
+		if _, found := newPolicy.GetAnnotations()[ztpDeployWaveAnnotation]; !found {
+			return false
+		}
+		return oldPolicy.Status.ComplianceState != policiesv1.NonCompliant &&
+			newPolicy.Status.ComplianceState == policiesv1.NonCompliant
+	},
+}
+
+// mapChildPolicyToCluster maps a child policy to the managed cluster it is propagated to
+func mapChildPolicyToCluster(obj client.Object) []reconcile.Request {
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Name: obj.GetNamespace()}}}
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ManagedClusterForCguReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if err := r.ensureNamespace(context.TODO(), ztpInstallNS); err != nil {
+		return err
+	}
+
+	controllerBuilder := ctrl.NewControllerManagedBy(mgr).
 		Named("managedclusterForCGU").
 		For(&clusterv1.ManagedCluster{},
-			// watch for create event for managedcluster
+			// watch for create event for managedcluster, and label changes for the ZTP cluster selector
 			builder.WithPredicates(predicate.Funcs{
 				GenericFunc: func(e event.GenericEvent) bool { return false },
 				CreateFunc:  func(e event.CreateEvent) bool { return true },
 				DeleteFunc:  func(e event.DeleteEvent) bool { return false },
-				UpdateFunc:  func(e event.UpdateEvent) bool { return false },
+				UpdateFunc: func(e event.UpdateEvent) bool {
+					return !labels.Equals(e.ObjectOld.GetLabels(), e.ObjectNew.GetLabels())
+				},
 			})).
-		Owns(&ranv1alpha1.ClusterGroupUpgrade{},
-			// watch for delete event for owned ClusterGroupUpgrade
+		// watch for delete event for owned ClusterGroupUpgrade, including the ones shared by a group of clusters
+		Watches(&source.Kind{Type: &ranv1alpha1.ClusterGroupUpgrade{}},
+			&handler.EnqueueRequestForOwner{OwnerType: &clusterv1.ManagedCluster{}},
 			builder.WithPredicates(predicate.Funcs{
 				GenericFunc: func(e event.GenericEvent) bool { return false },
 				CreateFunc:  func(e event.CreateEvent) bool { return false },
 				DeleteFunc:  func(e event.DeleteEvent) bool { return true },
 				UpdateFunc:  func(e event.UpdateEvent) bool { return false },
 			})).
-		Complete(r)
+		Watches(&source.Kind{Type: &policiesv1.Policy{}},
+			handler.EnqueueRequestsFromMapFunc(mapChildPolicyToCluster),
+			builder.WithPredicates(ztpChildPolicyPredicate))
+	if r.Shards != nil {
+		controllerBuilder = controllerBuilder.Watches(r.Shards.resyncSource(r.listManagedClustersOfShard),
+			&handler.EnqueueRequestForObject{})
+	}
+	return controllerBuilder.Complete(r)
+}
+
+// listManagedClustersOfShard lists all the managed clusters once this replica acquires shard 0
+func (r *ManagedClusterForCguReconciler) listManagedClustersOfShard(shard int) []client.Object {
+	if shard != 0 {
+		return nil
+	}
+	managedClusterList := &clusterv1.ManagedClusterList{}
+	if err := r.List(context.TODO(), managedClusterList); err != nil {
+		r.Log.Error(err, "[listManagedClustersOfShard] Failed to list ManagedClusters")
+		return nil
+	}
+	var managedClusters []client.Object
+	for i := range managedClusterList.Items {
+		managedClusters = append(managedClusters, &managedClusterList.Items[i])
+	}
+	return managedClusters
 }
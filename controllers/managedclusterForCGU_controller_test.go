@@ -18,23 +18,29 @@ package controllers
 
 import (
 	"context"
+	"reflect"
 	"strconv"
 	"testing"
 
 	"github.com/go-logr/logr"
 	policiesv1 "github.com/open-cluster-management/governance-policy-propagator/api/v1"
+	viewv1beta1 "github.com/open-cluster-management/multicloud-operators-foundation/pkg/apis/view/v1beta1"
 	ranv1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/api/v1alpha1"
 	"github.com/openshift-kni/cluster-group-upgrades-operator/controllers/utils"
 	"github.com/stretchr/testify/assert"
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/kubernetes/scheme"
 	clusterv1 "open-cluster-management.io/api/cluster/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
@@ -44,15 +50,69 @@ var (
 
 func init() {
 	testscheme.AddKnownTypes(clusterv1.GroupVersion, &clusterv1.ManagedCluster{})
+	testscheme.AddKnownTypes(clusterv1.GroupVersion, &clusterv1.ManagedClusterList{})
 	testscheme.AddKnownTypes(ranv1alpha1.GroupVersion, &ranv1alpha1.ClusterGroupUpgrade{})
 	testscheme.AddKnownTypes(ranv1alpha1.GroupVersion, &ranv1alpha1.ClusterGroupUpgradeList{})
+	testscheme.AddKnownTypes(ranv1alpha1.GroupVersion, &ranv1alpha1.ClusterGroupUpgradeOperatorConfig{})
 	testscheme.AddKnownTypes(policiesv1.GroupVersion, &policiesv1.Policy{})
 	testscheme.AddKnownTypes(policiesv1.GroupVersion, &policiesv1.PolicyList{})
+	testscheme.AddKnownTypes(viewv1beta1.GroupVersion, &viewv1beta1.ManagedClusterView{})
 }
 
 func getFakeClientFromObjects(objs ...client.Object) (client.WithWatch, error) {
 	c := fake.NewClientBuilder().WithScheme(testscheme).WithObjects(objs...).Build()
-	return c, nil
+	return &indexedFakeClient{WithWatch: c}, nil
+}
+
+// indexedFakeClient lists objects by the field indexes of the manager cache, which the fake client ignores
+type indexedFakeClient struct {
+	client.WithWatch
+}
+
+func (c *indexedFakeClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	listOpts := &client.ListOptions{}
+	listOpts.ApplyOptions(opts)
+	if listOpts.FieldSelector == nil || listOpts.FieldSelector.Empty() {
+		return c.WithWatch.List(ctx, list, opts...)
+	}
+	fieldSelector := listOpts.FieldSelector
+	listOpts.FieldSelector = nil
+	if err := c.WithWatch.List(ctx, list, listOpts); err != nil {
+		return err
+	}
+
+	items, err := meta.ExtractList(list)
+	if err != nil {
+		return err
+	}
+	var filtered []runtime.Object
+	for _, item := range items {
+		obj := item.(client.Object)
+		if matchesFieldIndexes(obj, fieldSelector) {
+			filtered = append(filtered, item)
+		}
+	}
+	return meta.SetList(list, filtered)
+}
+
+func matchesFieldIndexes(obj client.Object, fieldSelector fields.Selector) bool {
+	for _, requirement := range fieldSelector.Requirements() {
+		found := false
+		for _, indexer := range utils.FieldIndexers {
+			if indexer.Field != requirement.Field || reflect.TypeOf(indexer.Object) != reflect.TypeOf(obj) {
+				continue
+			}
+			for _, value := range indexer.Extract(obj) {
+				if value == requirement.Value {
+					found = true
+				}
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
 }
 
 func TestControllerReconciler(t *testing.T) {
@@ -148,8 +208,8 @@ func TestControllerReconciler(t *testing.T) {
 				},
 			},
 			validateFunc: func(t *testing.T, result ctrl.Result, runtimeClient client.Client) {
-				if !result.IsZero() {
-					t.Errorf("expect to stop reconcile, but failed")
+				if result.IsZero() || result.RequeueAfter != clusterStatusCheckRetryDelay {
+					t.Errorf("expect to reconcile after %v, but failed", clusterStatusCheckRetryDelay)
 				}
 			},
 		},
@@ -542,3 +602,238 @@ func TestControllerReconcileWithHundredClusters(t *testing.T) {
 		t.Errorf("expected a hundred of ClusterGroupUpgrades, but failed with %d", len(clusterGroupUpgrades.Items))
 	}
 }
+
+func getDay2TestObjects(day2Remediation bool) []client.Object {
+	newChildPolicy := func(name, wave string, compliance policiesv1.ComplianceState) *policiesv1.Policy {
+		policy := &policiesv1.Policy{
+			ObjectMeta: v1.ObjectMeta{
+				Name:      "ztp-common." + name,
+				Namespace: "testSpoke",
+				Labels:    map[string]string{utils.ChildPolicyLabel: "ztp-common." + name},
+			},
+			Status: policiesv1.PolicyStatus{ComplianceState: compliance},
+		}
+		if wave != "" {
+			policy.Annotations = map[string]string{ztpDeployWaveAnnotation: wave}
+		}
+		return policy
+	}
+	return []client.Object{
+		&corev1.Namespace{ObjectMeta: v1.ObjectMeta{Name: ztpInstallNS}},
+		&clusterv1.ManagedCluster{
+			ObjectMeta: v1.ObjectMeta{Name: "testSpoke", Labels: map[string]string{ztpDoneLabel: ""}},
+		},
+		&ranv1alpha1.ClusterGroupUpgradeOperatorConfig{
+			ObjectMeta: v1.ObjectMeta{Name: utils.OperatorConfigName},
+			Spec: ranv1alpha1.ClusterGroupUpgradeOperatorConfigSpec{
+				ZTP: &ranv1alpha1.ZTPSettings{Day2Remediation: day2Remediation},
+			},
+		},
+		newChildPolicy("sub-policy", "2", policiesv1.NonCompliant),
+		newChildPolicy("config-policy", "1", policiesv1.NonCompliant),
+		newChildPolicy("compliant-policy", "1", policiesv1.Compliant),
+		newChildPolicy("no-wave-policy", "", policiesv1.NonCompliant),
+	}
+}
+
+func TestControllerReconcileDay2(t *testing.T) {
+	day2Key := types.NamespacedName{Name: "testSpoke" + ztpDay2Suffix, Namespace: ztpInstallNS}
+	request := reconcile.Request{NamespacedName: types.NamespacedName{Name: "testSpoke"}}
+	testcases := []struct {
+		name            string
+		day2Remediation bool
+		existing        *ranv1alpha1.ClusterGroupUpgrade
+		validateFunc    func(t *testing.T, runtimeClient client.Client)
+	}{
+		{
+			name: "day-2 remediation disabled",
+			validateFunc: func(t *testing.T, runtimeClient client.Client) {
+				err := runtimeClient.Get(context.TODO(), day2Key, &ranv1alpha1.ClusterGroupUpgrade{})
+				assert.True(t, errors.IsNotFound(err))
+			},
+		},
+		{
+			name:            "follow-up CGU created for the NonCompliant policies",
+			day2Remediation: true,
+			validateFunc: func(t *testing.T, runtimeClient client.Client) {
+				clusterGroupUpgrade := &ranv1alpha1.ClusterGroupUpgrade{}
+				assert.NoError(t, runtimeClient.Get(context.TODO(), day2Key, clusterGroupUpgrade))
+				assert.Equal(t, []string{"testSpoke"}, clusterGroupUpgrade.Spec.Clusters)
+				assert.Equal(t, []string{"config-policy", "sub-policy"}, clusterGroupUpgrade.Spec.ManagedPolicies)
+				assert.Empty(t, clusterGroupUpgrade.Spec.Actions.AfterCompletion.AddClusterLabels)
+				assert.Equal(t, "testSpoke", clusterGroupUpgrade.OwnerReferences[0].Name)
+			},
+		},
+		{
+			name:            "unfinished follow-up CGU kept",
+			day2Remediation: true,
+			existing: &ranv1alpha1.ClusterGroupUpgrade{
+				ObjectMeta: v1.ObjectMeta{Name: day2Key.Name, Namespace: day2Key.Namespace},
+				Spec:       ranv1alpha1.ClusterGroupUpgradeSpec{ManagedPolicies: []string{"sub-policy"}},
+			},
+			validateFunc: func(t *testing.T, runtimeClient client.Client) {
+				clusterGroupUpgrade := &ranv1alpha1.ClusterGroupUpgrade{}
+				assert.NoError(t, runtimeClient.Get(context.TODO(), day2Key, clusterGroupUpgrade))
+				assert.Equal(t, []string{"sub-policy"}, clusterGroupUpgrade.Spec.ManagedPolicies)
+			},
+		},
+		{
+			name:            "succeeded follow-up CGU replaced",
+			day2Remediation: true,
+			existing: &ranv1alpha1.ClusterGroupUpgrade{
+				ObjectMeta: v1.ObjectMeta{Name: day2Key.Name, Namespace: day2Key.Namespace},
+				Spec:       ranv1alpha1.ClusterGroupUpgradeSpec{ManagedPolicies: []string{"sub-policy"}},
+				Status: ranv1alpha1.ClusterGroupUpgradeStatus{
+					Conditions: []v1.Condition{{Type: "Succeeded", Status: v1.ConditionTrue, Reason: "UpgradeCompleted"}},
+				},
+			},
+			validateFunc: func(t *testing.T, runtimeClient client.Client) {
+				clusterGroupUpgrade := &ranv1alpha1.ClusterGroupUpgrade{}
+				assert.NoError(t, runtimeClient.Get(context.TODO(), day2Key, clusterGroupUpgrade))
+				assert.Equal(t, []string{"config-policy", "sub-policy"}, clusterGroupUpgrade.Spec.ManagedPolicies)
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			objs := getDay2TestObjects(tc.day2Remediation)
+			if tc.existing != nil {
+				objs = append(objs, tc.existing)
+			}
+			fakeClient, err := getFakeClientFromObjects(objs...)
+			if err != nil {
+				t.Errorf("error in creating fake client")
+			}
+			r := &ManagedClusterForCguReconciler{Client: fakeClient, Log: logr.Discard(), Scheme: fakeClient.Scheme()}
+
+			// The deletion of a replaced CGU triggers another reconcile
+			for i := 0; i < 2; i++ {
+				result, err := r.Reconcile(context.TODO(), request)
+				assert.NoError(t, err)
+				assert.True(t, result.IsZero())
+			}
+			tc.validateFunc(t, fakeClient)
+		})
+	}
+}
+
+func TestControllerZtpChildPolicyPredicate(t *testing.T) {
+	newPolicy := func(labels map[string]string, wave bool, compliance policiesv1.ComplianceState) *policiesv1.Policy {
+		policy := &policiesv1.Policy{
+			ObjectMeta: v1.ObjectMeta{Name: "ztp-common.policy", Namespace: "testSpoke", Labels: labels},
+			Status:     policiesv1.PolicyStatus{ComplianceState: compliance},
+		}
+		if wave {
+			policy.Annotations = map[string]string{ztpDeployWaveAnnotation: "1"}
+		}
+		return policy
+	}
+	childLabels := map[string]string{utils.ChildPolicyLabel: "ztp-common.policy"}
+	copiedLabels := map[string]string{
+		utils.ChildPolicyLabel: "ztp-install.cgu-policy",
+		"openshift-cluster-group-upgrades/clusterGroupUpgrade": "cgu",
+	}
+
+	assert.True(t, ztpChildPolicyPredicate.Create(event.CreateEvent{Object: newPolicy(childLabels, false, "")}))
+	assert.False(t, ztpChildPolicyPredicate.Create(event.CreateEvent{Object: newPolicy(copiedLabels, true, "")}))
+	assert.False(t, ztpChildPolicyPredicate.Create(event.CreateEvent{Object: newPolicy(nil, true, "")}))
+
+	testcases := []struct {
+		name     string
+		old      *policiesv1.Policy
+		new      *policiesv1.Policy
+		expected bool
+	}{
+		{
+			name:     "wave-annotated policy turning NonCompliant",
+			old:      newPolicy(childLabels, true, policiesv1.Compliant),
+			new:      newPolicy(childLabels, true, policiesv1.NonCompliant),
+			expected: true,
+		},
+		{
+			name: "policy still NonCompliant",
+			old:  newPolicy(childLabels, true, policiesv1.NonCompliant),
+			new:  newPolicy(childLabels, true, policiesv1.NonCompliant),
+		},
+		{
+			name: "policy without wave turning NonCompliant",
+			old:  newPolicy(childLabels, false, policiesv1.Compliant),
+			new:  newPolicy(childLabels, false, policiesv1.NonCompliant),
+		},
+		{
+			name: "copied policy turning NonCompliant",
+			old:  newPolicy(copiedLabels, true, policiesv1.Compliant),
+			new:  newPolicy(copiedLabels, true, policiesv1.NonCompliant),
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, ztpChildPolicyPredicate.Update(event.UpdateEvent{ObjectOld: tc.old, ObjectNew: tc.new}))
+		})
+	}
+	assert.Equal(t, []reconcile.Request{{NamespacedName: types.NamespacedName{Name: "testSpoke"}}},
+		mapChildPolicyToCluster(newPolicy(childLabels, true, "")))
+}
+
+func TestControllerReconcileZtpSettings(t *testing.T) {
+	config := &ranv1alpha1.ClusterGroupUpgradeOperatorConfig{
+		ObjectMeta: v1.ObjectMeta{Name: utils.OperatorConfigName},
+		Spec: ranv1alpha1.ClusterGroupUpgradeOperatorConfigSpec{
+			ZTP: &ranv1alpha1.ZTPSettings{
+				Namespace:       "ztp-sites",
+				RunningLabel:    "sites-running",
+				DoneLabel:       "sites-done",
+				Timeout:         60,
+				PreCaching:      true,
+				ParallelWaves:   true,
+				ClusterSelector: &v1.LabelSelector{MatchLabels: map[string]string{"site": "true"}},
+			},
+		},
+	}
+	newCluster := func(name string, labels, annotations map[string]string) *clusterv1.ManagedCluster {
+		return &clusterv1.ManagedCluster{
+			ObjectMeta: v1.ObjectMeta{Name: name, Labels: labels, Annotations: annotations},
+			Status: clusterv1.ManagedClusterStatus{
+				Conditions: []v1.Condition{{Type: clusterv1.ManagedClusterConditionAvailable, Status: v1.ConditionTrue}},
+			},
+		}
+	}
+	newPolicy := func(cluster string) *policiesv1.Policy {
+		return &policiesv1.Policy{
+			ObjectMeta: v1.ObjectMeta{
+				Name:        "ztp-common.common-config-policy",
+				Namespace:   cluster,
+				Labels:      map[string]string{utils.ChildPolicyLabel: "ztp-common.common-config-policy"},
+				Annotations: map[string]string{ztpDeployWaveAnnotation: "1"},
+			},
+		}
+	}
+	fakeClient, err := getFakeClientFromObjects(config,
+		newCluster("site1", map[string]string{"site": "true"}, map[string]string{ztpTimeoutAnnotation: "90"}),
+		newCluster("site2", map[string]string{"site": "true", "sites-done": ""}, nil),
+		newCluster("other", nil, nil),
+		newPolicy("site1"), newPolicy("site2"), newPolicy("other"))
+	if err != nil {
+		t.Errorf("error in creating fake client")
+	}
+	r := &ManagedClusterForCguReconciler{Client: fakeClient, Log: logr.Discard(), Scheme: fakeClient.Scheme()}
+	for _, name := range []string{"site1", "site2", "other"} {
+		_, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: name}})
+		assert.NoError(t, err)
+	}
+
+	// Only the selected cluster without the done label gets a CGU, in the namespace of the settings
+	clusterGroupUpgrades := &ranv1alpha1.ClusterGroupUpgradeList{}
+	assert.NoError(t, fakeClient.List(context.TODO(), clusterGroupUpgrades))
+	assert.Len(t, clusterGroupUpgrades.Items, 1)
+	clusterGroupUpgrade := clusterGroupUpgrades.Items[0]
+	assert.Equal(t, "site1", clusterGroupUpgrade.Name)
+	assert.Equal(t, "ztp-sites", clusterGroupUpgrade.Namespace)
+	assert.Equal(t, 90, clusterGroupUpgrade.Spec.RemediationStrategy.Timeout)
+	assert.True(t, clusterGroupUpgrade.Spec.RemediationStrategy.ParallelWaves)
+	assert.True(t, clusterGroupUpgrade.Spec.PreCaching)
+	assert.False(t, clusterGroupUpgrade.Spec.Backup)
+	assert.Equal(t, map[string]string{"sites-running": ""}, clusterGroupUpgrade.Spec.Actions.BeforeEnable.AddClusterLabels)
+	assert.Equal(t, map[string]string{"sites-done": ""}, clusterGroupUpgrade.Spec.Actions.AfterCompletion.AddClusterLabels)
+	assert.NoError(t, fakeClient.Get(context.TODO(), types.NamespacedName{Name: "ztp-sites"}, &corev1.Namespace{}))
+}
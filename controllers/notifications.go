@@ -0,0 +1,129 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	ranv1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const notificationTimeout = 10 * time.Second
+
+// stateNotification is the document sent to the notification sinks when a CGU changes state
+type stateNotification struct {
+	Name          string      `json:"name"`
+	Namespace     string      `json:"namespace"`
+	PreviousState string      `json:"previousState,omitempty"`
+	State         string      `json:"state"`
+	Message       string      `json:"message,omitempty"`
+	Timestamp     metav1.Time `json:"timestamp"`
+}
+
+// getCguState returns the reason of the Ready condition of a CGU, empty if it is not set yet
+func getCguState(clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade) string {
+	readyCondition := meta.FindStatusCondition(clusterGroupUpgrade.Status.Conditions, "Ready")
+	if readyCondition == nil {
+		return ""
+	}
+	return readyCondition.Reason
+}
+
+// notifyStateChange sends the new state of the CGU to the configured notification sinks.
+// The notifications are sent in the background and failures are only logged.
+// returns: error/nil
+func (r *ClusterGroupUpgradeReconciler) notifyStateChange(
+	ctx context.Context, clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade, previousState string) error {
+
+	settings, err := r.getOperatorSettings(ctx, clusterGroupUpgrade.Namespace)
+	if err != nil {
+		return err
+	}
+	if len(settings.NotificationSinks) == 0 {
+		return nil
+	}
+
+	notification := stateNotification{
+		Name:          clusterGroupUpgrade.Name,
+		Namespace:     clusterGroupUpgrade.Namespace,
+		PreviousState: previousState,
+		State:         getCguState(clusterGroupUpgrade),
+		Timestamp:     metav1.Now(),
+	}
+	if readyCondition := meta.FindStatusCondition(clusterGroupUpgrade.Status.Conditions, "Ready"); readyCondition != nil {
+		notification.Message = readyCondition.Message
+	}
+	body, err := json.Marshal(notification)
+	if err != nil {
+		return err
+	}
+
+	for _, sink := range settings.NotificationSinks {
+		if !sinkWantsState(sink, notification.State) {
+			continue
+		}
+		go func(sink ranv1alpha1.NotificationSink) {
+			if err := sendNotification(sink.URL, body); err != nil {
+				r.Log.Error(err, "[notifyStateChange] Failed to notify sink", "sink", sink.Name,
+					"name", notification.Name, "namespace", notification.Namespace)
+			}
+		}(sink)
+	}
+	return nil
+}
+
+// sinkWantsState returns true if the sink subscribed to the given state
+func sinkWantsState(sink ranv1alpha1.NotificationSink, state string) bool {
+	if len(sink.Reasons) == 0 {
+		return true
+	}
+	for _, reason := range sink.Reasons {
+		if reason == state {
+			return true
+		}
+	}
+	return false
+}
+
+// sendNotification POSTs the JSON body to the given URL
+// returns: error/nil
+func sendNotification(url string, body []byte) error {
+	ctx, cancel := context.WithTimeout(context.Background(), notificationTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("notification sink returned %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	ranv1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/api/v1alpha1"
+	utils "github.com/openshift-kni/cluster-group-upgrades-operator/controllers/utils"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Default requeue intervals, used unless overridden in the operator configuration
+const (
+	defaultShortRequeueInterval  = 30 * time.Second
+	defaultMediumRequeueInterval = 1 * time.Minute
+	defaultLongRequeueInterval   = 5 * time.Minute
+)
+
+// defaultCguTimeout is the timeout in minutes of the CGUs, unless overridden in the operator configuration
+const defaultCguTimeout = 240
+
+/* getOperatorConfig reads the cluster-wide ClusterGroupUpgradeOperatorConfig.
+   An empty configuration is returned if the object doesn't exist or if its CRD is not installed.
+
+   returns: *ranv1alpha1.ClusterGroupUpgradeOperatorConfigSpec
+            error/nil
+*/
+func getOperatorConfig(ctx context.Context, c client.Client) (*ranv1alpha1.ClusterGroupUpgradeOperatorConfigSpec, error) {
+	config := &ranv1alpha1.ClusterGroupUpgradeOperatorConfig{}
+	err := c.Get(ctx, types.NamespacedName{Name: utils.OperatorConfigName}, config)
+	if err != nil {
+		if errors.IsNotFound(err) || meta.IsNoMatchError(err) {
+			return &ranv1alpha1.ClusterGroupUpgradeOperatorConfigSpec{}, nil
+		}
+		return nil, err
+	}
+	return &config.Spec, nil
+}
+
+// getNamespaceSettings returns the cluster-wide operator settings with the overrides of the namespace applied
+func getNamespaceSettings(config *ranv1alpha1.ClusterGroupUpgradeOperatorConfigSpec, namespace string) ranv1alpha1.OperatorSettings {
+	settings := *config.OperatorSettings.DeepCopy()
+	for _, overrides := range config.NamespaceOverrides {
+		if overrides.Namespace != namespace {
+			continue
+		}
+		if overrides.PrecacheImage != "" {
+			settings.PrecacheImage = overrides.PrecacheImage
+		}
+		if overrides.RecoveryImage != "" {
+			settings.RecoveryImage = overrides.RecoveryImage
+		}
+		if overrides.PlatformImage != "" {
+			settings.PlatformImage = overrides.PlatformImage
+		}
+		if len(overrides.OperatorsIndexes) != 0 {
+			settings.OperatorsIndexes = overrides.OperatorsIndexes
+		}
+		if len(overrides.OperatorsPackagesAndChannels) != 0 {
+			settings.OperatorsPackagesAndChannels = overrides.OperatorsPackagesAndChannels
+		}
+		if overrides.DefaultTimeout != 0 {
+			settings.DefaultTimeout = overrides.DefaultTimeout
+		}
+		if overrides.DefaultBatchTimeoutAction != "" {
+			settings.DefaultBatchTimeoutAction = overrides.DefaultBatchTimeoutAction
+		}
+		if overrides.PrecacheJob != nil {
+			settings.PrecacheJob = overrides.PrecacheJob
+		}
+		if overrides.BackupJob != nil {
+			settings.BackupJob = overrides.BackupJob
+		}
+		// Notification sinks add up so that a namespace can't silence the cluster-wide ones
+		settings.NotificationSinks = append(settings.NotificationSinks, overrides.NotificationSinks...)
+	}
+	return settings
+}
+
+// getOperatorSettings returns the operator settings used for the CGUs of the given namespace
+// returns: ranv1alpha1.OperatorSettings, error
+func (r *ClusterGroupUpgradeReconciler) getOperatorSettings(
+	ctx context.Context, namespace string) (ranv1alpha1.OperatorSettings, error) {
+
+	config, err := getOperatorConfig(ctx, r.Client)
+	if err != nil {
+		return ranv1alpha1.OperatorSettings{}, err
+	}
+	return getNamespaceSettings(config, namespace), nil
+}
+
+/* setComputedTimeout records the timeout of a CGU in its status: the timeout of the spec, or the default timeout
+   of its namespace when the spec doesn't set one. The default timeout is only resolved once, so that a CGU keeps
+   its timeout when the operator configuration changes. The spec is left as written by the user.
+
+   returns: error/nil
+*/
+func (r *ClusterGroupUpgradeReconciler) setComputedTimeout(
+	ctx context.Context, clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade) error {
+
+	if clusterGroupUpgrade.Spec.RemediationStrategy != nil && clusterGroupUpgrade.Spec.RemediationStrategy.Timeout != 0 {
+		clusterGroupUpgrade.Status.ComputedTimeout = clusterGroupUpgrade.Spec.RemediationStrategy.Timeout
+		return nil
+	}
+	if clusterGroupUpgrade.Status.ComputedTimeout != 0 {
+		return nil
+	}
+	settings, err := r.getOperatorSettings(ctx, clusterGroupUpgrade.Namespace)
+	if err != nil {
+		return err
+	}
+	clusterGroupUpgrade.Status.ComputedTimeout = settings.DefaultTimeout
+	if clusterGroupUpgrade.Status.ComputedTimeout == 0 {
+		clusterGroupUpgrade.Status.ComputedTimeout = defaultCguTimeout
+	}
+	return nil
+}
+
+// getCguTimeout returns the timeout of a CGU in minutes, set in the spec or computed by setComputedTimeout
+func getCguTimeout(clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade) int {
+	if clusterGroupUpgrade.Spec.RemediationStrategy != nil && clusterGroupUpgrade.Spec.RemediationStrategy.Timeout != 0 {
+		return clusterGroupUpgrade.Spec.RemediationStrategy.Timeout
+	}
+	return clusterGroupUpgrade.Status.ComputedTimeout
+}
+
+// refreshOperatorConfig caches the cluster-wide operator configuration for the settings that are
+// not specific to a CGU, like the requeue intervals and the fleet-wide concurrency limits
+// returns: error/nil
+func (r *ClusterGroupUpgradeReconciler) refreshOperatorConfig(ctx context.Context) error {
+	config, err := getOperatorConfig(ctx, r.Client)
+	if err != nil {
+		return err
+	}
+	r.operatorConfigLock.Lock()
+	defer r.operatorConfigLock.Unlock()
+	r.operatorConfig = *config
+	return nil
+}
+
+// getRequeueIntervals returns the short, medium and long requeue intervals
+func (r *ClusterGroupUpgradeReconciler) getRequeueIntervals() (short, medium, long time.Duration) {
+	short, medium, long = defaultShortRequeueInterval, defaultMediumRequeueInterval, defaultLongRequeueInterval
+
+	r.operatorConfigLock.RLock()
+	defer r.operatorConfigLock.RUnlock()
+	intervals := r.operatorConfig.RequeueIntervals
+	if intervals == nil {
+		return
+	}
+	if intervals.Short != nil && intervals.Short.Duration > 0 {
+		short = intervals.Short.Duration
+	}
+	if intervals.Medium != nil && intervals.Medium.Duration > 0 {
+		medium = intervals.Medium.Duration
+	}
+	if intervals.Long != nil && intervals.Long.Duration > 0 {
+		long = intervals.Long.Duration
+	}
+	return
+}
@@ -0,0 +1,95 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	ranv1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/api/v1alpha1"
+	utils "github.com/openshift-kni/cluster-group-upgrades-operator/controllers/utils"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestOperatorConfig_setComputedTimeout(t *testing.T) {
+	config := &ranv1alpha1.ClusterGroupUpgradeOperatorConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: utils.OperatorConfigName},
+		Spec: ranv1alpha1.ClusterGroupUpgradeOperatorConfigSpec{
+			OperatorSettings: ranv1alpha1.OperatorSettings{DefaultTimeout: 120},
+			NamespaceOverrides: []ranv1alpha1.NamespaceOverrides{{
+				Namespace:        "ztp-install",
+				OperatorSettings: ranv1alpha1.OperatorSettings{DefaultTimeout: 60},
+			}},
+		},
+	}
+	newCgu := func(namespace string, timeout int) *ranv1alpha1.ClusterGroupUpgrade {
+		return &ranv1alpha1.ClusterGroupUpgrade{
+			ObjectMeta: metav1.ObjectMeta{Name: "cgu", Namespace: namespace},
+			Spec: ranv1alpha1.ClusterGroupUpgradeSpec{
+				RemediationStrategy: &ranv1alpha1.RemediationStrategySpec{MaxConcurrency: 1, Timeout: timeout},
+			},
+		}
+	}
+	testcases := []struct {
+		name    string
+		objs    []client.Object
+		cgu     *ranv1alpha1.ClusterGroupUpgrade
+		timeout int
+	}{
+		{
+			name:    "cluster-wide default timeout",
+			objs:    []client.Object{config},
+			cgu:     newCgu("default", 0),
+			timeout: 120,
+		},
+		{
+			name:    "namespace default timeout",
+			objs:    []client.Object{config},
+			cgu:     newCgu("ztp-install", 0),
+			timeout: 60,
+		},
+		{
+			name:    "no operator configuration",
+			cgu:     newCgu("default", 0),
+			timeout: defaultCguTimeout,
+		},
+		{
+			name:    "timeout set in the CGU",
+			objs:    []client.Object{config},
+			cgu:     newCgu("default", 30),
+			timeout: 30,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeClient, err := getFakeClientFromObjects(append(tc.objs, tc.cgu)...)
+			if err != nil {
+				t.Errorf("error in creating fake client")
+			}
+			r := &ClusterGroupUpgradeReconciler{Client: fakeClient, Log: logr.Discard(), Scheme: testscheme}
+
+			spec := tc.cgu.Spec.DeepCopy()
+			assert.NoError(t, r.setComputedTimeout(context.TODO(), tc.cgu))
+			assert.Equal(t, tc.timeout, tc.cgu.Status.ComputedTimeout)
+			assert.Equal(t, tc.timeout, getCguTimeout(tc.cgu))
+			// The spec is left as written
+			assert.Equal(t, spec, &tc.cgu.Spec)
+		})
+	}
+
+	// The computed default timeout is kept when the operator configuration changes
+	cgu := newCgu("default", 0)
+	cgu.Status.ComputedTimeout = 120
+	fakeClient, err := getFakeClientFromObjects(cgu)
+	if err != nil {
+		t.Errorf("error in creating fake client")
+	}
+	r := &ClusterGroupUpgradeReconciler{Client: fakeClient, Log: logr.Discard(), Scheme: testscheme}
+	assert.NoError(t, r.setComputedTimeout(context.TODO(), cgu))
+	assert.Equal(t, 120, getCguTimeout(cgu))
+	// A timeout set later in the spec applies
+	cgu.Spec.RemediationStrategy.Timeout = 30
+	assert.NoError(t, r.setComputedTimeout(context.TODO(), cgu))
+	assert.Equal(t, 30, cgu.Status.ComputedTimeout)
+}
@@ -2,6 +2,7 @@ package controllers
 
 import (
 	"context"
+	"strings"
 
 	ranv1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/api/v1alpha1"
 	utils "github.com/openshift-kni/cluster-group-upgrades-operator/controllers/utils"
@@ -14,12 +15,32 @@ import (
 // getOverrides: reads user overrides to operator configuration
 //		An example for such an override would be the pre-cache
 //      workload image. It's usually taken from the operator CSV,
-//      but a user might need to override it in some cases
+//      but a user might need to override it in some cases.
+//		The overrides are taken from the ClusterGroupUpgradeOperatorConfig,
+//		with the settings of the CGU namespace applied on top. Entries of the
+//		cluster-group-upgrade-overrides ConfigMap in the CGU namespace take
+//		precedence over both
 func (r *ClusterGroupUpgradeReconciler) getOverrides(
 	ctx context.Context, clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade) (
 	map[string]string, error) {
 
 	configData := make(map[string]string)
+	settings, err := r.getOperatorSettings(ctx, clusterGroupUpgrade.Namespace)
+	if err != nil {
+		return configData, err
+	}
+	for key, value := range map[string]string{
+		"precache.image":                settings.PrecacheImage,
+		"recovery.image":                settings.RecoveryImage,
+		"platform.image":                settings.PlatformImage,
+		"operators.indexes":             strings.Join(settings.OperatorsIndexes, "\n"),
+		"operators.packagesAndChannels": strings.Join(settings.OperatorsPackagesAndChannels, "\n"),
+	} {
+		if value != "" {
+			configData[key] = value
+		}
+	}
+
 	cm := &corev1.ConfigMap{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: "v1",
@@ -29,16 +50,17 @@ func (r *ClusterGroupUpgradeReconciler) getOverrides(
 			Name:      utils.OperatorConfigOverrides,
 			Namespace: clusterGroupUpgrade.Namespace,
 		},
-		Data: configData,
 	}
 	found := &corev1.ConfigMap{}
-	err := r.Get(ctx, types.NamespacedName{Namespace: cm.Namespace, Name: cm.Name}, found)
+	err = r.Get(ctx, types.NamespacedName{Namespace: cm.Namespace, Name: cm.Name}, found)
 	if err != nil {
 		if errors.IsNotFound(err) {
 			return configData, nil
 		}
 		return configData, err
 	}
-	configData = found.Data
+	for key, value := range found.Data {
+		configData[key] = value
+	}
 	return configData, nil
 }
@@ -85,3 +85,96 @@ func TestOverrides_getOverrides(t *testing.T) {
 		})
 	}
 }
+
+func TestOverrides_getOverridesFromOperatorConfig(t *testing.T) {
+	config := &ranv1alpha1.ClusterGroupUpgradeOperatorConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: utils.OperatorConfigName},
+		Spec: ranv1alpha1.ClusterGroupUpgradeOperatorConfigSpec{
+			OperatorSettings: ranv1alpha1.OperatorSettings{
+				PrecacheImage:    "cluster-image:test-tag",
+				RecoveryImage:    "recovery-image:test-tag",
+				OperatorsIndexes: []string{"registry.example.com:5000/index-a:v0.0", "registry.example.com:5000/index-b:v0.0"},
+			},
+			NamespaceOverrides: []ranv1alpha1.NamespaceOverrides{
+				{
+					Namespace: "test",
+					OperatorSettings: ranv1alpha1.OperatorSettings{
+						PrecacheImage: "namespace-image:test-tag",
+						PlatformImage: "namespace-platform-image:test-tag",
+					},
+				},
+			},
+		},
+	}
+	testcases := []struct {
+		name   string
+		readNs string
+		cmData map[string]string
+		rdData map[string]string
+	}{
+		{
+			name:   "Cluster-wide settings only",
+			readNs: "other",
+			rdData: map[string]string{
+				"precache.image":    "cluster-image:test-tag",
+				"recovery.image":    "recovery-image:test-tag",
+				"operators.indexes": "registry.example.com:5000/index-a:v0.0\nregistry.example.com:5000/index-b:v0.0",
+			},
+		},
+		{
+			name:   "Namespace overrides applied on top",
+			readNs: "test",
+			rdData: map[string]string{
+				"precache.image":    "namespace-image:test-tag",
+				"recovery.image":    "recovery-image:test-tag",
+				"platform.image":    "namespace-platform-image:test-tag",
+				"operators.indexes": "registry.example.com:5000/index-a:v0.0\nregistry.example.com:5000/index-b:v0.0",
+			},
+		},
+		{
+			name:   "ConfigMap takes precedence",
+			readNs: "test",
+			cmData: map[string]string{
+				"precache.image": "configmap-image:test-tag",
+			},
+			rdData: map[string]string{
+				"precache.image":    "configmap-image:test-tag",
+				"recovery.image":    "recovery-image:test-tag",
+				"platform.image":    "namespace-platform-image:test-tag",
+				"operators.indexes": "registry.example.com:5000/index-a:v0.0\nregistry.example.com:5000/index-b:v0.0",
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			c, err := getFakeClientFromObjects(config.DeepCopy())
+			if err != nil {
+				t.Errorf("error in creating fake client")
+			}
+			r := &ClusterGroupUpgradeReconciler{
+				Client: c,
+				Log:    logr.Discard(),
+				Scheme: testscheme,
+			}
+			if tc.cmData != nil {
+				cm := &corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      utils.OperatorConfigOverrides,
+						Namespace: tc.readNs,
+					},
+					Data: tc.cmData,
+				}
+				if err := r.Create(context.TODO(), cm); err != nil {
+					t.Errorf("error creating a configmap: %v", err)
+				}
+			}
+			var cgu ranv1alpha1.ClusterGroupUpgrade
+			cgu.Namespace = tc.readNs
+			res, err := r.getOverrides(context.TODO(), &cgu)
+			if err != nil {
+				t.Errorf("error reading the overrides: %v", err)
+			}
+			assert.Equal(t, tc.rdData, res)
+		})
+	}
+}
@@ -2,21 +2,26 @@ package controllers
 
 import (
 	"context"
-	"crypto/tls"
+	"crypto/sha256"
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
-	"net/http"
 	"os"
-
+	"regexp"
+	"sort"
 	"strings"
 
+	viewv1beta1 "github.com/open-cluster-management/multicloud-operators-foundation/pkg/apis/view/v1beta1"
 	ranv1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/api/v1alpha1"
 	"github.com/openshift-kni/cluster-group-upgrades-operator/controllers/utils"
+	operatorsv1alpha1 "github.com/operator-framework/api/pkg/operators/v1alpha1"
 
+	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 )
 
 // reconcilePrecaching provides the main precaching entry point
@@ -54,49 +59,256 @@ func (r *ClusterGroupUpgradeReconciler) reconcilePrecaching(
 	return nil
 }
 
-// getImageForVersionFromUpdateGraph gets the image for the given version
-// by traversing the update graph.
-// Connecting to the upstream URL with the channel passed as a parameter
-// the update graph is returned as JSON. This function then traverses
-// the nodes list from that JSON to find the version and if found
-// then returns the image
-func (r *ClusterGroupUpgradeReconciler) getImageForVersionFromUpdateGraph(
-	upstream string, channel string, version string) (string, error) {
-	updateGraphURL := upstream + "?channel=" + channel
+// precachingSpecError is a software spec found in the policies that can't be pre-cached
+type precachingSpecError struct {
+	reason  string
+	message string
+}
+
+func (e *precachingSpecError) Error() string {
+	return e.message
+}
+
+// installedCSVPendingError is returned while the installed CSVs of the Subscriptions of a cluster are not read yet
+type installedCSVPendingError struct {
+	packages []string
+}
+
+func (e *installedCSVPendingError) Error() string {
+	return fmt.Sprintf("waiting for the installed CSV of the Subscriptions of packages %v to be read", e.packages)
+}
+
+/* updatePrecachingSpecs computes the software spec each cluster pre-caches and records it in the status, along
+   with the PrecacheSpecValid condition. The spec of a cluster is extracted from the child policies of the
+   managed policies propagated to it, as their hub templates may resolve to different software on each cluster.
+   The clusters sharing the same spec are grouped, a single spec shared by all the clusters is recorded in
+   precaching.spec, distinct specs in precaching.specs along with the name of the spec of each cluster in
+   precaching.clusterSpecs.
+
+   returns: bool the specs are valid
+            error
+*/
+func (r *ClusterGroupUpgradeReconciler) updatePrecachingSpecs(ctx context.Context,
+	clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade, clusters []string) (bool, error) {
+
+	clusterSpecs := make(map[string]ranv1alpha1.PrecachingSpec)
+	if isStandalonePrecaching(clusterGroupUpgrade) {
+		// The software spec of a standalone CGU is only the one of its pre-caching config
+		spec := includePreCachingConfig(clusterGroupUpgrade, ranv1alpha1.PrecachingSpec{})
+		for _, cluster := range clusters {
+			clusterSpecs[cluster] = spec
+		}
+	} else {
+		allManagedPoliciesExist, managedPoliciesMissing, managedPoliciesPresent, err := r.doManagedPoliciesExist(
+			ctx, clusterGroupUpgrade, false)
+		if err != nil {
+			return false, err
+		}
+		if !allManagedPoliciesExist {
+			setPrecacheSpecValidCondition(clusterGroupUpgrade, metav1.ConditionFalse, "NotAllManagedPoliciesExist",
+				fmt.Sprintf("The ClusterGroupUpgrade CR has managed policies that are missing: %s", managedPoliciesMissing))
+			return false, nil
+		}
+		clusterPolicies, err := r.getClusterPrecachingPolicies(ctx, managedPoliciesPresent, clusters)
+		if err != nil {
+			return false, err
+		}
 
-	tr := &http.Transport{
-		TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		// The views of the installed CSVs are created for all the clusters at once, the specs are only computed
+		// once all of them are read
+		var pendingClusters []string
+		for _, cluster := range clusters {
+			spec, err := r.extractPrecachingSpecFromPolicies(ctx, clusterGroupUpgrade, cluster, clusterPolicies[cluster])
+			if _, ok := err.(*installedCSVPendingError); ok {
+				pendingClusters = append(pendingClusters, cluster)
+				continue
+			}
+			if specErr, ok := err.(*precachingSpecError); ok {
+				setPrecacheSpecValidCondition(clusterGroupUpgrade, metav1.ConditionFalse, specErr.reason,
+					fmt.Sprintf("Cluster %s: %s", cluster, specErr.message))
+				return false, nil
+			}
+			if err != nil {
+				return false, err
+			}
+			r.Log.Info("[updatePrecachingSpecs]", "PrecacheSpecFromPolicies", spec, "cluster", cluster)
+			spec, err = r.includeSoftwareSpecOverrides(ctx, clusterGroupUpgrade, &spec)
+			if err != nil {
+				return false, err
+			}
+			clusterSpecs[cluster] = includePreCachingConfig(clusterGroupUpgrade, spec)
+		}
+		if len(pendingClusters) != 0 {
+			// Nothing is wrong with the spec yet, the view updates reconcile the CGU again
+			setPrecacheSpecValidCondition(clusterGroupUpgrade, metav1.ConditionUnknown, "InstalledCSVPending",
+				fmt.Sprintf("Waiting for the installed CSVs of the Subscriptions to be read for clusters %v",
+					pendingClusters))
+			return false, nil
+		}
 	}
-	client := &http.Client{Transport: tr}
-	req, _ := http.NewRequest("GET", updateGraphURL, nil)
-	req.Header.Add("Accept", "application/json")
-	res, err := client.Do(req)
 
-	if err != nil {
-		return "", fmt.Errorf("unable to request update graph on url %s: %w", updateGraphURL, err)
+	specs := make(map[string]*ranv1alpha1.PrecachingSpec)
+	specClusters := make(map[string][]string)
+	specNames := make(map[string]string)
+	for _, cluster := range clusters {
+		spec := clusterSpecs[cluster]
+		name, err := getPrecachingSpecName(spec)
+		if err != nil {
+			return false, err
+		}
+		specs[name] = &spec
+		specClusters[name] = append(specClusters[name], cluster)
+		specNames[cluster] = name
+	}
+	var sortedSpecNames []string
+	for name := range specs {
+		sortedSpecNames = append(sortedSpecNames, name)
+	}
+	sort.Strings(sortedSpecNames)
+	for _, name := range sortedSpecNames {
+		if ok, msg := r.checkPreCacheSpecConsistency(*specs[name]); !ok {
+			if len(specs) > 1 {
+				msg = fmt.Sprintf("%s for clusters %v", msg, specClusters[name])
+			}
+			setPrecacheSpecValidCondition(clusterGroupUpgrade, metav1.ConditionFalse, "PrecacheSpecIsIncomplete", msg)
+			return false, nil
+		}
 	}
+	setPrecacheSpecValidCondition(clusterGroupUpgrade, metav1.ConditionTrue, "PrecacheSpecIsWellFormed",
+		"Pre-caching spec is valid and consistent")
+
+	precaching := clusterGroupUpgrade.Status.Precaching
+	precaching.Spec, precaching.Specs, precaching.ClusterSpecs = nil, nil, nil
+	switch len(specs) {
+	case 0:
+	case 1:
+		precaching.Spec = specs[sortedSpecNames[0]]
+	default:
+		precaching.Specs = specs
+		precaching.ClusterSpecs = specNames
+	}
+	return true, nil
+}
+
+// setPrecacheSpecValidCondition sets the PrecacheSpecValid condition
+func setPrecacheSpecValidCondition(clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade,
+	status metav1.ConditionStatus, reason, message string) {
+
+	meta.SetStatusCondition(&clusterGroupUpgrade.Status.Conditions, metav1.Condition{
+		Type:    utils.PrecacheSpecValidCondition,
+		Status:  status,
+		Reason:  reason,
+		Message: message})
+}
+
+/* getClusterPrecachingPolicies returns the policies the software spec of each cluster is extracted from: the child
+   policies of the managed policies propagated to the cluster, in the order of the managed policies. A cluster none
+   of the managed policies is propagated to yet gets the managed policies themselves.
+
+   returns: map[string][]*unstructured.Unstructured the policies of each cluster
+            error
+*/
+func (r *ClusterGroupUpgradeReconciler) getClusterPrecachingPolicies(ctx context.Context,
+	policies []*unstructured.Unstructured, clusters []string) (map[string][]*unstructured.Unstructured, error) {
 
-	defer res.Body.Close()
-	body, err := ioutil.ReadAll(res.Body)
+	var policyNames []string
+	for _, policy := range policies {
+		policyNames = append(policyNames, policy.GetName())
+	}
+	childPolicies, err := utils.GetChildPoliciesOfPolicies(ctx, r.Client, policyNames, clusters)
 	if err != nil {
-		return "", fmt.Errorf("unable to read body from response: %w", err)
+		return nil, err
+	}
+	// The child policies by cluster and name, <root policy namespace>.<root policy name>
+	children := make(map[string]map[string]*unstructured.Unstructured)
+	for i := range childPolicies {
+		object, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&childPolicies[i])
+		if err != nil {
+			return nil, err
+		}
+		cluster := childPolicies[i].GetNamespace()
+		if children[cluster] == nil {
+			children[cluster] = make(map[string]*unstructured.Unstructured)
+		}
+		children[cluster][childPolicies[i].GetName()] = &unstructured.Unstructured{Object: object}
 	}
 
-	var graph map[string]interface{}
-	err = json.Unmarshal(body, &graph)
+	clusterPolicies := make(map[string][]*unstructured.Unstructured)
+	for _, cluster := range clusters {
+		for _, policy := range policies {
+			if child, ok := children[cluster][policy.GetNamespace()+"."+policy.GetName()]; ok {
+				clusterPolicies[cluster] = append(clusterPolicies[cluster], child)
+			}
+		}
+		if len(clusterPolicies[cluster]) == 0 {
+			clusterPolicies[cluster] = policies
+		}
+	}
+	return clusterPolicies, nil
+}
+
+/* getSubscriptionInstalledCSV returns the CSV installed on a cluster for a Subscription found in a policy. It is read
+   through the ManagedClusterView of the Subscription, created if missing, the one the InstallPlans of the
+   Subscription are approved through during the upgrade.
+
+   returns: string the installed CSV, empty if the Subscription is not on the cluster or has no CSV installed yet
+            bool the view has been processed
+            error
+*/
+func (r *ClusterGroupUpgradeReconciler) getSubscriptionInstalledCSV(ctx context.Context,
+	clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade, cluster string,
+	subscription map[string]interface{}) (string, bool, error) {
+
+	name, _, _ := unstructured.NestedString(subscription, "metadata", "name")
+	namespace, _, _ := unstructured.NestedString(subscription, "metadata", "namespace")
+	if name == "" || namespace == "" {
+		return "", true, nil
+	}
+	viewName := utils.GetMultiCloudObjectName(clusterGroupUpgrade, utils.PolicyTypeSubscription, name)
+	safeName := utils.GetSafeResourceName(viewName, clusterGroupUpgrade, utils.MaxObjectNameLength, 0)
+	view, err := utils.EnsureManagedClusterView(
+		ctx, r.Client, safeName, viewName, cluster, "subscriptions.operators.coreos.com", name, namespace,
+		types.NamespacedName{Name: clusterGroupUpgrade.Name, Namespace: clusterGroupUpgrade.Namespace})
 	if err != nil {
-		return "", fmt.Errorf("unable to unmarshal body: %w", err)
+		return "", false, err
 	}
 
-	nodes := graph["nodes"].([]interface{})
-	for _, n := range nodes {
-		node := n.(map[string]interface{})
-		if node["version"] == version {
-			return node["payload"].(string), nil
-		}
+	condition := meta.FindStatusCondition(view.Status.Conditions, viewv1beta1.ConditionViewProcessing)
+	if condition == nil {
+		return "", false, nil
+	}
+	if condition.Status != metav1.ConditionTrue || condition.Reason != viewv1beta1.ReasonGetResource {
+		// The Subscription is not on the cluster, the operator is not installed yet
+		return "", true, nil
+	}
+	installed := operatorsv1alpha1.Subscription{}
+	if err := json.Unmarshal(view.Status.Result.Raw, &installed); err != nil {
+		r.Log.Info("[getSubscriptionInstalledCSV] Unable to decode the Subscription", "cluster", cluster,
+			"subscription", name, "error", err.Error())
+		return "", true, nil
+	}
+	return installed.Status.InstalledCSV, true, nil
+}
+
+// getPrecachingSpecName returns the name of a software spec in precaching.specs, derived from its content
+func getPrecachingSpecName(spec ranv1alpha1.PrecachingSpec) (string, error) {
+	content, err := json.Marshal(spec)
+	if err != nil {
+		return "", err
 	}
+	return fmt.Sprintf("spec-%x", sha256.Sum256(content))[:len("spec-")+10], nil
+}
 
-	return "", fmt.Errorf("unable to find version %s on update graph on url %s", version, updateGraphURL)
+// getClusterPrecachingSpec returns the software spec a cluster pre-caches
+func getClusterPrecachingSpec(clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade, cluster string) *ranv1alpha1.PrecachingSpec {
+	precaching := clusterGroupUpgrade.Status.Precaching
+	if name, ok := precaching.ClusterSpecs[cluster]; ok && precaching.Specs[name] != nil {
+		return precaching.Specs[name]
+	}
+	if precaching.Spec == nil {
+		return &ranv1alpha1.PrecachingSpec{}
+	}
+	return precaching.Spec
 }
 
 // extractPrecachingSpecFromPolicies extracts the software spec to be pre-cached
@@ -105,13 +317,16 @@ func (r *ClusterGroupUpgradeReconciler) getImageForVersionFromUpdateGraph(
 //      - ClusterVersion: release image must be specified to be pre-cached
 //      - Subscription: provides the list of operator packages and channels
 //      - CatalogSource: must be explicitly configured to be precached.
-//        All the clusters in the CGU must have same catalog source(s)
+// The policies are the ones of a single cluster, a conflict between them is a *precachingSpecError
+// The CSVs installed on the cluster for the Subscriptions are read through their ManagedClusterViews, the views of
+// all the Subscriptions are created before an *installedCSVPendingError is returned for the ones not read yet
 // returns: precachingSpec, error
-func (r *ClusterGroupUpgradeReconciler) extractPrecachingSpecFromPolicies(
-	clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade,
+func (r *ClusterGroupUpgradeReconciler) extractPrecachingSpecFromPolicies(ctx context.Context,
+	clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade, cluster string,
 	policies []*unstructured.Unstructured) (ranv1alpha1.PrecachingSpec, error) {
 
 	var spec ranv1alpha1.PrecachingSpec
+	var pendingPackages []string
 	for _, policy := range policies {
 		objects, err := r.stripPolicy(policy.Object)
 		if err != nil {
@@ -121,44 +336,40 @@ func (r *ClusterGroupUpgradeReconciler) extractPrecachingSpecFromPolicies(
 			kind := object["kind"]
 			switch kind {
 			case utils.PolicyTypeClusterVersion:
-				cvSpec := object["spec"].(map[string]interface{})
-				desiredUpdate, found := cvSpec["desiredUpdate"]
+				desiredUpdate, found, err := unstructured.NestedMap(object, "spec", "desiredUpdate")
+				if err != nil {
+					return *new(ranv1alpha1.PrecachingSpec), &precachingSpecError{"PlatformImageInvalid", err.Error()}
+				}
 				if !found {
 					continue
 				}
-				image, found := desiredUpdate.(map[string]interface{})["image"]
-				if found && image != "" {
+				image, _ := desiredUpdate["image"].(string)
+				if image != "" {
 					if len(spec.PlatformImage) > 0 && spec.PlatformImage != image {
 						msg := fmt.Sprintf("Platform image must be set once, but %s and %s were given",
 							spec.PlatformImage, image)
-						meta.SetStatusCondition(&clusterGroupUpgrade.Status.Conditions, metav1.Condition{
-							Type:    utils.PrecacheSpecValidCondition,
-							Status:  metav1.ConditionFalse,
-							Reason:  "PlatformImageConflict",
-							Message: msg})
-						return *new(ranv1alpha1.PrecachingSpec), nil
+						return *new(ranv1alpha1.PrecachingSpec), &precachingSpecError{"PlatformImageConflict", msg}
 					}
-					spec.PlatformImage = fmt.Sprintf("%s", image)
+					spec.PlatformImage = image
 				} else {
-					upstream := object["spec"].(map[string]interface {
-					})["upstream"].(string)
-					channel := object["spec"].(map[string]interface {
-					})["channel"].(string)
-					version := object["spec"].(map[string]interface {
-					})["desiredUpdate"].(map[string]interface{})["version"].(string)
-
-					image, err = r.getImageForVersionFromUpdateGraph(upstream, channel, version)
-
-					if err != nil {
-						meta.SetStatusCondition(&clusterGroupUpgrade.Status.Conditions, metav1.Condition{
-							Type:    utils.PrecacheSpecValidCondition,
-							Status:  metav1.ConditionFalse,
-							Reason:  "PlatformImageInvalid",
-							Message: err.Error()})
-						return *new(ranv1alpha1.PrecachingSpec), nil
+					upstream, _, _ := unstructured.NestedString(object, "spec", "upstream")
+					channel, _, _ := unstructured.NestedString(object, "spec", "channel")
+					version, _ := desiredUpdate["version"].(string)
+					if channel == "" || version == "" {
+						return *new(ranv1alpha1.PrecachingSpec), &precachingSpecError{"PlatformImageInvalid",
+							"ClusterVersion desiredUpdate must set an image, or a version along with a channel"}
+					}
+
+					image, err = r.getImageForVersionFromUpdateGraph(ctx, upstream, channel, version)
+					switch err.(type) {
+					case nil:
+					case *malformedUpdateGraphError, *versionNotFoundError:
+						return *new(ranv1alpha1.PrecachingSpec), &precachingSpecError{"PlatformImageInvalid", err.Error()}
+					default:
+						return *new(ranv1alpha1.PrecachingSpec), &precachingSpecError{"UpdateGraphUnavailable", err.Error()}
 					}
 
-					spec.PlatformImage = image.(string)
+					spec.PlatformImage = image
 				}
 				r.Log.Info("[extractPrecachingSpecFromPolicies]", "ClusterVersion image", spec.PlatformImage)
 			case utils.PolicyTypeSubscription:
@@ -166,6 +377,19 @@ func (r *ClusterGroupUpgradeReconciler) extractPrecachingSpecFromPolicies(
 					object["spec"].(map[string]interface{})["channel"])
 				spec.OperatorsPackagesAndChannels = append(spec.OperatorsPackagesAndChannels, packChan)
 				r.Log.Info("[extractPrecachingSpecFromPolicies]", "Operator package:channel", packChan)
+				installedCSV, processed, err := r.getSubscriptionInstalledCSV(ctx, clusterGroupUpgrade, cluster, object)
+				if err != nil {
+					return *new(ranv1alpha1.PrecachingSpec), err
+				}
+				packageName, _, _ := unstructured.NestedString(object, "spec", "name")
+				if !processed {
+					pendingPackages = append(pendingPackages, packageName)
+					continue
+				}
+				if installedCSV != "" {
+					spec.OperatorsInstalledCSVs = append(spec.OperatorsInstalledCSVs,
+						fmt.Sprintf("%s:%s", packageName, installedCSV))
+				}
 				continue
 			case utils.PolicyTypeCatalogSource:
 				index := fmt.Sprintf("%s", object["spec"].(map[string]interface{})["image"])
@@ -177,6 +401,9 @@ func (r *ClusterGroupUpgradeReconciler) extractPrecachingSpecFromPolicies(
 			}
 		}
 	}
+	if len(pendingPackages) != 0 {
+		return *new(ranv1alpha1.PrecachingSpec), &installedCSVPendingError{pendingPackages}
+	}
 	return spec, nil
 }
 
@@ -222,13 +449,22 @@ func (r *ClusterGroupUpgradeReconciler) deployDependencies(
 	clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade,
 	cluster string) (bool, error) {
 
-	spec := r.getPrecacheSpecTemplateData(clusterGroupUpgrade)
-	spec.Cluster = cluster
+	spec := r.getPrecacheSpecTemplateData(clusterGroupUpgrade, cluster)
 	msg := fmt.Sprintf("%v", spec)
 	r.Log.Info("[deployDependencies]", "getPrecacheSpecTemplateData",
 		cluster, "status", "success", "content", msg)
 
-	err := r.createResourcesFromTemplates(ctx, spec, precacheDependenciesCreateTemplates)
+	settings, err := r.getOperatorSettings(ctx, clusterGroupUpgrade.Namespace)
+	if err != nil {
+		return false, err
+	}
+	spec.setJobSettings(settings.PrecacheJob, defaultPrecacheClusterRoleRules)
+	err = r.createResourcesFromTemplates(ctx, spec, withClusterRoleTemplate(
+		spec, precacheClusterRoleCreateTemplate, precacheDependenciesCreateTemplates))
+	if err != nil {
+		return false, err
+	}
+	err = r.createPrecachingSpecConfigMap(ctx, clusterGroupUpgrade, spec)
 	if err != nil {
 		return false, err
 	}
@@ -265,23 +501,148 @@ func (r *ClusterGroupUpgradeReconciler) getPrecacheimagePullSpec(
 	return image, nil
 }
 
-// getPrecacheSpecTemplateData: Converts precaching payload spec to template data
+/* createPrecachingSpecConfigMap creates the action creating the pre-cache-spec ConfigMap on a cluster. The action
+   is rendered once per software spec during a reconcile, the clusters sharing a spec get a copy of it.
+   returns: error
+*/
+func (r *ClusterGroupUpgradeReconciler) createPrecachingSpecConfigMap(ctx context.Context,
+	clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade, data *templateData) error {
+
+	key := cguCacheKey(clusterGroupUpgrade) + "/" + clusterGroupUpgrade.Status.Precaching.ClusterSpecs[data.Cluster]
+
+	cache := getReconcileCache(ctx)
+	var obj *unstructured.Unstructured
+	if cache != nil {
+		obj = cache.specConfigMaps[key]
+	}
+	if obj == nil {
+		var err error
+		obj, err = r.renderResourceFromTemplate(data, precacheSpecConfigMapTemplate)
+		if err != nil {
+			return err
+		}
+		if cache != nil {
+			cache.specConfigMaps[key] = obj
+		}
+	}
+	obj = obj.DeepCopy()
+	obj.SetNamespace(data.Cluster)
+	err := r.Create(ctx, obj)
+	if errors.IsAlreadyExists(err) {
+		r.Log.Info("[createPrecachingSpecConfigMap] Already exists", "cluster", data.Cluster)
+		return nil
+	}
+	return err
+}
+
+// getPrecacheSpecTemplateData: Converts the precaching payload spec of a cluster to template data
 // returns: precacheTemplateData (softwareSpec)
 //          error
 func (r *ClusterGroupUpgradeReconciler) getPrecacheSpecTemplateData(
-	clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade) *templateData {
+	clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade, cluster string) *templateData {
 
 	rv := new(templateData)
-	spec := clusterGroupUpgrade.Status.Precaching.Spec
+	rv.Owner = getOwnerRef(clusterGroupUpgrade)
+	rv.Cluster = cluster
+	spec := getClusterPrecachingSpec(clusterGroupUpgrade, cluster)
 	rv.PlatformImage = spec.PlatformImage
 	rv.Operators.Indexes = spec.OperatorsIndexes
 	rv.Operators.PackagesAndChannels = spec.OperatorsPackagesAndChannels
+	rv.Operators.InstalledCSVs = spec.OperatorsInstalledCSVs
+	rv.AdditionalImages = spec.AdditionalImages
+	rv.ExcludePatterns = spec.ExcludePrecachePatterns
+	if spec.SpaceRequired != "" {
+		// The spec consistency check has already parsed the quantity
+		spaceRequired, _ := resource.ParseQuantity(spec.SpaceRequired)
+		rv.SpaceRequired = spaceRequired.Value()
+	}
 	return rv
 }
 
+// isStandalonePrecaching returns true if the CGU only pre-caches the software spec of its pre-caching config
+func isStandalonePrecaching(clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade) bool {
+	return clusterGroupUpgrade.Spec.PreCaching && clusterGroupUpgrade.Spec.PreCachingConfig != nil &&
+		clusterGroupUpgrade.Spec.PreCachingConfig.Standalone
+}
+
+/* includePreCachingConfig includes the software spec of the CGU pre-caching config in the pre-caching spec.
+   The platform image, operator indexes and packages set in the config take precedence over the ones found in
+   the policies and in the overrides ConfigMap, the other fields are only set in the config.
+   returns: ranv1alpha1.PrecachingSpec
+*/
+func includePreCachingConfig(
+	clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade, spec ranv1alpha1.PrecachingSpec) ranv1alpha1.PrecachingSpec {
+
+	config := clusterGroupUpgrade.Spec.PreCachingConfig
+	if config == nil {
+		return spec
+	}
+	if config.PlatformImage != "" {
+		spec.PlatformImage = config.PlatformImage
+	}
+	if len(config.OperatorsIndexes) != 0 {
+		spec.OperatorsIndexes = config.OperatorsIndexes
+	}
+	if len(config.OperatorsPackagesAndChannels) != 0 {
+		spec.OperatorsPackagesAndChannels = config.OperatorsPackagesAndChannels
+	}
+	spec.AdditionalImages = config.AdditionalImages
+	spec.ExcludePrecachePatterns = config.ExcludePrecachePatterns
+	spec.SpaceRequired = config.SpaceRequired
+	return spec
+}
+
+// getPrecachedClusters returns the clusters already pre-cached by the CGU referenced in precachedBy, with the same
+// software spec as the one of this CGU. A missing CGU is only logged, its clusters are pre-cached again.
+// returns: map[string]bool, error
+func (r *ClusterGroupUpgradeReconciler) getPrecachedClusters(ctx context.Context,
+	clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade) (map[string]bool, error) {
+
+	config := clusterGroupUpgrade.Spec.PreCachingConfig
+	if config == nil || config.PrecachedBy == "" || config.PrecachedBy == clusterGroupUpgrade.Name {
+		return nil, nil
+	}
+	precachingCgu := &ranv1alpha1.ClusterGroupUpgrade{}
+	err := r.getClusterGroupUpgrade(ctx, types.NamespacedName{
+		Namespace: clusterGroupUpgrade.Namespace, Name: config.PrecachedBy}, precachingCgu)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			r.Log.Info("[getPrecachedClusters] The precachedBy CGU is not found", "precachedBy", config.PrecachedBy)
+			return nil, nil
+		}
+		return nil, err
+	}
+	if precachingCgu.Status.Precaching == nil {
+		return nil, nil
+	}
+	precachedClusters := make(map[string]bool)
+	for cluster, state := range precachingCgu.Status.Precaching.Status {
+		if state != PrecacheStateSucceeded {
+			continue
+		}
+		// The cluster is only pre-cached if the standalone CGU pre-cached the software spec it needs
+		precachedSpec, err := getPrecachingSpecName(*getClusterPrecachingSpec(precachingCgu, cluster))
+		if err != nil {
+			return nil, err
+		}
+		spec, err := getPrecachingSpecName(*getClusterPrecachingSpec(clusterGroupUpgrade, cluster))
+		if err != nil {
+			return nil, err
+		}
+		if precachedSpec != spec {
+			r.Log.Info("[getPrecachedClusters] The cluster was pre-cached with another software spec",
+				"cluster", cluster, "precachedBy", config.PrecachedBy)
+			continue
+		}
+		precachedClusters[cluster] = true
+	}
+	return precachedClusters, nil
+}
+
 // includeSoftwareSpecOverrides includes software spec overrides if present
 // Overrides can be used to force a specific pre-cache workload or payload
 //		irrespective of the configured policies or the operator csv. This can be done
+//		in the ClusterGroupUpgradeOperatorConfig object or, for backward compatibility,
 //		by creating a Configmap object named "cluster-group-upgrade-overrides"
 //		in the CGU namespace with zero or more of the following "data" entries:
 //		1. "precache.image" - pre-caching workload image pull spec. Normally derived
@@ -322,6 +683,7 @@ func (r *ClusterGroupUpgradeReconciler) includeSoftwareSpecOverrides(
 		operatorsPackagesAndChannels = spec.OperatorsPackagesAndChannels
 	}
 	rv.OperatorsPackagesAndChannels = operatorsPackagesAndChannels
+	rv.OperatorsInstalledCSVs = spec.OperatorsInstalledCSVs
 
 	if err != nil {
 		return *rv, err
@@ -344,8 +706,18 @@ func (r *ClusterGroupUpgradeReconciler) checkPreCacheSpecConsistency(
 	if operatorsRequested && len(spec.OperatorsPackagesAndChannels) == 0 {
 		return false, "inconsistent precaching configuration: olm index provided, but no packages"
 	}
-	if !operatorsRequested && !platformRequested {
+	if !operatorsRequested && !platformRequested && len(spec.AdditionalImages) == 0 {
 		return false, "inconsistent precaching configuration: no software spec provided"
 	}
+	for _, pattern := range spec.ExcludePrecachePatterns {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return false, fmt.Sprintf("inconsistent precaching configuration: invalid exclude pattern %q", pattern)
+		}
+	}
+	if spec.SpaceRequired != "" {
+		if _, err := resource.ParseQuantity(spec.SpaceRequired); err != nil {
+			return false, fmt.Sprintf("inconsistent precaching configuration: invalid space required %q", spec.SpaceRequired)
+		}
+	}
 	return true, ""
 }
@@ -19,6 +19,9 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"math"
+	"sort"
+	"time"
 
 	ranv1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/api/v1alpha1"
 	"github.com/openshift-kni/cluster-group-upgrades-operator/controllers/utils"
@@ -29,14 +32,20 @@ import (
 // Pre-cache states
 const (
 	PrecacheStateNotStarted       = "NotStarted"
+	PrecacheStateQueued           = "Queued"
 	PrecacheStatePreparingToStart = "PreparingToStart"
 	PrecacheStateStarting         = "Starting"
 	PrecacheStateActive           = "Active"
 	PrecacheStateSucceeded        = "Succeeded"
 	PrecacheStateTimeout          = "PrecacheTimeout"
 	PrecacheStateError            = "UnrecoverableError"
+	// PrecacheStateInsufficientSpace is reached when the job finds the cluster short of disk space before pulling
+	PrecacheStateInsufficientSpace = "PrecacheInsufficientSpace"
 )
 
+// defaultPrecachingRetryBackoff is the time waited before retrying a failed cluster the first time
+const defaultPrecachingRetryBackoff = 5 * time.Minute
+
 // Jobresources conditions
 const (
 	NoNsView                   = "NoNsView"
@@ -60,50 +69,6 @@ func (r *ClusterGroupUpgradeReconciler) precachingFsm(ctx context.Context,
 	clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade) error {
 
 	r.setPrecachingRequired(clusterGroupUpgrade)
-	specCondition := meta.FindStatusCondition(clusterGroupUpgrade.Status.Conditions, utils.PrecacheSpecValidCondition)
-	if specCondition == nil || specCondition.Status == metav1.ConditionFalse {
-		allManagedPoliciesExist, managedPoliciesMissing, managedPoliciesPresent, err := r.doManagedPoliciesExist(
-			ctx, clusterGroupUpgrade, false)
-		if err != nil {
-			return err
-		}
-		if !allManagedPoliciesExist {
-			statusMessage := fmt.Sprintf(
-				"The ClusterGroupUpgrade CR has managed policies that are missing: %s", managedPoliciesMissing)
-			meta.SetStatusCondition(&clusterGroupUpgrade.Status.Conditions, metav1.Condition{
-				Type:    utils.PrecacheSpecValidCondition,
-				Status:  metav1.ConditionFalse,
-				Reason:  "NotAllManagedPoliciesExist",
-				Message: statusMessage})
-			return nil
-		}
-
-		spec, err := r.extractPrecachingSpecFromPolicies(clusterGroupUpgrade, managedPoliciesPresent)
-		if err != nil {
-			return err
-		}
-		r.Log.Info("[precachingFsm]", "PrecacheSpecFromPolicies", spec)
-		spec, err = r.includeSoftwareSpecOverrides(ctx, clusterGroupUpgrade, &spec)
-		if err != nil {
-			return err
-		}
-		ok, msg := r.checkPreCacheSpecConsistency(spec)
-		if !ok {
-			meta.SetStatusCondition(&clusterGroupUpgrade.Status.Conditions, metav1.Condition{
-				Type:    utils.PrecacheSpecValidCondition,
-				Status:  metav1.ConditionFalse,
-				Reason:  "PrecacheSpecIsIncomplete",
-				Message: msg})
-			return nil
-		}
-		meta.SetStatusCondition(&clusterGroupUpgrade.Status.Conditions, metav1.Condition{
-			Type:    utils.PrecacheSpecValidCondition,
-			Status:  metav1.ConditionTrue,
-			Reason:  "PrecacheSpecIsWellFormed",
-			Message: "Pre-caching spec is valid and consistent"})
-
-		clusterGroupUpgrade.Status.Precaching.Spec = &spec
-	}
 
 	var (
 		clusters []string
@@ -117,10 +82,38 @@ func (r *ClusterGroupUpgradeReconciler) precachingFsm(ctx context.Context,
 		if err != nil {
 			return fmt.Errorf("cannot obtain the CGU cluster list: %s", err)
 		}
-		clusterGroupUpgrade.Status.Precaching.Clusters = clusters
 	}
 
+	specCondition := meta.FindStatusCondition(clusterGroupUpgrade.Status.Conditions, utils.PrecacheSpecValidCondition)
+	if specCondition == nil || specCondition.Status != metav1.ConditionTrue {
+		valid, err := r.updatePrecachingSpecs(ctx, clusterGroupUpgrade, clusters)
+		if err != nil || !valid {
+			return err
+		}
+	}
+	clusterGroupUpgrade.Status.Precaching.Clusters = clusters
+
+	// Pre-caching jobs only start while there are fleet-wide pre-caching slots available.
+	availableSlots, err := r.getAvailableCapacity(ctx, clusterGroupUpgrade, capacityPrecaching)
+	if err != nil {
+		return err
+	}
+	waitingForCapacity := false
+
+	// A new value of the retry annotation retries all the failed clusters, whatever their attempts
+	retryTrigger := clusterGroupUpgrade.GetAnnotations()[utils.PrecacheRetryAnnotation]
+	retryRequested := retryTrigger != "" && retryTrigger != clusterGroupUpgrade.Status.Precaching.RetryTrigger
+
+	// The clusters pre-cached by the referenced standalone CGU don't need a job
+	precachedClusters, err := r.getPrecachedClusters(ctx, clusterGroupUpgrade)
+	if err != nil {
+		return err
+	}
+
+	// The clusters waiting for a slot are handled last, so the slots freed by the jobs ending on this pass
+	// are handed out right away
 	clusterStates := make(map[string]string)
+	var waitingClusters []string
 	for _, cluster := range clusters {
 		var currentState string
 		if len(clusterGroupUpgrade.Status.Precaching.Status) == 0 {
@@ -134,12 +127,10 @@ func (r *ClusterGroupUpgradeReconciler) precachingFsm(ctx context.Context,
 		)
 		r.Log.Info("[precachingFsm]", "currentState", currentState, "cluster", cluster)
 		switch currentState {
-		// Initial State
-		case PrecacheStateNotStarted:
-			nextState, err = r.handleNotStarted(ctx, cluster)
-			if err != nil {
-				return err
-			}
+		// Initial states, the clusters wait for a slot
+		case PrecacheStateNotStarted, PrecacheStateQueued:
+			waitingClusters = append(waitingClusters, cluster)
+			continue
 		case PrecacheStatePreparingToStart:
 			nextState, err = r.handlePreparing(ctx, cluster)
 			if err != nil {
@@ -150,11 +141,21 @@ func (r *ClusterGroupUpgradeReconciler) precachingFsm(ctx context.Context,
 			if err != nil {
 				return err
 			}
-		// Final states that don't change for the life of the CR
-		case PrecacheStateSucceeded, PrecacheStateTimeout, PrecacheStateError:
+		// Final state that doesn't change for the life of the CR
+		case PrecacheStateSucceeded:
 			nextState = currentState
 			r.Log.Info("[precachingFsm]", "cluster", cluster, "final state", currentState)
 
+		// Failed states, final unless the cluster is retried
+		case PrecacheStateTimeout, PrecacheStateError, PrecacheStateInsufficientSpace:
+			if retryRequested || isPrecachingRetryDue(clusterGroupUpgrade, cluster) {
+				r.Log.Info("[precachingFsm] Retrying", "cluster", cluster, "state", currentState)
+				waitingClusters = append(waitingClusters, cluster)
+				continue
+			}
+			nextState = currentState
+			r.Log.Info("[precachingFsm]", "cluster", cluster, "failed state", currentState)
+
 		case PrecacheStateActive:
 			nextState, err = r.handleActive(ctx, cluster)
 			if err != nil {
@@ -166,18 +167,204 @@ func (r *ClusterGroupUpgradeReconciler) precachingFsm(ctx context.Context,
 
 		}
 
+		nextState, err = r.updatePrecachingSummary(ctx, clusterGroupUpgrade, cluster, nextState)
+		if err != nil {
+			return err
+		}
+		if isPrecachingFailed(nextState) && !isPrecachingFailed(currentState) {
+			recordPrecachingFailure(clusterGroupUpgrade, cluster)
+		}
 		clusterStates[cluster] = nextState
 		r.Log.Info("[precachingFsm]", "previousState", currentState, "nextState", nextState, "cluster", cluster)
 
 	}
+
+	cguSlots := getPrecachingSlots(clusterGroupUpgrade, clusterStates)
+	for _, cluster := range waitingClusters {
+		if precachedClusters[cluster] {
+			r.Log.Info("[precachingFsm] Pre-cached by another CGU", "cluster", cluster,
+				"precachedBy", clusterGroupUpgrade.Spec.PreCachingConfig.PrecachedBy)
+			clusterStates[cluster] = PrecacheStateSucceeded
+			continue
+		}
+		if cguSlots <= 0 || availableSlots <= 0 {
+			clusterStates[cluster] = PrecacheStateQueued
+			// The clusters queued behind the limit of the CGU itself are not waiting for fleet-wide capacity
+			if cguSlots > 0 {
+				waitingForCapacity = true
+			}
+			continue
+		}
+		cguSlots--
+		availableSlots--
+		nextState, err := r.handleNotStarted(ctx, clusterGroupUpgrade, cluster)
+		if err != nil {
+			return err
+		}
+		recordPrecachingAttempt(clusterGroupUpgrade, cluster)
+		// The summary of a previous attempt doesn't apply to the new job
+		delete(clusterGroupUpgrade.Status.Precaching.Summaries, cluster)
+		clusterStates[cluster] = nextState
+		r.Log.Info("[precachingFsm]", "nextState", nextState, "cluster", cluster)
+	}
 	clusterGroupUpgrade.Status.Precaching.Status = clusterStates
+	clusterGroupUpgrade.Status.Precaching.Counts = countPrecachingStates(clusterStates)
+	if retryRequested {
+		clusterGroupUpgrade.Status.Precaching.RetryTrigger = retryTrigger
+	}
+	r.setCapacityCondition(clusterGroupUpgrade, capacityPrecaching, waitingForCapacity)
 	r.checkAllPrecachingDone(clusterGroupUpgrade)
+	if isStandalonePrecaching(clusterGroupUpgrade) {
+		checkStandalonePrecachingDone(clusterGroupUpgrade, retryRequested)
+	}
 	return nil
 }
 
+// getPrecachingMaxConcurrency returns the maximum number of clusters of the CGU pre-caching at the same time,
+// 0 if not limited
+func getPrecachingMaxConcurrency(clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade) int {
+	if clusterGroupUpgrade.Spec.PreCachingConfig == nil {
+		return 0
+	}
+	return clusterGroupUpgrade.Spec.PreCachingConfig.MaxConcurrency
+}
+
+// getPrecachingSlots returns how many more clusters of the CGU may start pre-caching given the states of its
+// clusters, math.MaxInt32 if the CGU sets no limit
+func getPrecachingSlots(clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade, clusterStates map[string]string) int {
+	maxConcurrency := getPrecachingMaxConcurrency(clusterGroupUpgrade)
+	if maxConcurrency <= 0 {
+		return math.MaxInt32
+	}
+	counts := countPrecachingStates(clusterStates)
+	return maxConcurrency - counts[PrecacheStatePreparingToStart] - counts[PrecacheStateStarting] -
+		counts[PrecacheStateActive]
+}
+
+// countPrecachingStates returns the number of clusters per pre-caching state
+func countPrecachingStates(clusterStates map[string]string) map[string]int {
+	counts := make(map[string]int)
+	for _, state := range clusterStates {
+		counts[state]++
+	}
+	return counts
+}
+
+// isPrecachingFailed returns true if the pre-caching state is a failed one
+func isPrecachingFailed(state string) bool {
+	return state == PrecacheStateTimeout || state == PrecacheStateError || state == PrecacheStateInsufficientSpace
+}
+
+// getPrecachingAttempts returns the record of the pre-caching attempts on a cluster, created if needed
+func getPrecachingAttempts(
+	clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade, cluster string) *ranv1alpha1.PrecachingAttempts {
+
+	if clusterGroupUpgrade.Status.Precaching.Attempts == nil {
+		clusterGroupUpgrade.Status.Precaching.Attempts = make(map[string]*ranv1alpha1.PrecachingAttempts)
+	}
+	if clusterGroupUpgrade.Status.Precaching.Attempts[cluster] == nil {
+		clusterGroupUpgrade.Status.Precaching.Attempts[cluster] = &ranv1alpha1.PrecachingAttempts{}
+	}
+	return clusterGroupUpgrade.Status.Precaching.Attempts[cluster]
+}
+
+// recordPrecachingAttempt counts a pre-caching attempt started on a cluster
+func recordPrecachingAttempt(clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade, cluster string) {
+	getPrecachingAttempts(clusterGroupUpgrade, cluster).Count++
+}
+
+// recordPrecachingFailure records the time the pre-caching attempt of a cluster failed
+func recordPrecachingFailure(clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade, cluster string) {
+	now := metav1.Now()
+	getPrecachingAttempts(clusterGroupUpgrade, cluster).LastFailureTime = &now
+}
+
+/* getPrecachingRetryTime computes when a failed cluster is retried automatically: after the backoff of the
+   retry policy, doubled for each attempt after the first one, counted from the last failure.
+
+   returns: *time.Time the retry time, nil if the cluster used all its attempts or no retry policy is set
+*/
+func getPrecachingRetryTime(clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade, cluster string) *time.Time {
+	config := clusterGroupUpgrade.Spec.PreCachingConfig
+	if config == nil || config.Retry == nil {
+		return nil
+	}
+	attempts := clusterGroupUpgrade.Status.Precaching.Attempts[cluster]
+	if attempts == nil || attempts.Count >= config.Retry.Attempts || attempts.LastFailureTime == nil {
+		return nil
+	}
+	backoff := defaultPrecachingRetryBackoff
+	if config.Retry.Backoff != nil {
+		backoff = config.Retry.Backoff.Duration
+	}
+	for i := 1; i < attempts.Count; i++ {
+		backoff *= 2
+	}
+	retryTime := attempts.LastFailureTime.Add(backoff)
+	return &retryTime
+}
+
+// isPrecachingRetryDue returns true if a failed cluster is to be retried automatically now
+func isPrecachingRetryDue(clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade, cluster string) bool {
+	retryTime := getPrecachingRetryTime(clusterGroupUpgrade, cluster)
+	return retryTime != nil && !time.Now().Before(*retryTime)
+}
+
+// getNextPrecachingRetry returns the earliest time a failed cluster of the CGU is retried at, nil if none is
+func getNextPrecachingRetry(clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade) *time.Time {
+	if clusterGroupUpgrade.Status.Precaching == nil {
+		return nil
+	}
+	var next *time.Time
+	for cluster, state := range clusterGroupUpgrade.Status.Precaching.Status {
+		if !isPrecachingFailed(state) {
+			continue
+		}
+		retryTime := getPrecachingRetryTime(clusterGroupUpgrade, cluster)
+		if retryTime != nil && (next == nil || retryTime.Before(*next)) {
+			next = retryTime
+		}
+	}
+	return next
+}
+
+/* updatePrecachingSummary reads the summary the pre-caching job of a cluster publishes while it pulls the images
+   into the status. The job publishes a last summary when it ends, the summary view is deleted once the job
+   ended and that last summary was read. A failed job whose summary reports the cluster short of disk space
+   moves the cluster to PrecacheInsufficientSpace.
+
+   returns: string the next state of the cluster
+            error
+*/
+func (r *ClusterGroupUpgradeReconciler) updatePrecachingSummary(ctx context.Context,
+	clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade, cluster, state string) (string, error) {
+
+	if state != PrecacheStateActive && state != PrecacheStateSucceeded && !isPrecachingFailed(state) {
+		return state, nil
+	}
+	summary, done, err := r.getPrecachingSummary(ctx, cluster)
+	if err != nil || summary == nil {
+		return state, err
+	}
+	if clusterGroupUpgrade.Status.Precaching.Summaries == nil {
+		clusterGroupUpgrade.Status.Precaching.Summaries = make(map[string]*ranv1alpha1.PrecachingSummary)
+	}
+	clusterGroupUpgrade.Status.Precaching.Summaries[cluster] = summary
+	if isPrecachingFailed(state) && summary.SpaceRequired > summary.SpaceAvailable {
+		r.Log.Info("[precachingFsm] Insufficient disk space", "cluster", cluster,
+			"spaceAvailable", summary.SpaceAvailable, "spaceRequired", summary.SpaceRequired)
+		state = PrecacheStateInsufficientSpace
+	}
+	if done && state != PrecacheStateActive {
+		return state, r.deleteAllViews(ctx, cluster, precacheSummaryView)
+	}
+	return state, nil
+}
+
 // handleNotStarted handles conditions in PrecacheStateNotStarted
 // returns: error
 func (r *ClusterGroupUpgradeReconciler) handleNotStarted(ctx context.Context,
+	clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade,
 	cluster string) (string, error) {
 
 	currentState, nextState := PrecacheStateNotStarted, PrecacheStatePreparingToStart
@@ -190,6 +377,7 @@ func (r *ClusterGroupUpgradeReconciler) handleNotStarted(ctx context.Context,
 	}
 	data := templateData{
 		Cluster: cluster,
+		Owner:   getOwnerRef(clusterGroupUpgrade),
 	}
 	err = r.createResourcesFromTemplates(ctx, &data, precacheDeleteTemplates)
 	if err != nil {
@@ -254,6 +442,7 @@ func (r *ClusterGroupUpgradeReconciler) handleStarting(ctx context.Context,
 		data := templateData{
 			Cluster:               cluster,
 			ViewUpdateIntervalSec: utils.ViewUpdateSec * len(clusterGroupUpgrade.Status.Precaching.Clusters),
+			Owner:                 getOwnerRef(clusterGroupUpgrade),
 		}
 		err = r.createResourcesFromTemplates(ctx, &data, precacheJobView)
 		if err != nil {
@@ -362,6 +551,39 @@ func (r *ClusterGroupUpgradeReconciler) setPrecachingRequired(
 			Message: "Precaching is required and not done"})
 }
 
+/* checkStandalonePrecachingDone completes a standalone CGU once its clusters are done pre-caching: it succeeds
+   if all the clusters are pre-cached, and fails if the other clusters failed and none of them is to be retried.
+   A new value of the retry annotation retries the failed clusters of a failed standalone CGU.
+*/
+func checkStandalonePrecachingDone(clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade, retryRequested bool) {
+	if meta.IsStatusConditionTrue(clusterGroupUpgrade.Status.Conditions, "PrecachingDone") {
+		meta.SetStatusCondition(&clusterGroupUpgrade.Status.Conditions, metav1.Condition{
+			Type:    "Ready",
+			Status:  metav1.ConditionTrue,
+			Reason:  "UpgradeCompleted",
+			Message: "Precaching is completed for all clusters"})
+		return
+	}
+	if retryRequested || getNextPrecachingRetry(clusterGroupUpgrade) != nil {
+		return
+	}
+	var failedClusters []string
+	for cluster, state := range clusterGroupUpgrade.Status.Precaching.Status {
+		switch {
+		case isPrecachingFailed(state):
+			failedClusters = append(failedClusters, cluster)
+		case state != PrecacheStateSucceeded:
+			return
+		}
+	}
+	sort.Strings(failedClusters)
+	meta.SetStatusCondition(&clusterGroupUpgrade.Status.Conditions, metav1.Condition{
+		Type:    "Ready",
+		Status:  metav1.ConditionFalse,
+		Reason:  ranv1alpha1.ConditionReasons.PrecachingFailed,
+		Message: fmt.Sprintf("Precaching failed for clusters: %v", failedClusters)})
+}
+
 // checkAllPrecachingDone handles alleviation of PrecachingDone==False condition
 func (r *ClusterGroupUpgradeReconciler) checkAllPrecachingDone(
 	clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade) {
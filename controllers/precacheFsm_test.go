@@ -0,0 +1,910 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	policiesv1 "github.com/open-cluster-management/governance-policy-propagator/api/v1"
+	viewv1beta1 "github.com/open-cluster-management/multicloud-operators-foundation/pkg/apis/view/v1beta1"
+	ranv1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/api/v1alpha1"
+	"github.com/openshift-kni/cluster-group-upgrades-operator/controllers/utils"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func TestPrecachingFsm_queueing(t *testing.T) {
+	testcases := []struct {
+		name           string
+		maxConcurrency int
+		fleetLimit     int
+		states         map[string]string
+		expected       map[string]string
+		expectedCounts map[string]int
+		waiting        bool
+	}{
+		{
+			name: "no limit",
+			states: map[string]string{
+				"spoke1": PrecacheStateNotStarted, "spoke2": PrecacheStateNotStarted, "spoke3": PrecacheStateNotStarted,
+			},
+			expected: map[string]string{
+				"spoke1": PrecacheStatePreparingToStart, "spoke2": PrecacheStatePreparingToStart,
+				"spoke3": PrecacheStatePreparingToStart,
+			},
+			expectedCounts: map[string]int{PrecacheStatePreparingToStart: 3},
+		},
+		{
+			name:           "limited by the CGU",
+			maxConcurrency: 2,
+			states: map[string]string{
+				"spoke1": PrecacheStateNotStarted, "spoke2": PrecacheStateNotStarted, "spoke3": PrecacheStateNotStarted,
+			},
+			expected: map[string]string{
+				"spoke1": PrecacheStatePreparingToStart, "spoke2": PrecacheStatePreparingToStart,
+				"spoke3": PrecacheStateQueued,
+			},
+			expectedCounts: map[string]int{PrecacheStatePreparingToStart: 2, PrecacheStateQueued: 1},
+		},
+		{
+			name:           "queued clusters start as jobs end",
+			maxConcurrency: 2,
+			states: map[string]string{
+				"spoke1": PrecacheStateSucceeded, "spoke2": PrecacheStateError, "spoke3": PrecacheStateQueued,
+				"spoke4": PrecacheStateQueued, "spoke5": PrecacheStateQueued,
+			},
+			expected: map[string]string{
+				"spoke1": PrecacheStateSucceeded, "spoke2": PrecacheStateError, "spoke3": PrecacheStatePreparingToStart,
+				"spoke4": PrecacheStatePreparingToStart, "spoke5": PrecacheStateQueued,
+			},
+			expectedCounts: map[string]int{PrecacheStateSucceeded: 1, PrecacheStateError: 1,
+				PrecacheStatePreparingToStart: 2, PrecacheStateQueued: 1},
+		},
+		{
+			name:           "limited by the fleet",
+			maxConcurrency: 2,
+			fleetLimit:     1,
+			states: map[string]string{
+				"spoke1": PrecacheStateQueued, "spoke2": PrecacheStateQueued, "spoke3": PrecacheStateQueued,
+			},
+			expected: map[string]string{
+				"spoke1": PrecacheStatePreparingToStart, "spoke2": PrecacheStateQueued, "spoke3": PrecacheStateQueued,
+			},
+			expectedCounts: map[string]int{PrecacheStatePreparingToStart: 1, PrecacheStateQueued: 2},
+			waiting:        true,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			cgu := &ranv1alpha1.ClusterGroupUpgrade{
+				ObjectMeta: metav1.ObjectMeta{Name: "cgu", Namespace: "default"},
+				Spec: ranv1alpha1.ClusterGroupUpgradeSpec{
+					PreCaching:       true,
+					PreCachingConfig: &ranv1alpha1.PreCachingConfig{MaxConcurrency: tc.maxConcurrency},
+				},
+				Status: ranv1alpha1.ClusterGroupUpgradeStatus{
+					Conditions: []metav1.Condition{
+						{Type: utils.PrecacheSpecValidCondition, Status: metav1.ConditionTrue, Reason: "PrecacheSpecIsWellFormed"},
+					},
+					Precaching: &ranv1alpha1.PrecachingStatus{Status: tc.states},
+				},
+			}
+			for _, cluster := range []string{"spoke1", "spoke2", "spoke3", "spoke4", "spoke5"} {
+				if _, ok := tc.states[cluster]; ok {
+					cgu.Status.Precaching.Clusters = append(cgu.Status.Precaching.Clusters, cluster)
+				}
+			}
+
+			fakeClient, err := getFakeClientFromObjects(cgu)
+			if err != nil {
+				t.Errorf("error in creating fake client")
+			}
+			r := &ClusterGroupUpgradeReconciler{
+				Client:                  fakeClient,
+				Log:                     logr.Discard(),
+				Scheme:                  testscheme,
+				MaxConcurrentPrecaching: tc.fleetLimit,
+			}
+			assert.NoError(t, r.precachingFsm(context.TODO(), cgu))
+			assert.Equal(t, tc.expected, cgu.Status.Precaching.Status)
+			assert.Equal(t, tc.expectedCounts, cgu.Status.Precaching.Counts)
+			capacityCondition := meta.FindStatusCondition(cgu.Status.Conditions, utils.PrecachingCapacityAvailableCondition)
+			assert.Equal(t, tc.waiting, capacityCondition != nil)
+		})
+	}
+}
+
+func TestPrecachingFsm_isWaitingForCapacity(t *testing.T) {
+	cgu := &ranv1alpha1.ClusterGroupUpgrade{
+		Spec: ranv1alpha1.ClusterGroupUpgradeSpec{
+			PreCachingConfig: &ranv1alpha1.PreCachingConfig{MaxConcurrency: 1},
+		},
+		Status: ranv1alpha1.ClusterGroupUpgradeStatus{
+			Precaching: &ranv1alpha1.PrecachingStatus{
+				Status: map[string]string{"spoke1": PrecacheStateActive, "spoke2": PrecacheStateQueued},
+			},
+		},
+	}
+	// Queued behind the limit of the CGU itself
+	assert.False(t, isWaitingForCapacity(cgu, capacityPrecaching))
+
+	cgu.Spec.PreCachingConfig.MaxConcurrency = 2
+	assert.True(t, isWaitingForCapacity(cgu, capacityPrecaching))
+
+	cgu.Spec.PreCachingConfig = nil
+	assert.True(t, isWaitingForCapacity(cgu, capacityPrecaching))
+}
+
+func TestPrecachingFsm_retry(t *testing.T) {
+	now := time.Now()
+	retryPolicy := &ranv1alpha1.PreCachingRetryPolicy{Attempts: 3, Backoff: &metav1.Duration{Duration: time.Minute}}
+	testcases := []struct {
+		name             string
+		retry            *ranv1alpha1.PreCachingRetryPolicy
+		attempts         int
+		lastFailure      time.Duration
+		annotation       string
+		handledTrigger   string
+		expected         string
+		expectedAttempts int
+	}{
+		{
+			name:             "no retry policy",
+			attempts:         1,
+			lastFailure:      time.Hour,
+			expected:         PrecacheStateError,
+			expectedAttempts: 1,
+		},
+		{
+			name:             "retried after the backoff",
+			retry:            retryPolicy,
+			attempts:         1,
+			lastFailure:      2 * time.Minute,
+			expected:         PrecacheStatePreparingToStart,
+			expectedAttempts: 2,
+		},
+		{
+			name:             "backoff doubled for the next attempts",
+			retry:            retryPolicy,
+			attempts:         2,
+			lastFailure:      90 * time.Second,
+			expected:         PrecacheStateError,
+			expectedAttempts: 2,
+		},
+		{
+			name:             "no attempt left",
+			retry:            retryPolicy,
+			attempts:         3,
+			lastFailure:      time.Hour,
+			expected:         PrecacheStateError,
+			expectedAttempts: 3,
+		},
+		{
+			name:             "retried on request",
+			attempts:         3,
+			lastFailure:      time.Second,
+			annotation:       "1",
+			expected:         PrecacheStatePreparingToStart,
+			expectedAttempts: 4,
+		},
+		{
+			name:             "request already handled",
+			attempts:         1,
+			lastFailure:      time.Second,
+			annotation:       "1",
+			handledTrigger:   "1",
+			expected:         PrecacheStateError,
+			expectedAttempts: 1,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			lastFailure := metav1.NewTime(now.Add(-tc.lastFailure))
+			cgu := &ranv1alpha1.ClusterGroupUpgrade{
+				ObjectMeta: metav1.ObjectMeta{Name: "cgu", Namespace: "default"},
+				Spec: ranv1alpha1.ClusterGroupUpgradeSpec{
+					PreCaching:       true,
+					PreCachingConfig: &ranv1alpha1.PreCachingConfig{Retry: tc.retry},
+				},
+				Status: ranv1alpha1.ClusterGroupUpgradeStatus{
+					Conditions: []metav1.Condition{
+						{Type: utils.PrecacheSpecValidCondition, Status: metav1.ConditionTrue, Reason: "PrecacheSpecIsWellFormed"},
+					},
+					Precaching: &ranv1alpha1.PrecachingStatus{
+						Clusters: []string{"spoke1"},
+						Status:   map[string]string{"spoke1": PrecacheStateError},
+						Attempts: map[string]*ranv1alpha1.PrecachingAttempts{
+							"spoke1": {Count: tc.attempts, LastFailureTime: &lastFailure},
+						},
+						RetryTrigger: tc.handledTrigger,
+					},
+				},
+			}
+			if tc.annotation != "" {
+				cgu.Annotations = map[string]string{utils.PrecacheRetryAnnotation: tc.annotation}
+			}
+
+			fakeClient, err := getFakeClientFromObjects(cgu)
+			if err != nil {
+				t.Errorf("error in creating fake client")
+			}
+			r := &ClusterGroupUpgradeReconciler{Client: fakeClient, Log: logr.Discard(), Scheme: testscheme}
+			assert.NoError(t, r.precachingFsm(context.TODO(), cgu))
+			assert.Equal(t, tc.expected, cgu.Status.Precaching.Status["spoke1"])
+			assert.Equal(t, tc.expectedAttempts, cgu.Status.Precaching.Attempts["spoke1"].Count)
+			assert.Equal(t, tc.annotation, cgu.Status.Precaching.RetryTrigger)
+		})
+	}
+}
+
+func TestPrecachingFsm_getNextPrecachingRetry(t *testing.T) {
+	lastFailure := metav1.NewTime(time.Now().Add(-time.Minute))
+	cgu := &ranv1alpha1.ClusterGroupUpgrade{
+		Spec: ranv1alpha1.ClusterGroupUpgradeSpec{
+			PreCachingConfig: &ranv1alpha1.PreCachingConfig{
+				Retry: &ranv1alpha1.PreCachingRetryPolicy{Attempts: 3},
+			},
+		},
+		Status: ranv1alpha1.ClusterGroupUpgradeStatus{
+			Precaching: &ranv1alpha1.PrecachingStatus{
+				Status: map[string]string{
+					"spoke1": PrecacheStateTimeout, "spoke2": PrecacheStateError, "spoke3": PrecacheStateError,
+				},
+				Attempts: map[string]*ranv1alpha1.PrecachingAttempts{
+					"spoke1": {Count: 2, LastFailureTime: &lastFailure},
+					"spoke2": {Count: 1, LastFailureTime: &lastFailure},
+					"spoke3": {Count: 3, LastFailureTime: &lastFailure},
+				},
+			},
+		},
+	}
+	// spoke2 is retried after the default backoff, spoke1 after twice that and spoke3 has no attempt left
+	nextRetry := getNextPrecachingRetry(cgu)
+	if assert.NotNil(t, nextRetry) {
+		assert.Equal(t, lastFailure.Add(defaultPrecachingRetryBackoff), *nextRetry)
+	}
+
+	cgu.Spec.PreCachingConfig.Retry = nil
+	assert.Nil(t, getNextPrecachingRetry(cgu))
+}
+
+func TestPrecachingFsm_updatePrecachingSummary(t *testing.T) {
+	testcases := []struct {
+		name          string
+		state         string
+		summary       string
+		expected      *ranv1alpha1.PrecachingSummary
+		expectedState string
+		viewDeleted   bool
+	}{
+		{
+			name:     "progress of an active job",
+			state:    PrecacheStateActive,
+			summary:  `{"total":10,"pulled":4,"skipped":2,"bytes":4096}`,
+			expected: &ranv1alpha1.PrecachingSummary{Total: 10, Pulled: 4, Skipped: 2, Bytes: 4096},
+		},
+		{
+			name:  "last summary of a failed job",
+			state: PrecacheStateError,
+			summary: `{"total":10,"pulled":8,"failed":2,"done":true,` +
+				`"failures":[{"image":"quay.io/operator:v1","reason":"manifest unknown"}]}`,
+			expected: &ranv1alpha1.PrecachingSummary{Total: 10, Pulled: 8, Failed: 2,
+				Failures: []ranv1alpha1.PrecachingImageFailure{{Image: "quay.io/operator:v1", Reason: "manifest unknown"}}},
+			viewDeleted: true,
+		},
+		{
+			name:  "insufficient disk space",
+			state: PrecacheStateError,
+			summary: `{"total":10,"done":true,"spaceAvailable":1024,"spaceRequired":4096,` +
+				`"error":"Not enough disk space to pre-cache 10 images"}`,
+			expected: &ranv1alpha1.PrecachingSummary{Total: 10, SpaceAvailable: 1024, SpaceRequired: 4096,
+				Error: "Not enough disk space to pre-cache 10 images"},
+			expectedState: PrecacheStateInsufficientSpace,
+			viewDeleted:   true,
+		},
+		{
+			name:     "enough disk space",
+			state:    PrecacheStateActive,
+			summary:  `{"total":10,"pulled":1,"spaceAvailable":8192,"spaceRequired":4096}`,
+			expected: &ranv1alpha1.PrecachingSummary{Total: 10, Pulled: 1, SpaceAvailable: 8192, SpaceRequired: 4096},
+		},
+		{
+			name:     "last summary not published yet",
+			state:    PrecacheStateSucceeded,
+			summary:  `{"total":10,"pulled":9}`,
+			expected: &ranv1alpha1.PrecachingSummary{Total: 10, Pulled: 9},
+		},
+		{
+			name:  "summary not published yet",
+			state: PrecacheStateActive,
+		},
+		{
+			name:    "invalid summary",
+			state:   PrecacheStateActive,
+			summary: `{"total":`,
+		},
+		{
+			name:    "job not started",
+			state:   PrecacheStateStarting,
+			summary: `{"total":10}`,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			cgu := &ranv1alpha1.ClusterGroupUpgrade{
+				ObjectMeta: metav1.ObjectMeta{Name: "cgu", Namespace: "default"},
+				Status: ranv1alpha1.ClusterGroupUpgradeStatus{
+					Precaching: &ranv1alpha1.PrecachingStatus{
+						Clusters: []string{"spoke1"},
+						Status:   map[string]string{"spoke1": tc.state},
+					},
+				},
+			}
+			fakeClient, err := getFakeClientFromObjects(cgu)
+			if err != nil {
+				t.Errorf("error in creating fake client")
+			}
+			r := &ClusterGroupUpgradeReconciler{Client: fakeClient, Log: logr.Discard(), Scheme: testscheme}
+			view := &unstructured.Unstructured{}
+			view.SetAPIVersion("view.open-cluster-management.io/v1beta1")
+			view.SetKind("ManagedClusterView")
+			view.SetName(precacheSummaryView[0].resourceName)
+			view.SetNamespace("spoke1")
+			if tc.summary != "" {
+				assert.NoError(t, unstructured.SetNestedField(view.Object, tc.summary, precacheSummaryData...))
+			}
+			assert.NoError(t, fakeClient.Create(context.TODO(), view))
+
+			state, err := r.updatePrecachingSummary(context.TODO(), cgu, "spoke1", tc.state)
+			assert.NoError(t, err)
+			if tc.expectedState == "" {
+				tc.expectedState = tc.state
+			}
+			assert.Equal(t, tc.expectedState, state)
+			assert.Equal(t, tc.expected, cgu.Status.Precaching.Summaries["spoke1"])
+			_, present, err := r.getView(context.TODO(), precacheSummaryView[0].resourceName, "spoke1")
+			assert.NoError(t, err)
+			assert.Equal(t, !tc.viewDeleted, present)
+		})
+	}
+}
+
+func TestPrecachingFsm_standalone(t *testing.T) {
+	testcases := []struct {
+		name           string
+		states         map[string]string
+		retry          *ranv1alpha1.PreCachingRetryPolicy
+		expectedStatus metav1.ConditionStatus
+		expectedReason string
+	}{
+		{
+			name:           "all clusters pre-cached",
+			states:         map[string]string{"spoke1": PrecacheStateSucceeded, "spoke2": PrecacheStateSucceeded},
+			expectedStatus: metav1.ConditionTrue,
+			expectedReason: "UpgradeCompleted",
+		},
+		{
+			name:           "pre-caching in progress",
+			states:         map[string]string{"spoke1": PrecacheStateError, "spoke2": PrecacheStatePreparingToStart},
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: "PrecachingRequired",
+		},
+		{
+			name:           "failed cluster to be retried",
+			states:         map[string]string{"spoke1": PrecacheStateError, "spoke2": PrecacheStateSucceeded},
+			retry:          &ranv1alpha1.PreCachingRetryPolicy{Attempts: 3},
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: "PrecachingRequired",
+		},
+		{
+			name:           "failed cluster",
+			states:         map[string]string{"spoke1": PrecacheStateError, "spoke2": PrecacheStateSucceeded},
+			expectedStatus: metav1.ConditionFalse,
+			expectedReason: ranv1alpha1.ConditionReasons.PrecachingFailed,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			lastFailure := metav1.Now()
+			cgu := &ranv1alpha1.ClusterGroupUpgrade{
+				ObjectMeta: metav1.ObjectMeta{Name: "cgu", Namespace: "default"},
+				Spec: ranv1alpha1.ClusterGroupUpgradeSpec{
+					PreCaching: true,
+					PreCachingConfig: &ranv1alpha1.PreCachingConfig{
+						Standalone: true, Retry: tc.retry, AdditionalImages: []string{"quay.io/cnf:v1"},
+					},
+				},
+				Status: ranv1alpha1.ClusterGroupUpgradeStatus{
+					Conditions: []metav1.Condition{
+						{Type: utils.PrecacheSpecValidCondition, Status: metav1.ConditionTrue, Reason: "PrecacheSpecIsWellFormed"},
+					},
+					Precaching: &ranv1alpha1.PrecachingStatus{
+						Clusters: []string{"spoke1", "spoke2"},
+						Status:   tc.states,
+						Attempts: map[string]*ranv1alpha1.PrecachingAttempts{
+							"spoke1": {Count: 1, LastFailureTime: &lastFailure},
+						},
+					},
+				},
+			}
+			fakeClient, err := getFakeClientFromObjects(cgu)
+			if err != nil {
+				t.Errorf("error in creating fake client")
+			}
+			r := &ClusterGroupUpgradeReconciler{Client: fakeClient, Log: logr.Discard(), Scheme: testscheme}
+			assert.NoError(t, r.precachingFsm(context.TODO(), cgu))
+			readyCondition := meta.FindStatusCondition(cgu.Status.Conditions, "Ready")
+			if assert.NotNil(t, readyCondition) {
+				assert.Equal(t, tc.expectedStatus, readyCondition.Status)
+				assert.Equal(t, tc.expectedReason, readyCondition.Reason)
+			}
+		})
+	}
+}
+
+func TestPrecachingFsm_standaloneSpec(t *testing.T) {
+	cgu := &ranv1alpha1.ClusterGroupUpgrade{
+		ObjectMeta: metav1.ObjectMeta{Name: "cgu", Namespace: "default"},
+		Spec: ranv1alpha1.ClusterGroupUpgradeSpec{
+			PreCaching: true,
+			PreCachingConfig: &ranv1alpha1.PreCachingConfig{
+				Standalone:       true,
+				PlatformImage:    "quay.io/release",
+				AdditionalImages: []string{"quay.io/cnf:v1"},
+			},
+			// The policies are not used by a standalone CGU
+			ManagedPolicies: []string{"missing-policy"},
+		},
+		Status: ranv1alpha1.ClusterGroupUpgradeStatus{
+			Precaching: &ranv1alpha1.PrecachingStatus{
+				Clusters: []string{"spoke1"},
+				Status:   map[string]string{},
+			},
+		},
+	}
+	fakeClient, err := getFakeClientFromObjects(cgu)
+	if err != nil {
+		t.Errorf("error in creating fake client")
+	}
+	r := &ClusterGroupUpgradeReconciler{Client: fakeClient, Log: logr.Discard(), Scheme: testscheme}
+	assert.NoError(t, r.precachingFsm(context.TODO(), cgu))
+	assert.True(t, meta.IsStatusConditionTrue(cgu.Status.Conditions, utils.PrecacheSpecValidCondition))
+	assert.Equal(t, &ranv1alpha1.PrecachingSpec{
+		PlatformImage: "quay.io/release", AdditionalImages: []string{"quay.io/cnf:v1"},
+	}, cgu.Status.Precaching.Spec)
+	assert.Equal(t, []string{"quay.io/cnf:v1"}, r.getPrecacheSpecTemplateData(cgu, "spoke1").AdditionalImages)
+}
+
+func TestPrecachingFsm_precachedBy(t *testing.T) {
+	spec := &ranv1alpha1.PrecachingSpec{PlatformImage: "quay.io/release:4.12"}
+	otherSpec := &ranv1alpha1.PrecachingSpec{PlatformImage: "quay.io/release:4.11"}
+	specName, err := getPrecachingSpecName(*spec)
+	assert.NoError(t, err)
+	otherSpecName, err := getPrecachingSpecName(*otherSpec)
+	assert.NoError(t, err)
+	precachingCgu := &ranv1alpha1.ClusterGroupUpgrade{
+		ObjectMeta: metav1.ObjectMeta{Name: "precache", Namespace: "default"},
+		Status: ranv1alpha1.ClusterGroupUpgradeStatus{
+			Precaching: &ranv1alpha1.PrecachingStatus{
+				Specs: map[string]*ranv1alpha1.PrecachingSpec{specName: spec, otherSpecName: otherSpec},
+				ClusterSpecs: map[string]string{
+					"spoke1": specName, "spoke2": specName, "spoke3": otherSpecName,
+				},
+				Status: map[string]string{
+					"spoke1": PrecacheStateSucceeded, "spoke2": PrecacheStateError, "spoke3": PrecacheStateSucceeded,
+				},
+			},
+		},
+	}
+	// spoke3 was pre-cached with another software spec, it is pre-cached again
+	cgu := &ranv1alpha1.ClusterGroupUpgrade{
+		ObjectMeta: metav1.ObjectMeta{Name: "cgu", Namespace: "default"},
+		Spec: ranv1alpha1.ClusterGroupUpgradeSpec{
+			PreCaching:       true,
+			PreCachingConfig: &ranv1alpha1.PreCachingConfig{PrecachedBy: "precache"},
+		},
+		Status: ranv1alpha1.ClusterGroupUpgradeStatus{
+			Conditions: []metav1.Condition{
+				{Type: utils.PrecacheSpecValidCondition, Status: metav1.ConditionTrue, Reason: "PrecacheSpecIsWellFormed"},
+			},
+			Precaching: &ranv1alpha1.PrecachingStatus{
+				Spec:     spec,
+				Clusters: []string{"spoke1", "spoke2", "spoke3"},
+				Status: map[string]string{
+					"spoke1": PrecacheStateNotStarted, "spoke2": PrecacheStateNotStarted, "spoke3": PrecacheStateNotStarted,
+				},
+			},
+		},
+	}
+	fakeClient, err := getFakeClientFromObjects(cgu, precachingCgu)
+	if err != nil {
+		t.Errorf("error in creating fake client")
+	}
+	r := &ClusterGroupUpgradeReconciler{Client: fakeClient, Log: logr.Discard(), Scheme: testscheme}
+	assert.NoError(t, r.precachingFsm(context.TODO(), cgu))
+	assert.Equal(t, map[string]string{
+		"spoke1": PrecacheStateSucceeded, "spoke2": PrecacheStatePreparingToStart, "spoke3": PrecacheStatePreparingToStart,
+	}, cgu.Status.Precaching.Status)
+	assert.Nil(t, cgu.Status.Precaching.Attempts["spoke1"])
+
+	// A missing CGU is ignored
+	cgu.Spec.PreCachingConfig.PrecachedBy = "missing"
+	precachedClusters, err := r.getPrecachedClusters(context.TODO(), cgu)
+	assert.NoError(t, err)
+	assert.Empty(t, precachedClusters)
+}
+
+func TestPrecachingFsm_includePreCachingConfig(t *testing.T) {
+	policiesSpec := ranv1alpha1.PrecachingSpec{
+		PlatformImage:                "quay.io/release:policies",
+		OperatorsIndexes:             []string{"quay.io/index:policies"},
+		OperatorsPackagesAndChannels: []string{"sriov:stable"},
+	}
+	testcases := []struct {
+		name     string
+		config   *ranv1alpha1.PreCachingConfig
+		expected ranv1alpha1.PrecachingSpec
+	}{
+		{
+			name:     "no config",
+			expected: policiesSpec,
+		},
+		{
+			name: "config takes precedence",
+			config: &ranv1alpha1.PreCachingConfig{
+				PlatformImage:           "quay.io/release:config",
+				AdditionalImages:        []string{"quay.io/cnf:v1"},
+				ExcludePrecachePatterns: []string{"aws"},
+				SpaceRequired:           "40Gi",
+			},
+			expected: ranv1alpha1.PrecachingSpec{
+				PlatformImage:                "quay.io/release:config",
+				OperatorsIndexes:             []string{"quay.io/index:policies"},
+				OperatorsPackagesAndChannels: []string{"sriov:stable"},
+				AdditionalImages:             []string{"quay.io/cnf:v1"},
+				ExcludePrecachePatterns:      []string{"aws"},
+				SpaceRequired:                "40Gi",
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			cgu := &ranv1alpha1.ClusterGroupUpgrade{
+				Spec: ranv1alpha1.ClusterGroupUpgradeSpec{PreCaching: true, PreCachingConfig: tc.config},
+			}
+			assert.Equal(t, tc.expected, includePreCachingConfig(cgu, policiesSpec))
+		})
+	}
+}
+
+func TestPrecachingFsm_checkPreCacheSpecConsistency(t *testing.T) {
+	testcases := []struct {
+		name       string
+		spec       ranv1alpha1.PrecachingSpec
+		consistent bool
+	}{
+		{
+			name:       "platform image",
+			spec:       ranv1alpha1.PrecachingSpec{PlatformImage: "quay.io/release", SpaceRequired: "40Gi"},
+			consistent: true,
+		},
+		{
+			name:       "additional images only",
+			spec:       ranv1alpha1.PrecachingSpec{AdditionalImages: []string{"quay.io/cnf:v1"}},
+			consistent: true,
+		},
+		{
+			name: "no software spec",
+			spec: ranv1alpha1.PrecachingSpec{ExcludePrecachePatterns: []string{"aws"}},
+		},
+		{
+			name: "invalid exclude pattern",
+			spec: ranv1alpha1.PrecachingSpec{PlatformImage: "quay.io/release", ExcludePrecachePatterns: []string{"aws("}},
+		},
+		{
+			name: "invalid space required",
+			spec: ranv1alpha1.PrecachingSpec{PlatformImage: "quay.io/release", SpaceRequired: "40 gigs"},
+		},
+	}
+	r := &ClusterGroupUpgradeReconciler{Log: logr.Discard()}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			consistent, message := r.checkPreCacheSpecConsistency(tc.spec)
+			assert.Equal(t, tc.consistent, consistent)
+			assert.Equal(t, tc.consistent, message == "")
+		})
+	}
+}
+
+func TestPrecachingFsm_updatePrecachingSpecs(t *testing.T) {
+	newPolicy := func(name, namespace, image string, labels map[string]string) *policiesv1.Policy {
+		objectDefinition := fmt.Sprintf(`{"apiVersion": "policy.open-cluster-management.io/v1",
+			"kind": "ConfigurationPolicy", "spec": {"object-templates": [{"objectDefinition": {
+			"kind": "ClusterVersion", "spec": {"desiredUpdate": {"image": "%s"}}}}]}}`, image)
+		return &policiesv1.Policy{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: labels},
+			Spec: policiesv1.PolicySpec{
+				PolicyTemplates: []*policiesv1.PolicyTemplate{
+					{ObjectDefinition: runtime.RawExtension{Raw: []byte(objectDefinition)}},
+				},
+			},
+		}
+	}
+	newChildPolicy := func(cluster, root, image string) *policiesv1.Policy {
+		return newPolicy("default."+root, cluster, image, map[string]string{utils.ChildPolicyLabel: "default." + root})
+	}
+	rootPolicies := []client.Object{
+		newPolicy("upgrade", "default", "quay.io/release:4.10", nil),
+		newPolicy("hotfix", "default", "quay.io/release:4.10", nil),
+	}
+
+	testcases := []struct {
+		name                 string
+		managedPolicies      []string
+		childPolicies        []client.Object
+		expectedSpec         *ranv1alpha1.PrecachingSpec
+		expectedSpecs        map[string]string
+		expectedClusterSpecs map[string]string
+		expectedReason       string
+	}{
+		{
+			name:            "same spec on all the clusters",
+			managedPolicies: []string{"upgrade"},
+			childPolicies: []client.Object{
+				newChildPolicy("spoke1", "upgrade", "quay.io/release:4.10"),
+				newChildPolicy("spoke2", "upgrade", "quay.io/release:4.10"),
+				newChildPolicy("spoke3", "upgrade", "quay.io/release:4.10"),
+			},
+			expectedSpec:   &ranv1alpha1.PrecachingSpec{PlatformImage: "quay.io/release:4.10"},
+			expectedReason: "PrecacheSpecIsWellFormed",
+		},
+		{
+			name:            "spec per cluster",
+			managedPolicies: []string{"upgrade"},
+			childPolicies: []client.Object{
+				newChildPolicy("spoke1", "upgrade", "quay.io/release:4.10"),
+				newChildPolicy("spoke2", "upgrade", "quay.io/release:4.11"),
+			},
+			// spoke3 has no child policy yet, its spec is the one of the root policies
+			expectedSpecs: map[string]string{"spoke1": "quay.io/release:4.10", "spoke2": "quay.io/release:4.11",
+				"spoke3": "quay.io/release:4.10"},
+			expectedReason: "PrecacheSpecIsWellFormed",
+		},
+		{
+			name:            "conflict on a cluster",
+			managedPolicies: []string{"upgrade", "hotfix"},
+			childPolicies: []client.Object{
+				newChildPolicy("spoke1", "upgrade", "quay.io/release:4.10"),
+				newChildPolicy("spoke2", "upgrade", "quay.io/release:4.10"),
+				newChildPolicy("spoke2", "hotfix", "quay.io/release:4.11"),
+			},
+			expectedReason: "PlatformImageConflict",
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			cgu := &ranv1alpha1.ClusterGroupUpgrade{
+				ObjectMeta: metav1.ObjectMeta{Name: "cgu", Namespace: "default"},
+				Spec: ranv1alpha1.ClusterGroupUpgradeSpec{
+					PreCaching:      true,
+					Clusters:        []string{"spoke1", "spoke2", "spoke3"},
+					ManagedPolicies: tc.managedPolicies,
+				},
+				Status: ranv1alpha1.ClusterGroupUpgradeStatus{Precaching: &ranv1alpha1.PrecachingStatus{}},
+			}
+			fakeClient, err := getFakeClientFromObjects(append(append([]client.Object{cgu}, rootPolicies...),
+				tc.childPolicies...)...)
+			if err != nil {
+				t.Errorf("error in creating fake client")
+			}
+			r := &ClusterGroupUpgradeReconciler{Client: fakeClient, Log: logr.Discard(), Scheme: testscheme}
+
+			valid, err := r.updatePrecachingSpecs(context.TODO(), cgu, cgu.Spec.Clusters)
+			assert.NoError(t, err)
+			condition := meta.FindStatusCondition(cgu.Status.Conditions, utils.PrecacheSpecValidCondition)
+			assert.Equal(t, tc.expectedReason, condition.Reason)
+			assert.Equal(t, tc.expectedReason == "PrecacheSpecIsWellFormed", valid)
+			if !valid {
+				assert.Contains(t, condition.Message, "spoke2")
+				return
+			}
+			assert.Equal(t, tc.expectedSpec, cgu.Status.Precaching.Spec)
+			if tc.expectedSpecs == nil {
+				assert.Nil(t, cgu.Status.Precaching.Specs)
+				assert.Nil(t, cgu.Status.Precaching.ClusterSpecs)
+				return
+			}
+			assert.Len(t, cgu.Status.Precaching.Specs, 2)
+			for cluster, image := range tc.expectedSpecs {
+				assert.Equal(t, image, getClusterPrecachingSpec(cgu, cluster).PlatformImage)
+			}
+			assert.Equal(t, cgu.Status.Precaching.ClusterSpecs["spoke1"], cgu.Status.Precaching.ClusterSpecs["spoke3"])
+
+			// The spec ConfigMap action is rendered once per spec and created on each cluster
+			ctx := withReconcileCache(context.TODO())
+			for _, cluster := range cgu.Spec.Clusters {
+				assert.NoError(t, r.createPrecachingSpecConfigMap(ctx, cgu, r.getPrecacheSpecTemplateData(cgu, cluster)))
+			}
+			assert.Len(t, getReconcileCache(ctx).specConfigMaps, 2)
+			for cluster, image := range tc.expectedSpecs {
+				action := &unstructured.Unstructured{}
+				action.SetGroupVersionKind(schema.GroupVersionKind{
+					Group: "action.open-cluster-management.io", Version: "v1beta1", Kind: "ManagedClusterAction"})
+				assert.NoError(t, fakeClient.Get(context.TODO(),
+					types.NamespacedName{Name: "precache-spec-cm-create", Namespace: cluster}, action))
+				platformImage, _, _ := unstructured.NestedString(action.Object, "spec", "kube", "template", "data",
+					"platform.image")
+				assert.Equal(t, image, platformImage)
+			}
+		})
+	}
+}
+
+func TestPrecachingFsm_installedCSVs(t *testing.T) {
+	policy := &unstructured.Unstructured{Object: map[string]interface{}{
+		"metadata": map[string]interface{}{"name": "operators", "namespace": "default"},
+		"spec": map[string]interface{}{
+			"policy-templates": []interface{}{map[string]interface{}{
+				"objectDefinition": map[string]interface{}{
+					"spec": map[string]interface{}{
+						"object-templates": []interface{}{map[string]interface{}{
+							"objectDefinition": map[string]interface{}{
+								"kind":     "Subscription",
+								"metadata": map[string]interface{}{"name": "sriov-sub", "namespace": "openshift-sriov"},
+								"spec": map[string]interface{}{
+									"name": "sriov-network-operator", "channel": "stable",
+								},
+							},
+						}},
+					},
+				},
+			}},
+		},
+	}}
+
+	testcases := []struct {
+		name         string
+		condition    *metav1.Condition
+		result       string
+		expectedCSVs []string
+		pending      bool
+	}{
+		{
+			name:    "view not processed yet",
+			pending: true,
+		},
+		{
+			name: "operator installed",
+			condition: &metav1.Condition{
+				Type: viewv1beta1.ConditionViewProcessing, Status: metav1.ConditionTrue, Reason: viewv1beta1.ReasonGetResource,
+			},
+			result:       `{"kind": "Subscription", "status": {"installedCSV": "sriov-network-operator.v4.10.0"}}`,
+			expectedCSVs: []string{"sriov-network-operator:sriov-network-operator.v4.10.0"},
+		},
+		{
+			name: "operator not installed",
+			condition: &metav1.Condition{
+				Type: viewv1beta1.ConditionViewProcessing, Status: metav1.ConditionFalse,
+				Reason: viewv1beta1.ReasonGetResourceFailed,
+			},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			cgu := &ranv1alpha1.ClusterGroupUpgrade{
+				ObjectMeta: metav1.ObjectMeta{Name: "cgu", Namespace: "default"},
+			}
+			fakeClient, err := getFakeClientFromObjects(cgu)
+			if err != nil {
+				t.Errorf("error in creating fake client")
+			}
+			r := &ClusterGroupUpgradeReconciler{Client: fakeClient, Log: logr.Discard(), Scheme: testscheme}
+
+			// The view is the one the InstallPlans are approved through
+			viewName := utils.GetMultiCloudObjectName(cgu, utils.PolicyTypeSubscription, "sriov-sub")
+			safeName := utils.GetSafeResourceName(viewName, cgu, utils.MaxObjectNameLength, 0)
+			if tc.condition != nil {
+				view := &viewv1beta1.ManagedClusterView{
+					ObjectMeta: metav1.ObjectMeta{Name: safeName, Namespace: "spoke1"},
+					Spec: viewv1beta1.ViewSpec{
+						Scope: viewv1beta1.ViewScope{
+							Resource: "subscriptions.operators.coreos.com", Name: "sriov-sub", Namespace: "openshift-sriov",
+						},
+					},
+					Status: viewv1beta1.ViewStatus{Conditions: []metav1.Condition{*tc.condition}},
+				}
+				if tc.result != "" {
+					view.Status.Result = runtime.RawExtension{Raw: []byte(tc.result)}
+				}
+				assert.NoError(t, fakeClient.Create(context.TODO(), view))
+			}
+
+			spec, err := r.extractPrecachingSpecFromPolicies(context.TODO(), cgu, "spoke1",
+				[]*unstructured.Unstructured{policy})
+			view := &viewv1beta1.ManagedClusterView{}
+			assert.NoError(t, fakeClient.Get(context.TODO(), types.NamespacedName{Name: safeName, Namespace: "spoke1"}, view))
+			assert.Equal(t, "openshift-sriov", view.Spec.Scope.Namespace)
+			if tc.pending {
+				assert.IsType(t, &installedCSVPendingError{}, err)
+				assert.Equal(t, []string{"sriov-network-operator"}, err.(*installedCSVPendingError).packages)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, []string{"sriov-network-operator:stable"}, spec.OperatorsPackagesAndChannels)
+			assert.Equal(t, tc.expectedCSVs, spec.OperatorsInstalledCSVs)
+		})
+	}
+}
+
+func TestPrecachingFsm_installedCSVsPending(t *testing.T) {
+	objectDefinition := `{"apiVersion": "policy.open-cluster-management.io/v1",
+		"kind": "ConfigurationPolicy", "spec": {"object-templates": [{"objectDefinition": {
+		"kind": "Subscription", "metadata": {"name": "sriov-sub", "namespace": "openshift-sriov"},
+		"spec": {"name": "sriov-network-operator", "channel": "stable"}}}, {"objectDefinition": {
+		"kind": "CatalogSource", "spec": {"image": "quay.io/index:v4.10"}}}]}}`
+	newPolicy := func(name, namespace string, labels map[string]string) client.Object {
+		return &policiesv1.Policy{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Labels: labels},
+			Spec: policiesv1.PolicySpec{
+				PolicyTemplates: []*policiesv1.PolicyTemplate{
+					{ObjectDefinition: runtime.RawExtension{Raw: []byte(objectDefinition)}},
+				},
+			},
+		}
+	}
+	cgu := &ranv1alpha1.ClusterGroupUpgrade{
+		ObjectMeta: metav1.ObjectMeta{Name: "cgu", Namespace: "default"},
+		Spec: ranv1alpha1.ClusterGroupUpgradeSpec{
+			PreCaching:      true,
+			Clusters:        []string{"spoke1", "spoke2", "spoke3"},
+			ManagedPolicies: []string{"operators"},
+		},
+		Status: ranv1alpha1.ClusterGroupUpgradeStatus{Precaching: &ranv1alpha1.PrecachingStatus{}},
+	}
+	objs := []client.Object{cgu, newPolicy("operators", "default", nil)}
+	for _, cluster := range cgu.Spec.Clusters {
+		objs = append(objs, newPolicy("default.operators", cluster,
+			map[string]string{utils.ChildPolicyLabel: "default.operators"}))
+	}
+	fakeClient, err := getFakeClientFromObjects(objs...)
+	if err != nil {
+		t.Errorf("error in creating fake client")
+	}
+	r := &ClusterGroupUpgradeReconciler{Client: fakeClient, Log: logr.Discard(), Scheme: testscheme}
+
+	// The views of all the clusters are created in a single pass, and the spec is not reported invalid
+	valid, err := r.updatePrecachingSpecs(context.TODO(), cgu, cgu.Spec.Clusters)
+	assert.NoError(t, err)
+	assert.False(t, valid)
+	condition := meta.FindStatusCondition(cgu.Status.Conditions, utils.PrecacheSpecValidCondition)
+	assert.Equal(t, metav1.ConditionUnknown, condition.Status)
+	assert.Equal(t, "InstalledCSVPending", condition.Reason)
+	assert.Contains(t, condition.Message, "[spoke1 spoke2 spoke3]")
+	viewName := utils.GetMultiCloudObjectName(cgu, utils.PolicyTypeSubscription, "sriov-sub")
+	safeName := utils.GetSafeResourceName(viewName, cgu, utils.MaxObjectNameLength, 0)
+	for _, cluster := range cgu.Spec.Clusters {
+		view := &viewv1beta1.ManagedClusterView{}
+		assert.NoError(t, fakeClient.Get(context.TODO(), types.NamespacedName{Name: safeName, Namespace: cluster}, view))
+
+		// The operator is not installed on the clusters
+		meta.SetStatusCondition(&view.Status.Conditions, metav1.Condition{
+			Type: viewv1beta1.ConditionViewProcessing, Status: metav1.ConditionFalse,
+			Reason: viewv1beta1.ReasonGetResourceFailed,
+		})
+		assert.NoError(t, fakeClient.Update(context.TODO(), view))
+	}
+
+	// The specs are computed once all the views are read
+	valid, err = r.updatePrecachingSpecs(context.TODO(), cgu, cgu.Spec.Clusters)
+	assert.NoError(t, err)
+	assert.True(t, valid)
+	assert.Equal(t, []string{"sriov-network-operator:stable"}, cgu.Status.Precaching.Spec.OperatorsPackagesAndChannels)
+}
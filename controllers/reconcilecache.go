@@ -0,0 +1,69 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"strconv"
+
+	ranv1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+type reconcileCacheKey struct{}
+
+// reconcileCache holds the results computed once per reconcile of a CGU. The clusters only depend on the
+// CGU spec, the policy compliance on the version of the policy, the pre-caching spec ConfigMap actions on the
+// software spec they carry.
+type reconcileCache struct {
+	clusters          map[string][]string
+	clusterCompliance map[string]map[string]string
+	specConfigMaps    map[string]*unstructured.Unstructured
+	// capacityClaims holds the number of slots the CGU used for each kind of work it claimed slots for,
+	// until the claims are released
+	capacityClaims map[string]int
+	// storedCgus holds the CGUs as stored on the API server when the reconcile read them or last wrote their
+	// status, without the cluster states loaded from ConfigMaps. They are the base of the status patches.
+	storedCgus map[types.NamespacedName]*ranv1alpha1.ClusterGroupUpgrade
+}
+
+// withReconcileCache returns a context carrying a new reconcileCache
+func withReconcileCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, reconcileCacheKey{}, &reconcileCache{
+		clusters:          make(map[string][]string),
+		clusterCompliance: make(map[string]map[string]string),
+		specConfigMaps:    make(map[string]*unstructured.Unstructured),
+		capacityClaims:    make(map[string]int),
+		storedCgus:        make(map[types.NamespacedName]*ranv1alpha1.ClusterGroupUpgrade),
+	})
+}
+
+// getReconcileCache returns the reconcileCache of the context, nil outside of a reconcile
+func getReconcileCache(ctx context.Context) *reconcileCache {
+	cache, _ := ctx.Value(reconcileCacheKey{}).(*reconcileCache)
+	return cache
+}
+
+func cguCacheKey(clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade) string {
+	return clusterGroupUpgrade.Namespace + "/" + clusterGroupUpgrade.Name + "/" +
+		strconv.FormatInt(clusterGroupUpgrade.Generation, 10)
+}
+
+func policyCacheKey(policy *unstructured.Unstructured) string {
+	return policy.GetNamespace() + "/" + policy.GetName() + "/" + policy.GetResourceVersion()
+}
@@ -0,0 +1,204 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/go-logr/logr"
+	policiesv1 "github.com/open-cluster-management/governance-policy-propagator/api/v1"
+	ranv1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/api/v1alpha1"
+	"github.com/openshift-kni/cluster-group-upgrades-operator/controllers/utils"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// countingClient counts the reads of the client
+type countingClient struct {
+	client.Client
+	gets  int
+	lists int
+}
+
+func (c *countingClient) Get(ctx context.Context, key client.ObjectKey, obj client.Object) error {
+	c.gets++
+	return c.Client.Get(ctx, key, obj)
+}
+
+func (c *countingClient) List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error {
+	c.lists++
+	return c.Client.List(ctx, list, opts...)
+}
+
+// getHubObjects returns a hub with the given number of clusters, labeled upgrade=true or upgrade=false in turn,
+// and the given number of inform policies propagated to all of them. The odd clusters are NonCompliant.
+func getHubObjects(clusterCount, policyCount int) []client.Object {
+	var objs []client.Object
+	for i := 0; i < policyCount; i++ {
+		policyName := fmt.Sprintf("policy%d", i)
+		rootPolicy := &policiesv1.Policy{
+			ObjectMeta: metav1.ObjectMeta{Name: policyName, Namespace: "policies"},
+			Spec:       policiesv1.PolicySpec{RemediationAction: policiesv1.Inform},
+		}
+		for j := 0; j < clusterCount; j++ {
+			clusterName := fmt.Sprintf("spoke%d", j)
+			compliance := policiesv1.Compliant
+			if j%2 == 1 {
+				compliance = policiesv1.NonCompliant
+			}
+			rootPolicy.Status.Status = append(rootPolicy.Status.Status, &policiesv1.CompliancePerClusterStatus{
+				ComplianceState: compliance, ClusterName: clusterName, ClusterNamespace: clusterName})
+			objs = append(objs, &policiesv1.Policy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "policies." + policyName, Namespace: clusterName,
+					Labels: map[string]string{utils.ChildPolicyLabel: "policies." + policyName},
+				},
+				Spec: policiesv1.PolicySpec{RemediationAction: policiesv1.Inform},
+			})
+		}
+		objs = append(objs, rootPolicy)
+	}
+	for j := 0; j < clusterCount; j++ {
+		objs = append(objs, &clusterv1.ManagedCluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:   fmt.Sprintf("spoke%d", j),
+				Labels: map[string]string{"upgrade": fmt.Sprint(j%2 == 0), "common": "true"},
+			},
+		})
+	}
+	return objs
+}
+
+func TestReconcileCache_getAllClustersForUpgrade(t *testing.T) {
+	testcases := []struct {
+		name     string
+		spec     ranv1alpha1.ClusterGroupUpgradeSpec
+		expected []string
+	}{
+		{
+			name:     "cluster selector",
+			spec:     ranv1alpha1.ClusterGroupUpgradeSpec{ClusterSelector: []string{"upgrade=true"}},
+			expected: []string{"spoke0", "spoke2"},
+		},
+		{
+			name: "cluster label selector",
+			spec: ranv1alpha1.ClusterGroupUpgradeSpec{ClusterLabelSelectors: []metav1.LabelSelector{
+				{MatchLabels: map[string]string{"common": "true", "upgrade": "false"}},
+			}},
+			expected: []string{"spoke1", "spoke3"},
+		},
+		{
+			name: "cluster label selector with expressions only",
+			spec: ranv1alpha1.ClusterGroupUpgradeSpec{ClusterLabelSelectors: []metav1.LabelSelector{
+				{MatchExpressions: []metav1.LabelSelectorRequirement{
+					{Key: "upgrade", Operator: metav1.LabelSelectorOpIn, Values: []string{"true"}},
+				}},
+			}},
+			expected: []string{"spoke0", "spoke2"},
+		},
+		{
+			name: "selectors and clusters",
+			spec: ranv1alpha1.ClusterGroupUpgradeSpec{
+				ClusterSelector: []string{"upgrade=true"},
+				Clusters:        []string{"spoke2", "spoke3"},
+			},
+			expected: []string{"spoke0", "spoke2", "spoke3"},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeClient, err := getFakeClientFromObjects(getHubObjects(4, 0)...)
+			if err != nil {
+				t.Errorf("error in creating fake client")
+			}
+			countingClient := &countingClient{Client: fakeClient}
+			r := &ClusterGroupUpgradeReconciler{Client: countingClient, Log: logr.Discard(), Scheme: testscheme}
+			cgu := &ranv1alpha1.ClusterGroupUpgrade{
+				ObjectMeta: metav1.ObjectMeta{Name: "cgu", Namespace: "default"},
+				Spec:       tc.spec,
+			}
+
+			ctx := withReconcileCache(context.TODO())
+			clusters, err := r.getAllClustersForUpgrade(ctx, cgu)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, clusters)
+			lists := countingClient.lists
+
+			// The clusters are listed once per reconcile and can't be changed by the callers
+			clusters[0] = "changed"
+			clusters, err = r.getAllClustersForUpgrade(ctx, cgu)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, clusters)
+			assert.Equal(t, lists, countingClient.lists)
+		})
+	}
+}
+
+func TestReconcileCache_doManagedPoliciesExist(t *testing.T) {
+	objs := getHubObjects(4, 2)
+	// An enforce policy only propagated to a cluster outside of the CGU is ignored
+	objs = append(objs, &policiesv1.Policy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "other.policy1", Namespace: "spoke9",
+			Labels: map[string]string{utils.ChildPolicyLabel: "other.policy1"},
+		},
+		Spec: policiesv1.PolicySpec{RemediationAction: policiesv1.Enforce},
+	})
+	fakeClient, err := getFakeClientFromObjects(objs...)
+	if err != nil {
+		t.Errorf("error in creating fake client")
+	}
+	r := &ClusterGroupUpgradeReconciler{Client: fakeClient, Log: logr.Discard(), Scheme: testscheme}
+	cgu := &ranv1alpha1.ClusterGroupUpgrade{
+		ObjectMeta: metav1.ObjectMeta{Name: "cgu", Namespace: "default"},
+		Spec: ranv1alpha1.ClusterGroupUpgradeSpec{
+			ClusterSelector: []string{"common=true"},
+			ManagedPolicies: []string{"policy0", "policy1", "policy2"},
+		},
+	}
+
+	ctx := withReconcileCache(context.TODO())
+	allPoliciesExist, missingPolicies, presentPolicies, err := r.doManagedPoliciesExist(ctx, cgu, true)
+	assert.NoError(t, err)
+	assert.False(t, allPoliciesExist)
+	assert.Equal(t, []string{"policy2"}, missingPolicies)
+	assert.Len(t, presentPolicies, 2)
+	assert.Equal(t, map[string]string{"policy0": "policies", "policy1": "policies"}, cgu.Status.ManagedPoliciesNs)
+
+	clusters, err := r.getClustersNonCompliantWithPolicy(ctx, cgu, presentPolicies[0])
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"spoke1", "spoke3"}, clusters)
+	assert.Equal(t, utils.ClusterNotMatchedWithPolicy,
+		r.getClusterComplianceWithPolicy(ctx, "spoke9", presentPolicies[0]))
+}
+
+func BenchmarkReconcileCache_doManagedPoliciesExist(b *testing.B) {
+	fakeClient, err := getFakeClientFromObjects(getHubObjects(3500, 3)...)
+	if err != nil {
+		b.Errorf("error in creating fake client")
+	}
+	countingClient := &countingClient{Client: fakeClient}
+	r := &ClusterGroupUpgradeReconciler{Client: countingClient, Log: logr.Discard(), Scheme: testscheme}
+	cgu := &ranv1alpha1.ClusterGroupUpgrade{
+		ObjectMeta: metav1.ObjectMeta{Name: "cgu", Namespace: "default"},
+		Spec: ranv1alpha1.ClusterGroupUpgradeSpec{
+			ClusterLabelSelectors: []metav1.LabelSelector{{MatchLabels: map[string]string{"common": "true"}}},
+			ManagedPolicies:       []string{"policy0", "policy1", "policy2"},
+		},
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ctx := withReconcileCache(context.TODO())
+		if _, _, _, err := r.doManagedPoliciesExist(ctx, cgu, true); err != nil {
+			b.Fatal(err)
+		}
+		if _, err := r.getClustersNonCompliantWithManagedPolicies(ctx, cgu, nil); err != nil {
+			b.Fatal(err)
+		}
+	}
+	b.ReportMetric(float64(countingClient.lists)/float64(b.N), "lists/op")
+	b.ReportMetric(float64(countingClient.gets)/float64(b.N), "gets/op")
+}
@@ -0,0 +1,381 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	coordinationv1client "k8s.io/client-go/kubernetes/typed/coordination/v1"
+	"k8s.io/client-go/tools/leaderelection"
+	"k8s.io/client-go/tools/leaderelection/resourcelock"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+)
+
+// Keys the CGUs are assigned to a shard by
+const (
+	ShardByName      = "name"
+	ShardByNamespace = "namespace"
+)
+
+// shardReplicaLabel labels the Leases the replicas renew to be counted, with the Name of the shard leases
+const shardReplicaLabel = "ran.openshift.io/shard-replicas"
+
+// Default timings of the shard leases, the same as the ones of the manager leader election
+const (
+	defaultShardLeaseDuration = 15 * time.Second
+	defaultShardRenewDeadline = 10 * time.Second
+	defaultShardRetryPeriod   = 2 * time.Second
+)
+
+// ShardLeases splits the CGUs between the replicas of the operator. Each CGU belongs to one of Count shards, by
+// the hash of its namespace/name or of its namespace, and each shard is owned by the replica holding its Lease.
+// A replica only reconciles the CGUs of the shards it owns. The replica owning shard 0 also creates the ZTP CGUs.
+// Each replica also renews a replica Lease, and targets its part of the shards among the live replicas.
+// A nil ShardLeases owns everything.
+type ShardLeases struct {
+	Leases coordinationv1client.LeasesGetter
+	// Namespace and Name locate the Leases, named <Name>-shard-<index>
+	Namespace string
+	Name      string
+	Identity  string
+	Count     int
+	// By is ShardByName (default) or ShardByNamespace
+	By  string
+	Log logr.Logger
+
+	LeaseDuration time.Duration
+	RenewDeadline time.Duration
+	RetryPeriod   time.Duration
+
+	lock sync.RWMutex
+	// target is the number of shards this replica holds, 0 until the live replicas are counted
+	target   int
+	owned    map[int]bool
+	handlers []func(shard int)
+}
+
+// ShardOf returns the shard of a CGU
+func (s *ShardLeases) ShardOf(namespace, name string) int {
+	key := namespace + "/" + name
+	if s.By == ShardByNamespace {
+		key = namespace
+	}
+	hash := fnv.New32a()
+	hash.Write([]byte(key))
+	return int(hash.Sum32() % uint32(s.Count))
+}
+
+// Owns returns true if this replica owns the shard of a CGU
+func (s *ShardLeases) Owns(namespace, name string) bool {
+	if s == nil {
+		return true
+	}
+	return s.OwnsShard(s.ShardOf(namespace, name))
+}
+
+// OwnsShard returns true if this replica holds the lease of a shard
+func (s *ShardLeases) OwnsShard(shard int) bool {
+	if s == nil {
+		return true
+	}
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.owned[shard]
+}
+
+// OnAcquired registers a function called each time this replica acquires a shard, for the controllers to
+// reconcile the objects they skipped while another replica owned it. It must be called before Start.
+func (s *ShardLeases) OnAcquired(handler func(shard int)) {
+	s.handlers = append(s.handlers, handler)
+}
+
+// NeedLeaderElection tells the manager to run the shard leases on every replica
+func (s *ShardLeases) NeedLeaderElection() bool {
+	return false
+}
+
+// Start renews the replica Lease and competes for the lease of each shard until the context is done
+func (s *ShardLeases) Start(ctx context.Context) error {
+	if s.Count <= 0 {
+		return fmt.Errorf("the number of shards must be positive, got %d", s.Count)
+	}
+	s.renewReplica(ctx)
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		wait.UntilWithContext(ctx, s.renewReplica, s.retryPeriod())
+	}()
+	for shard := 0; shard < s.Count; shard++ {
+		wg.Add(1)
+		go func(shard int) {
+			defer wg.Done()
+			s.runShard(ctx, shard)
+		}(shard)
+	}
+	wg.Wait()
+	s.removeReplica()
+	return nil
+}
+
+// replicaLeaseName returns the name of the replica Lease of this replica
+func (s *ShardLeases) replicaLeaseName() string {
+	hash := fnv.New32a()
+	hash.Write([]byte(s.Identity))
+	return fmt.Sprintf("%s-replica-%08x", s.Name, hash.Sum32())
+}
+
+/* renewReplica renews the replica Lease of this replica and sets the number of shards it targets: the number of
+   shards divided by the number of replicas whose Lease has not expired, rounded up. The shards of a replica that
+   stops are taken over by the others once its replica Lease expires, and a new replica gets its part of the
+   shards from the replicas above their new target.
+*/
+func (s *ShardLeases) renewReplica(ctx context.Context) {
+	leases := s.Leases.Leases(s.Namespace)
+	now := metav1.NewMicroTime(time.Now())
+	duration := int32(math.Ceil(s.leaseDuration().Seconds()))
+	lease, err := leases.Get(ctx, s.replicaLeaseName(), metav1.GetOptions{})
+	switch {
+	case errors.IsNotFound(err):
+		lease = &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      s.replicaLeaseName(),
+				Namespace: s.Namespace,
+				Labels:    map[string]string{shardReplicaLabel: s.Name},
+			},
+			Spec: coordinationv1.LeaseSpec{
+				HolderIdentity:       &s.Identity,
+				LeaseDurationSeconds: &duration,
+				RenewTime:            &now,
+			},
+		}
+		_, err = leases.Create(ctx, lease, metav1.CreateOptions{})
+	case err == nil:
+		lease.Spec.LeaseDurationSeconds = &duration
+		lease.Spec.RenewTime = &now
+		_, err = leases.Update(ctx, lease, metav1.UpdateOptions{})
+	}
+	if err != nil {
+		s.Log.Error(err, "[renewReplica] Failed to renew the replica lease", "identity", s.Identity)
+		return
+	}
+
+	list, err := leases.List(ctx, metav1.ListOptions{LabelSelector: shardReplicaLabel + "=" + s.Name})
+	if err != nil {
+		s.Log.Error(err, "[renewReplica] Failed to list the replica leases", "identity", s.Identity)
+		return
+	}
+	replicas := 0
+	for _, lease := range list.Items {
+		if lease.Name == s.replicaLeaseName() || isLeaseLive(&lease, now.Time) {
+			replicas++
+		}
+	}
+	if replicas == 0 {
+		replicas = 1
+	}
+	s.setTarget((s.Count + replicas - 1) / replicas)
+}
+
+// isLeaseLive returns true if a replica Lease was renewed within its duration
+func isLeaseLive(lease *coordinationv1.Lease, now time.Time) bool {
+	if lease.Spec.RenewTime == nil || lease.Spec.LeaseDurationSeconds == nil {
+		return false
+	}
+	return now.Before(lease.Spec.RenewTime.Add(time.Duration(*lease.Spec.LeaseDurationSeconds) * time.Second))
+}
+
+// removeReplica deletes the replica Lease of this replica, so the others take over its shards right away
+func (s *ShardLeases) removeReplica() {
+	ctx, cancel := context.WithTimeout(context.Background(), s.renewDeadline())
+	defer cancel()
+	err := s.Leases.Leases(s.Namespace).Delete(ctx, s.replicaLeaseName(), metav1.DeleteOptions{})
+	if err != nil && !errors.IsNotFound(err) {
+		s.Log.Error(err, "[removeReplica] Failed to delete the replica lease", "identity", s.Identity)
+	}
+}
+
+// setTarget sets the number of shards this replica holds
+func (s *ShardLeases) setTarget(target int) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if target != s.target {
+		s.Log.Info("[setTarget] Number of shards to hold changed", "target", target, "identity", s.Identity)
+		s.target = target
+	}
+}
+
+/* runShard competes for the lease of a shard, again each time it is lost, until the context is done. This
+   replica only competes while it owns fewer shards than its target, and stops trying to acquire the lease once
+   it reaches its target by acquiring other shards. Above its target, it gives back its highest shards one at a
+   time. The lease is released when the context is done.
+*/
+func (s *ShardLeases) runShard(ctx context.Context, shard int) {
+	retryPeriod := s.retryPeriod()
+	for ctx.Err() == nil {
+		if s.isFull() {
+			select {
+			case <-ctx.Done():
+			case <-time.After(retryPeriod):
+			}
+			continue
+		}
+
+		attemptCtx, cancel := context.WithCancel(ctx)
+		go wait.Until(func() {
+			if s.isExtra(shard) || (s.isFull() && !s.OwnsShard(shard)) {
+				cancel()
+			}
+		}, retryPeriod, attemptCtx.Done())
+
+		elector, err := leaderelection.NewLeaderElector(leaderelection.LeaderElectionConfig{
+			Lock: &resourcelock.LeaseLock{
+				LeaseMeta: metav1.ObjectMeta{
+					Namespace: s.Namespace,
+					Name:      fmt.Sprintf("%s-shard-%d", s.Name, shard),
+				},
+				Client:     s.Leases,
+				LockConfig: resourcelock.ResourceLockConfig{Identity: s.Identity},
+			},
+			LeaseDuration:   s.leaseDuration(),
+			RenewDeadline:   s.renewDeadline(),
+			RetryPeriod:     retryPeriod,
+			ReleaseOnCancel: true,
+			Callbacks: leaderelection.LeaderCallbacks{
+				OnStartedLeading: func(leaseCtx context.Context) {
+					if !s.acquired(leaseCtx, shard) {
+						cancel()
+					}
+				},
+				OnStoppedLeading: func() {
+					s.released(shard)
+				},
+			},
+		})
+		if err != nil {
+			s.Log.Error(err, "[runShard] Invalid shard lease configuration", "shard", shard)
+			cancel()
+			return
+		}
+		elector.Run(attemptCtx)
+		cancel()
+	}
+}
+
+// acquired records a shard acquired by this replica and notifies the controllers. It returns false if
+// this replica already owns its target of shards or the lease is already lost, the shard is then given back.
+func (s *ShardLeases) acquired(leaseCtx context.Context, shard int) bool {
+	s.lock.Lock()
+	if leaseCtx.Err() != nil || len(s.owned) >= s.target {
+		s.lock.Unlock()
+		return false
+	}
+	if s.owned == nil {
+		s.owned = make(map[int]bool)
+	}
+	s.owned[shard] = true
+	s.lock.Unlock()
+
+	s.Log.Info("[acquired] Acquired shard", "shard", shard, "identity", s.Identity)
+	for _, handler := range s.handlers {
+		handler(shard)
+	}
+	return true
+}
+
+// released records a shard lost or given back by this replica
+func (s *ShardLeases) released(shard int) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.owned[shard] {
+		delete(s.owned, shard)
+		s.Log.Info("[released] Released shard", "shard", shard, "identity", s.Identity)
+	}
+}
+
+// isFull returns true if this replica owns its target of shards
+func (s *ShardLeases) isFull() bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return len(s.owned) >= s.target
+}
+
+// isExtra returns true if this replica owns more shards than its target and the shard is the highest it owns
+func (s *ShardLeases) isExtra(shard int) bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	if !s.owned[shard] || len(s.owned) <= s.target {
+		return false
+	}
+	for owned := range s.owned {
+		if owned > shard {
+			return false
+		}
+	}
+	return true
+}
+
+// capacityLedgerKey returns the ConfigMap the replicas share the fleet-wide capacity claims in, next to the Leases
+func (s *ShardLeases) capacityLedgerKey() types.NamespacedName {
+	return types.NamespacedName{Namespace: s.Namespace, Name: s.Name + "-capacity"}
+}
+
+func (s *ShardLeases) leaseDuration() time.Duration {
+	if s.LeaseDuration > 0 {
+		return s.LeaseDuration
+	}
+	return defaultShardLeaseDuration
+}
+
+func (s *ShardLeases) renewDeadline() time.Duration {
+	if s.RenewDeadline > 0 {
+		return s.RenewDeadline
+	}
+	return defaultShardRenewDeadline
+}
+
+func (s *ShardLeases) retryPeriod() time.Duration {
+	if s.RetryPeriod > 0 {
+		return s.RetryPeriod
+	}
+	return defaultShardRetryPeriod
+}
+
+// resyncSource returns a source of the objects listed by the given function each time this replica acquires a
+// shard, for a controller to reconcile the objects of the shard it ignored so far
+func (s *ShardLeases) resyncSource(list func(shard int) []client.Object) source.Source {
+	events := make(chan event.GenericEvent)
+	s.OnAcquired(func(shard int) {
+		for _, obj := range list(shard) {
+			events <- event.GenericEvent{Object: obj}
+		}
+	})
+	return &source.Channel{Source: events}
+}
@@ -0,0 +1,261 @@
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/stretchr/testify/assert"
+	coordinationv1 "k8s.io/api/coordination/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	coordinationv1client "k8s.io/client-go/kubernetes/typed/coordination/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// fakeLeases keeps Leases in memory for the ShardLeases of several replicas
+type fakeLeases struct {
+	coordinationv1client.LeaseInterface
+	lock    sync.Mutex
+	version int
+	leases  map[string]*coordinationv1.Lease
+}
+
+func (f *fakeLeases) Leases(namespace string) coordinationv1client.LeaseInterface {
+	return f
+}
+
+func (f *fakeLeases) Get(ctx context.Context, name string, opts metav1.GetOptions) (*coordinationv1.Lease, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	lease, ok := f.leases[name]
+	if !ok {
+		return nil, errors.NewNotFound(schema.GroupResource{Group: "coordination.k8s.io", Resource: "leases"}, name)
+	}
+	return lease.DeepCopy(), nil
+}
+
+func (f *fakeLeases) Create(ctx context.Context, lease *coordinationv1.Lease, opts metav1.CreateOptions) (*coordinationv1.Lease, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if _, ok := f.leases[lease.Name]; ok {
+		return nil, errors.NewAlreadyExists(schema.GroupResource{Group: "coordination.k8s.io", Resource: "leases"}, lease.Name)
+	}
+	return f.store(lease), nil
+}
+
+func (f *fakeLeases) Update(ctx context.Context, lease *coordinationv1.Lease, opts metav1.UpdateOptions) (*coordinationv1.Lease, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	current, ok := f.leases[lease.Name]
+	if !ok {
+		return nil, errors.NewNotFound(schema.GroupResource{Group: "coordination.k8s.io", Resource: "leases"}, lease.Name)
+	}
+	if current.ResourceVersion != lease.ResourceVersion {
+		return nil, errors.NewConflict(schema.GroupResource{Group: "coordination.k8s.io", Resource: "leases"}, lease.Name, nil)
+	}
+	return f.store(lease), nil
+}
+
+func (f *fakeLeases) Delete(ctx context.Context, name string, opts metav1.DeleteOptions) error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if _, ok := f.leases[name]; !ok {
+		return errors.NewNotFound(schema.GroupResource{Group: "coordination.k8s.io", Resource: "leases"}, name)
+	}
+	delete(f.leases, name)
+	return nil
+}
+
+func (f *fakeLeases) List(ctx context.Context, opts metav1.ListOptions) (*coordinationv1.LeaseList, error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	selector, err := labels.Parse(opts.LabelSelector)
+	if err != nil {
+		return nil, err
+	}
+	list := &coordinationv1.LeaseList{}
+	for _, lease := range f.leases {
+		if selector.Matches(labels.Set(lease.Labels)) {
+			list.Items = append(list.Items, *lease.DeepCopy())
+		}
+	}
+	return list, nil
+}
+
+func (f *fakeLeases) store(lease *coordinationv1.Lease) *coordinationv1.Lease {
+	if f.leases == nil {
+		f.leases = make(map[string]*coordinationv1.Lease)
+	}
+	f.version++
+	stored := lease.DeepCopy()
+	stored.ResourceVersion = strconv.Itoa(f.version)
+	f.leases[lease.Name] = stored
+	return stored.DeepCopy()
+}
+
+// ownedShards returns the shards owned by a replica
+func ownedShards(s *ShardLeases) []int {
+	var owned []int
+	for shard := 0; shard < s.Count; shard++ {
+		if s.OwnsShard(shard) {
+			owned = append(owned, shard)
+		}
+	}
+	return owned
+}
+
+func TestShardLeases_ShardOf(t *testing.T) {
+	byName := &ShardLeases{Count: 4, By: ShardByName}
+	byNamespace := &ShardLeases{Count: 4, By: ShardByNamespace}
+	shardsByName := make(map[int]bool)
+	for i := 0; i < 100; i++ {
+		name := fmt.Sprintf("cgu%d", i)
+		shard := byName.ShardOf("default", name)
+		assert.True(t, shard >= 0 && shard < 4)
+		assert.Equal(t, shard, byName.ShardOf("default", name))
+		shardsByName[shard] = true
+		assert.Equal(t, byNamespace.ShardOf("default", "cgu0"), byNamespace.ShardOf("default", name))
+	}
+	assert.Len(t, shardsByName, 4)
+
+	// Without sharding everything is owned
+	var noShards *ShardLeases
+	assert.True(t, noShards.Owns("default", "cgu0"))
+	assert.True(t, noShards.OwnsShard(0))
+}
+
+func TestShardLeases_acquired(t *testing.T) {
+	s := &ShardLeases{Count: 3, Log: logr.Discard()}
+	// Until the replicas are counted, no shard is acquired
+	assert.True(t, s.isFull())
+	assert.False(t, s.acquired(context.TODO(), 0))
+	s.setTarget(2)
+	var notified []int
+	s.OnAcquired(func(shard int) { notified = append(notified, shard) })
+
+	assert.True(t, s.acquired(context.TODO(), 0))
+	assert.False(t, s.isFull())
+	assert.True(t, s.acquired(context.TODO(), 2))
+	assert.True(t, s.isFull())
+	// Over the target, the shard is given back
+	assert.False(t, s.acquired(context.TODO(), 1))
+	assert.True(t, s.OwnsShard(0))
+	assert.False(t, s.OwnsShard(1))
+	assert.Equal(t, []int{0, 2}, notified)
+
+	s.released(0)
+	assert.False(t, s.OwnsShard(0))
+	// A lease lost before being recorded is ignored
+	lostCtx, cancel := context.WithCancel(context.TODO())
+	cancel()
+	assert.False(t, s.acquired(lostCtx, 1))
+	assert.True(t, s.acquired(context.TODO(), 1))
+	assert.Equal(t, []int{0, 2, 1}, notified)
+}
+
+func TestShardLeases_Reconcile(t *testing.T) {
+	fakeClient, err := getFakeClientFromObjects()
+	if err != nil {
+		t.Errorf("error in creating fake client")
+	}
+	countingClient := &countingClient{Client: fakeClient}
+	shards := &ShardLeases{Count: 2, Log: logr.Discard()}
+	shards.setTarget(2)
+	r := &ClusterGroupUpgradeReconciler{Client: countingClient, Log: logr.Discard(), Scheme: testscheme, Shards: shards}
+	req := ctrl.Request{}
+	req.Namespace, req.Name = "default", "cgu"
+
+	// The CGUs of the shards owned by other replicas are skipped
+	result, err := r.Reconcile(context.TODO(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, doNotRequeue(), result)
+	assert.Equal(t, 0, countingClient.gets)
+
+	shards.acquired(context.TODO(), shards.ShardOf("default", "cgu"))
+	_, err = r.Reconcile(context.TODO(), req)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, countingClient.gets)
+}
+
+func TestShardLeases_renewReplica(t *testing.T) {
+	leases := &fakeLeases{}
+	s := &ShardLeases{Leases: leases, Name: "cgu", Identity: "replica1", Count: 4, Log: logr.Discard()}
+	s.renewReplica(context.TODO())
+	assert.Equal(t, 4, s.target)
+
+	// A live replica takes its part of the shards
+	duration := int32(15)
+	renewed := metav1.NewMicroTime(time.Now())
+	_, err := leases.Create(context.TODO(), &coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{Name: "cgu-replica-live", Labels: map[string]string{shardReplicaLabel: "cgu"}},
+		Spec:       coordinationv1.LeaseSpec{LeaseDurationSeconds: &duration, RenewTime: &renewed},
+	}, metav1.CreateOptions{})
+	assert.NoError(t, err)
+	s.renewReplica(context.TODO())
+	assert.Equal(t, 2, s.target)
+
+	// An expired replica does not
+	expired := metav1.NewMicroTime(time.Now().Add(-time.Minute))
+	_, err = leases.Create(context.TODO(), &coordinationv1.Lease{
+		ObjectMeta: metav1.ObjectMeta{Name: "cgu-replica-expired", Labels: map[string]string{shardReplicaLabel: "cgu"}},
+		Spec:       coordinationv1.LeaseSpec{LeaseDurationSeconds: &duration, RenewTime: &expired},
+	}, metav1.CreateOptions{})
+	assert.NoError(t, err)
+	s.renewReplica(context.TODO())
+	assert.Equal(t, 2, s.target)
+
+	s.removeReplica()
+	_, err = leases.Get(context.TODO(), s.replicaLeaseName(), metav1.GetOptions{})
+	assert.True(t, errors.IsNotFound(err))
+}
+
+func TestShardLeases_replicaStops(t *testing.T) {
+	leases := &fakeLeases{}
+	newReplica := func(identity string) *ShardLeases {
+		return &ShardLeases{
+			Leases: leases, Name: "cgu", Identity: identity, Count: 4, Log: logr.Discard(),
+			LeaseDuration: time.Second, RenewDeadline: 500 * time.Millisecond, RetryPeriod: 100 * time.Millisecond,
+		}
+	}
+	replica1, replica2 := newReplica("replica1"), newReplica("replica2")
+	ctx1, cancel1 := context.WithCancel(context.TODO())
+	defer cancel1()
+	ctx2, cancel2 := context.WithCancel(context.TODO())
+	stopped := make(chan struct{})
+	go func() {
+		_ = replica1.Start(ctx1)
+	}()
+	go func() {
+		_ = replica2.Start(ctx2)
+		close(stopped)
+	}()
+
+	// The shards are spread over the two replicas
+	assert.Eventually(t, func() bool {
+		return len(ownedShards(replica1)) == 2 && len(ownedShards(replica2)) == 2
+	}, 20*time.Second, 50*time.Millisecond)
+
+	// The shards of the replica that stops are taken over
+	cancel2()
+	<-stopped
+	assert.Empty(t, ownedShards(replica2))
+	assert.Eventually(t, func() bool {
+		return len(ownedShards(replica1)) == 4
+	}, 20*time.Second, 50*time.Millisecond)
+
+	// A new replica gets its part of the shards back
+	replica3 := newReplica("replica3")
+	go func() {
+		_ = replica3.Start(ctx1)
+	}()
+	assert.Eventually(t, func() bool {
+		return len(ownedShards(replica1)) == 2 && len(ownedShards(replica3)) == 2
+	}, 20*time.Second, 50*time.Millisecond)
+}
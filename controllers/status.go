@@ -0,0 +1,141 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	ranv1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// apiReader returns the reader bypassing the cache, or the client if there is none
+func (r *ClusterGroupUpgradeReconciler) apiReader() client.Reader {
+	if r.APIReader != nil {
+		return r.APIReader
+	}
+	return r.Client
+}
+
+/* getClusterGroupUpgrade reads the CGU from the cache. If the cache doesn't have the last status written
+   by the operator yet, the CGU is read from the API server instead, so that a reconcile never works on a
+   status older than the one it wrote before, for example creating a child resource a second time.
+   The per-cluster state stored in ConfigMaps is merged into the status.
+
+   returns: error/nil
+*/
+func (r *ClusterGroupUpgradeReconciler) getClusterGroupUpgrade(
+	ctx context.Context, key types.NamespacedName, clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade) error {
+
+	if err := r.Get(ctx, key, clusterGroupUpgrade); err != nil {
+		return err
+	}
+	writtenVersion, ok := r.writtenVersions.Load(key)
+	if ok && writtenVersion != clusterGroupUpgrade.ResourceVersion {
+		r.Log.Info("[getClusterGroupUpgrade] Stale CGU in the cache, reading it from the API server",
+			"name", key, "cachedVersion", clusterGroupUpgrade.ResourceVersion, "writtenVersion", writtenVersion)
+		if err := r.apiReader().Get(ctx, key, clusterGroupUpgrade); err != nil {
+			return err
+		}
+		// Keep checking until the cache has the write, the other CGUs count its capacity usage
+		ok = writtenVersion != clusterGroupUpgrade.ResourceVersion
+	}
+	if ok {
+		r.writtenVersions.Delete(key)
+	}
+	if cache := getReconcileCache(ctx); cache != nil {
+		cache.storedCgus[key] = clusterGroupUpgrade.DeepCopy()
+	}
+	return r.loadClusterStates(ctx, clusterGroupUpgrade)
+}
+
+// refreshStaleCgu reads a CGU listed from the cache again from the API server if the cache doesn't have its
+// last status write yet
+func (r *ClusterGroupUpgradeReconciler) refreshStaleCgu(ctx context.Context, clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade) error {
+	key := types.NamespacedName{Name: clusterGroupUpgrade.Name, Namespace: clusterGroupUpgrade.Namespace}
+	writtenVersion, ok := r.writtenVersions.Load(key)
+	if !ok || writtenVersion == clusterGroupUpgrade.ResourceVersion {
+		return nil
+	}
+	return client.IgnoreNotFound(r.apiReader().Get(ctx, key, clusterGroupUpgrade))
+}
+
+/* updateStatus writes the status of the CGU as a merge patch against the CGU as the reconcile read it or last
+   wrote it. The patch carries that resourceVersion, so it fails with a conflict if the CGU was written by
+   someone else since, and the CGU is reconciled again from its latest version rather than overwriting the
+   changes. The CGU resourceVersion is updated to the written one. When enabled, the per-cluster state is
+   written to ConfigMaps first and left out of the CGU status.
+
+   returns: error/nil
+*/
+func (r *ClusterGroupUpgradeReconciler) updateStatus(ctx context.Context, clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade) error {
+	updateConditions(clusterGroupUpgrade)
+	key := types.NamespacedName{Name: clusterGroupUpgrade.Name, Namespace: clusterGroupUpgrade.Namespace}
+
+	stored, err := r.getStoredCgu(ctx, clusterGroupUpgrade)
+	if err != nil {
+		return err
+	}
+	status, err := r.storeClusterStates(ctx, clusterGroupUpgrade)
+	if err != nil {
+		return err
+	}
+	previousClusterStates := clusterGroupUpgrade.Status.ClusterStates
+	clusterGroupUpgrade.Status.ClusterStates = status.ClusterStates.DeepCopy()
+	if equality.Semantic.DeepEqual(stored.Status, *status) {
+		return r.deleteUnusedClusterStates(ctx, clusterGroupUpgrade, previousClusterStates)
+	}
+
+	updated := stored.DeepCopy()
+	patch := client.MergeFromWithOptions(stored, client.MergeFromWithOptimisticLock{})
+	updated.Status = *status
+	if err := r.Status().Patch(ctx, updated, patch); err != nil {
+		return err
+	}
+	clusterGroupUpgrade.ResourceVersion = updated.ResourceVersion
+	if cache := getReconcileCache(ctx); cache != nil {
+		cache.storedCgus[key] = updated
+	}
+	r.writtenVersions.Store(key, updated.ResourceVersion)
+	// The status now records the clusters started with the slots claimed
+	r.releaseCapacity(ctx, clusterGroupUpgrade)
+	return r.deleteUnusedClusterStates(ctx, clusterGroupUpgrade, previousClusterStates)
+}
+
+/* getStoredCgu returns the CGU as stored on the API server when the reconcile read it or last wrote its
+   status. Outside of a reconcile, the CGU is read from the API server with the resourceVersion of the given
+   CGU, so that a write made since still makes the status patch fail.
+
+   returns: *ranv1alpha1.ClusterGroupUpgrade
+            error/nil
+*/
+func (r *ClusterGroupUpgradeReconciler) getStoredCgu(
+	ctx context.Context, clusterGroupUpgrade *ranv1alpha1.ClusterGroupUpgrade) (*ranv1alpha1.ClusterGroupUpgrade, error) {
+
+	key := types.NamespacedName{Name: clusterGroupUpgrade.Name, Namespace: clusterGroupUpgrade.Namespace}
+	if cache := getReconcileCache(ctx); cache != nil && cache.storedCgus[key] != nil {
+		return cache.storedCgus[key], nil
+	}
+	stored := &ranv1alpha1.ClusterGroupUpgrade{}
+	if err := r.apiReader().Get(ctx, key, stored); err != nil {
+		return nil, err
+	}
+	stored.ResourceVersion = clusterGroupUpgrade.ResourceVersion
+	return stored, nil
+}
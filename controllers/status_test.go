@@ -0,0 +1,112 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/go-logr/logr"
+	ranv1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestStatus_updateStatus(t *testing.T) {
+	key := types.NamespacedName{Name: "cgu", Namespace: "default"}
+	fakeClient, err := getFakeClientFromObjects(&ranv1alpha1.ClusterGroupUpgrade{
+		ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+		Spec:       ranv1alpha1.ClusterGroupUpgradeSpec{Clusters: []string{"spoke1"}},
+		Status: ranv1alpha1.ClusterGroupUpgradeStatus{
+			ManagedPoliciesNs: map[string]string{"policy1": "default", "policy2": "default"},
+		},
+	})
+	if err != nil {
+		t.Errorf("error in creating fake client")
+	}
+	// The status writes of a reconcile don't read the CGU from the API server again
+	emptyClient, err := getFakeClientFromObjects()
+	if err != nil {
+		t.Errorf("error in creating fake client")
+	}
+	r := &ClusterGroupUpgradeReconciler{Client: fakeClient, APIReader: emptyClient, Log: logr.Discard(), Scheme: testscheme}
+
+	ctx := withReconcileCache(context.TODO())
+	cgu := &ranv1alpha1.ClusterGroupUpgrade{}
+	assert.NoError(t, r.getClusterGroupUpgrade(ctx, key, cgu))
+	cgu.Status.ComputedMaxConcurrency = 1
+	delete(cgu.Status.ManagedPoliciesNs, "policy2")
+	assert.NoError(t, r.updateStatus(ctx, cgu))
+
+	updated := &ranv1alpha1.ClusterGroupUpgrade{}
+	assert.NoError(t, fakeClient.Get(context.TODO(), key, updated))
+	assert.Equal(t, 1, updated.Status.ComputedMaxConcurrency)
+	assert.Equal(t, map[string]string{"policy1": "default"}, updated.Status.ManagedPoliciesNs)
+	assert.Equal(t, updated.ResourceVersion, cgu.ResourceVersion)
+
+	writtenVersion, ok := r.writtenVersions.Load(key)
+	assert.True(t, ok)
+	assert.Equal(t, updated.ResourceVersion, writtenVersion)
+
+	// Nothing is written when the status didn't change
+	assert.NoError(t, r.updateStatus(ctx, cgu))
+	assert.NoError(t, fakeClient.Get(context.TODO(), key, updated))
+	assert.Equal(t, writtenVersion, updated.ResourceVersion)
+
+	// The status written by someone else while the CGU is being reconciled is not overwritten
+	userCgu := updated.DeepCopy()
+	userCgu.Status.ComputedMaxConcurrency = 2
+	assert.NoError(t, fakeClient.Status().Update(context.TODO(), userCgu))
+	cgu.Status.ComputedMaxConcurrency = 3
+	err = r.updateStatus(ctx, cgu)
+	assert.True(t, errors.IsConflict(err))
+	assert.NoError(t, fakeClient.Get(context.TODO(), key, updated))
+	assert.Equal(t, 2, updated.Status.ComputedMaxConcurrency)
+}
+
+func TestStatus_getClusterGroupUpgrade(t *testing.T) {
+	key := types.NamespacedName{Name: "cgu", Namespace: "default"}
+	staleCgu := &ranv1alpha1.ClusterGroupUpgrade{
+		ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+	}
+	latestCgu := staleCgu.DeepCopy()
+	latestCgu.Status.SafeResourceNames = map[string]string{"cgu-policy1": "cgu-policy1-kpqz2"}
+
+	testcases := []struct {
+		name              string
+		writtenVersion    bool
+		expectedSafeNames map[string]string
+	}{
+		{
+			name: "no status written",
+		},
+		{
+			name:              "status written and cache stale",
+			writtenVersion:    true,
+			expectedSafeNames: latestCgu.Status.SafeResourceNames,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			cacheClient, err := getFakeClientFromObjects(staleCgu.DeepCopy())
+			if err != nil {
+				t.Errorf("error in creating fake client")
+			}
+			apiClient, err := getFakeClientFromObjects(latestCgu.DeepCopy())
+			if err != nil {
+				t.Errorf("error in creating fake client")
+			}
+			r := &ClusterGroupUpgradeReconciler{
+				Client: cacheClient, APIReader: apiClient, Log: logr.Discard(), Scheme: testscheme}
+			if tc.writtenVersion {
+				r.writtenVersions.Store(key, "1000")
+			}
+
+			cgu := &ranv1alpha1.ClusterGroupUpgrade{}
+			assert.NoError(t, r.getClusterGroupUpgrade(context.TODO(), key, cgu))
+			assert.Equal(t, tc.expectedSafeNames, cgu.Status.SafeResourceNames)
+			_, ok := r.writtenVersions.Load(key)
+			assert.False(t, ok)
+		})
+	}
+}
@@ -36,6 +36,23 @@ spec:
         namespace: openshift-talo-backup
 `
 
+// MngClusterActCreateBackupClusterRole creates the clusterrole bound to the backup serviceaccount
+// unless the job settings bind cluster-admin
+const MngClusterActCreateBackupClusterRole string = `
+{{ template "actionGVK"}}
+{{ template "metadata" . }}
+spec:
+  actionType: Create
+  kube:
+    resource: clusterrole
+    template:
+      apiVersion: rbac.authorization.k8s.io/v1
+      kind: ClusterRole
+      metadata:
+        name: backup-agent
+      rules: {{ toJSON .Job.ClusterRoleRules }}
+`
+
 // MngClusterActCreateRB creates clusterrolebinding
 const MngClusterActCreateRB string = `
 {{ template "actionGVK"}}
@@ -52,7 +69,7 @@ spec:
       roleRef:
         apiGroup: rbac.authorization.k8s.io
         kind: ClusterRole
-        name: cluster-admin
+        name: {{ if .Job.ClusterAdmin }}cluster-admin{{ else }}backup-agent{{ end }}
       subjects:
         - kind: ServiceAccount
           name: backup-agent
@@ -92,8 +109,14 @@ spec:
               -
                 args:
                   - launchBackup
+                {{- with .Job.Env }}
+                env: {{ toJSON . }}
+                {{- end }}
                 image: {{ .WorkloadImage }} 
                 name: container-image
+                {{- with .Job.Resources }}
+                resources: {{ toJSON . }}
+                {{- end }}
                 securityContext:
                   privileged: true
                   runAsUser: 0
@@ -105,6 +128,15 @@ spec:
             restartPolicy: Never
             hostNetwork: true
             serviceAccountName: backup-agent
+            {{- with .Job.PriorityClassName }}
+            priorityClassName: {{ . }}
+            {{- end }}
+            {{- with .Job.NodeSelector }}
+            nodeSelector: {{ toJSON . }}
+            {{- end }}
+            {{- with .Job.Tolerations }}
+            tolerations: {{ toJSON . }}
+            {{- end }}
             volumes:
               -
                 hostPath:
@@ -124,6 +156,28 @@ spec:
     resource: namespace
 `
 
+// MngClusterActDeleteBackupRB deletes clusterrolebinding
+const MngClusterActDeleteBackupRB string = `
+{{ template "actionGVK"}}
+{{ template "metadata" . }}
+spec:
+  actionType: Delete
+  kube:
+    name: backup-agent
+    resource: clusterrolebinding
+`
+
+// MngClusterActDeleteBackupClusterRole deletes clusterrole
+const MngClusterActDeleteBackupClusterRole string = `
+{{ template "actionGVK"}}
+{{ template "metadata" . }}
+spec:
+  actionType: Delete
+  kube:
+    name: backup-agent
+    resource: clusterrole
+`
+
 // MngClusterViewBackupJob creates mcv to monitor k8s job
 const MngClusterViewBackupJob string = `
 {{ template "viewGVK"}}
@@ -49,11 +49,18 @@ spec:
     template:
       apiVersion: v1
       data:
+        additional.images: |{{ range .AdditionalImages }}
+          {{ . }} {{ end }}
         operators.indexes: |{{ range .Operators.Indexes }}
           {{ . }} {{ end }}
         operators.packagesAndChannels: |{{ range .Operators.PackagesAndChannels }} 
           {{ . }} {{ end }}
+        operators.installedCSVs: |{{ range .Operators.InstalledCSVs }}
+          {{ . }} {{ end }}
+        exclude.patterns: |{{ range .ExcludePatterns }}
+          {{ . }} {{ end }}
         platform.image: {{ .PlatformImage }}
+        space.required: "{{ .SpaceRequired }}"
       kind: ConfigMap
       metadata:
         name: pre-cache-spec
@@ -76,6 +83,75 @@ spec:
         namespace: openshift-talo-pre-cache
 `
 
+// MngClusterActCreateRole creates the role allowing the precaching serviceaccount to publish
+// the pre-cache-summary configmap
+const MngClusterActCreateRole string = `
+{{ template "actionGVK"}}
+{{ template "metadata" . }}
+spec:
+  actionType: Create
+  kube:
+    resource: role
+    namespace: openshift-talo-pre-cache
+    template:
+      apiVersion: rbac.authorization.k8s.io/v1
+      kind: Role
+      metadata:
+        name: pre-cache-agent
+        namespace: openshift-talo-pre-cache
+      rules:
+        - apiGroups:
+            - ""
+          resources:
+            - configmaps
+          verbs:
+            - get
+            - create
+            - update
+`
+
+// MngClusterActCreateRoleBinding creates rolebinding
+const MngClusterActCreateRoleBinding string = `
+{{ template "actionGVK"}}
+{{ template "metadata" . }}
+spec:
+  actionType: Create
+  kube:
+    resource: rolebinding
+    namespace: openshift-talo-pre-cache
+    template:
+      apiVersion: rbac.authorization.k8s.io/v1
+      kind: RoleBinding
+      metadata:
+        name: pre-cache-agent
+        namespace: openshift-talo-pre-cache
+      roleRef:
+        apiGroup: rbac.authorization.k8s.io
+        kind: Role
+        name: pre-cache-agent
+      subjects:
+        - kind: ServiceAccount
+          name: pre-cache-agent
+          namespace: openshift-talo-pre-cache
+`
+
+// MngClusterActCreateClusterRole creates the clusterrole bound to the precaching serviceaccount
+// unless the job settings bind cluster-admin
+const MngClusterActCreateClusterRole string = `
+{{ template "actionGVK"}}
+{{ template "metadata" . }}
+spec:
+  actionType: Create
+  kube:
+    resource: clusterrole
+    template:
+      apiVersion: rbac.authorization.k8s.io/v1
+      kind: ClusterRole
+      metadata:
+        name: pre-cache-agent
+      rules: {{ toJSON .Job.ClusterRoleRules }}
+`
+
 // MngClusterActCreateClusterRoleBinding creates clusterrolebinding
 const MngClusterActCreateClusterRoleBinding string = `
 {{ template "actionGVK"}}
@@ -92,7 +168,7 @@ spec:
       roleRef:
         apiGroup: rbac.authorization.k8s.io
         kind: ClusterRole
-        name: cluster-admin
+        name: {{ if .Job.ClusterAdmin }}cluster-admin{{ else }}pre-cache-agent{{ end }}
       subjects:
         - kind: ServiceAccount
           name: pre-cache-agent
@@ -134,9 +210,16 @@ spec:
               env:
               - name: config_volume_path
                 value: /etc/config
+              {{- if .PullRateLimit }}
+              - name: PULL_RATE_LIMIT
+                value: "{{ .PullRateLimit }}"
+              {{- end }}
+              {{- range .Job.Env }}
+              - {{ toJSON . }}
+              {{- end }}
               image: {{ .WorkloadImage }}
               name: pre-cache-container
-              resources: {}
+              resources: {{ with .Job.Resources }}{{ toJSON . }}{{ else }}{}{{ end }}
               securityContext:
                 privileged: true
                 runAsUser: 0
@@ -153,7 +236,13 @@ spec:
             schedulerName: default-scheduler
             securityContext: {}
             serviceAccountName: pre-cache-agent
-            priorityClassName: system-cluster-critical
+            priorityClassName: {{ or .Job.PriorityClassName "system-cluster-critical" }}
+            {{- with .Job.NodeSelector }}
+            nodeSelector: {{ toJSON . }}
+            {{- end }}
+            {{- with .Job.Tolerations }}
+            tolerations: {{ toJSON . }}
+            {{- end }}
             volumes:
             - configMap:
                 defaultMode: 420
@@ -190,6 +279,18 @@ spec:
     updateIntervalSeconds: {{ .ViewUpdateIntervalSec }}
 `
 
+// MngClusterViewSummaryConfigMap creates mcv to monitor the summary configmap published by the precaching job
+const MngClusterViewSummaryConfigMap string = `
+{{ template "viewGVK"}}
+{{ template "metadata" . }}
+spec:
+  scope:
+    resource: configmap
+    name: pre-cache-summary
+    namespace: openshift-talo-pre-cache
+    updateIntervalSeconds: {{ .ViewUpdateIntervalSec }}
+`
+
 // MngClusterViewServiceAcct creates mcv to monitor serviceaccount
 const MngClusterViewServiceAcct string = `
 {{ template "viewGVK"}}
@@ -234,3 +335,25 @@ spec:
     resource: namespace
     name: openshift-talo-pre-cache
 `
+
+// MngClusterActDeleteClusterRoleBinding deletes precaching clusterrolebinding
+const MngClusterActDeleteClusterRoleBinding string = `
+{{ template "actionGVK"}}
+{{ template "metadata" . }}
+spec:
+  actionType: Delete
+  kube:
+    resource: clusterrolebinding
+    name: pre-cache-crb
+`
+
+// MngClusterActDeleteClusterRole deletes precaching clusterrole
+const MngClusterActDeleteClusterRole string = `
+{{ template "actionGVK"}}
+{{ template "metadata" . }}
+spec:
+  actionType: Delete
+  kube:
+    resource: clusterrole
+    name: pre-cache-agent
+`
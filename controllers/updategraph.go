@@ -0,0 +1,254 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+
+	ranv1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/api/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+const (
+	// defaultUpdateGraphUpstream is the upstream of the ClusterVersion policies that don't set one
+	defaultUpdateGraphUpstream = "https://api.openshift.com/api/upgrades_info/v1/graph"
+	defaultUpdateGraphTimeout  = 30 * time.Second
+	defaultUpdateGraphCacheTTL = 10 * time.Minute
+	// updateGraphCABundleKey is the key of the CA certificates in the CA bundle ConfigMap
+	updateGraphCABundleKey = "ca-bundle.crt"
+	// maxUpdateGraphSize bounds the size of an update graph response
+	maxUpdateGraphSize = 32 << 20
+)
+
+// updateGraph is the part of an OCP update graph read by the operator
+type updateGraph struct {
+	Nodes []updateGraphNode `json:"nodes"`
+}
+
+// updateGraphNode is a release of an update graph
+type updateGraphNode struct {
+	Version string `json:"version"`
+	Payload string `json:"payload"`
+}
+
+// updateGraphCacheEntry is an update graph requested from an upstream, reused until it expires
+type updateGraphCacheEntry struct {
+	graph   *updateGraph
+	expires time.Time
+}
+
+// malformedUpdateGraphError is an update graph that can't be decoded
+type malformedUpdateGraphError struct {
+	source string
+	reason string
+}
+
+func (e *malformedUpdateGraphError) Error() string {
+	return fmt.Sprintf("malformed update graph from %s: %s", e.source, e.reason)
+}
+
+// versionNotFoundError is a version missing from an update graph
+type versionNotFoundError struct {
+	source  string
+	version string
+}
+
+func (e *versionNotFoundError) Error() string {
+	return fmt.Sprintf("unable to find version %s on update graph from %s", e.version, e.source)
+}
+
+// getUpdateGraphSettings returns the update graph settings of the operator configuration
+func (r *ClusterGroupUpgradeReconciler) getUpdateGraphSettings() ranv1alpha1.UpdateGraphSettings {
+	r.operatorConfigLock.RLock()
+	defer r.operatorConfigLock.RUnlock()
+	if r.operatorConfig.UpdateGraph == nil {
+		return ranv1alpha1.UpdateGraphSettings{}
+	}
+	return *r.operatorConfig.UpdateGraph.DeepCopy()
+}
+
+/* getImageForVersionFromUpdateGraph gets the release image of a version from the update graph of a channel.
+   The graph is read from the offline ConfigMap of the operator configuration when set, requested from the
+   upstream otherwise, and reused for the cache TTL.
+
+   returns: string the release image
+            error, a *malformedUpdateGraphError or *versionNotFoundError if the graph has no image for the version
+*/
+func (r *ClusterGroupUpgradeReconciler) getImageForVersionFromUpdateGraph(
+	ctx context.Context, upstream string, channel string, version string) (string, error) {
+
+	settings := r.getUpdateGraphSettings()
+	var (
+		graph  *updateGraph
+		source string
+		err    error
+	)
+	if settings.Offline != nil {
+		source = fmt.Sprintf("ConfigMap %s/%s", settings.Offline.Namespace, settings.Offline.Name)
+		graph, err = r.readOfflineUpdateGraph(ctx, settings.Offline, channel)
+	} else {
+		if upstream == "" {
+			upstream = defaultUpdateGraphUpstream
+		}
+		source = upstream + "?" + url.Values{"channel": []string{channel}}.Encode()
+		graph, err = r.requestUpdateGraph(ctx, &settings, source)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	for _, node := range graph.Nodes {
+		if node.Version != version {
+			continue
+		}
+		if node.Payload == "" {
+			return "", &malformedUpdateGraphError{source, fmt.Sprintf("no payload for version %s", version)}
+		}
+		return node.Payload, nil
+	}
+	return "", &versionNotFoundError{source, version}
+}
+
+// decodeUpdateGraph decodes an update graph
+// returns: *updateGraph, *malformedUpdateGraphError
+func decodeUpdateGraph(source string, data []byte) (*updateGraph, error) {
+	graph := &updateGraph{}
+	if err := json.Unmarshal(data, graph); err != nil {
+		return nil, &malformedUpdateGraphError{source, err.Error()}
+	}
+	if graph.Nodes == nil {
+		return nil, &malformedUpdateGraphError{source, "no nodes"}
+	}
+	return graph, nil
+}
+
+// readOfflineUpdateGraph reads the update graph of a channel from the offline ConfigMap
+// returns: *updateGraph, error
+func (r *ClusterGroupUpgradeReconciler) readOfflineUpdateGraph(
+	ctx context.Context, ref *ranv1alpha1.ConfigMapReference, channel string) (*updateGraph, error) {
+
+	configMap := &corev1.ConfigMap{}
+	err := r.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: ref.Namespace}, configMap)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the offline update graph ConfigMap %s/%s: %w",
+			ref.Namespace, ref.Name, err)
+	}
+	data, ok := configMap.Data[channel]
+	if !ok {
+		return nil, fmt.Errorf("no update graph for channel %s in ConfigMap %s/%s", channel, ref.Namespace, ref.Name)
+	}
+	return decodeUpdateGraph(fmt.Sprintf("ConfigMap %s/%s", ref.Namespace, ref.Name), []byte(data))
+}
+
+// requestUpdateGraph requests an update graph, or returns the cached one if it has not expired
+// returns: *updateGraph, error
+func (r *ClusterGroupUpgradeReconciler) requestUpdateGraph(
+	ctx context.Context, settings *ranv1alpha1.UpdateGraphSettings, graphURL string) (*updateGraph, error) {
+
+	if cached, ok := r.updateGraphCache.Load(graphURL); ok {
+		entry := cached.(updateGraphCacheEntry)
+		if time.Now().Before(entry.expires) {
+			return entry.graph, nil
+		}
+	}
+
+	httpClient, err := r.newUpdateGraphHTTPClient(ctx, settings)
+	if err != nil {
+		return nil, err
+	}
+	defer httpClient.CloseIdleConnections()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, graphURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("invalid update graph url %s: %w", graphURL, err)
+	}
+	req.Header.Add("Accept", "application/json")
+	res, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("unable to request update graph on url %s: %w", graphURL, err)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unable to request update graph on url %s: %s", graphURL, res.Status)
+	}
+	body, err := ioutil.ReadAll(io.LimitReader(res.Body, maxUpdateGraphSize))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read body from response: %w", err)
+	}
+	graph, err := decodeUpdateGraph(graphURL, body)
+	if err != nil {
+		return nil, err
+	}
+
+	ttl := defaultUpdateGraphCacheTTL
+	if settings.CacheTTL != nil {
+		ttl = settings.CacheTTL.Duration
+	}
+	r.updateGraphCache.Store(graphURL, updateGraphCacheEntry{graph: graph, expires: time.Now().Add(ttl)})
+	return graph, nil
+}
+
+// newUpdateGraphHTTPClient returns an HTTP client with the CA bundle, proxy and timeout of the settings
+// returns: *http.Client, error
+func (r *ClusterGroupUpgradeReconciler) newUpdateGraphHTTPClient(
+	ctx context.Context, settings *ranv1alpha1.UpdateGraphSettings) (*http.Client, error) {
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = http.ProxyFromEnvironment
+	if settings.Proxy != "" {
+		proxyURL, err := url.Parse(settings.Proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid update graph proxy %s: %w", settings.Proxy, err)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+
+	if settings.CABundle != nil {
+		configMap := &corev1.ConfigMap{}
+		err := r.Get(ctx, types.NamespacedName{Name: settings.CABundle.Name, Namespace: settings.CABundle.Namespace},
+			configMap)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read the update graph CA bundle ConfigMap %s/%s: %w",
+				settings.CABundle.Namespace, settings.CABundle.Name, err)
+		}
+		// The CA bundle is trusted in addition to the system CA certificates
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM([]byte(configMap.Data[updateGraphCABundleKey])) {
+			return nil, fmt.Errorf("no CA certificate found under %s in ConfigMap %s/%s", updateGraphCABundleKey,
+				settings.CABundle.Namespace, settings.CABundle.Name)
+		}
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	}
+
+	timeout := defaultUpdateGraphTimeout
+	if settings.Timeout != nil && settings.Timeout.Duration > 0 {
+		timeout = settings.Timeout.Duration
+	}
+	return &http.Client{Transport: transport, Timeout: timeout}, nil
+}
@@ -0,0 +1,159 @@
+package controllers
+
+import (
+	"context"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	ranv1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const testUpdateGraph = `{"nodes": [
+	{"version": "4.10.20", "payload": "quay.io/openshift-release-dev/ocp-release@sha256:1020"},
+	{"version": "4.10.21", "payload": "quay.io/openshift-release-dev/ocp-release@sha256:1021"}
+], "edges": [[0, 1]]}`
+
+func getUpdateGraphTestReconciler(t *testing.T, settings *ranv1alpha1.UpdateGraphSettings,
+	objs ...client.Object) *ClusterGroupUpgradeReconciler {
+
+	fakeClient, err := getFakeClientFromObjects(objs...)
+	if err != nil {
+		t.Errorf("error in creating fake client")
+	}
+	r := &ClusterGroupUpgradeReconciler{Client: fakeClient, Log: logr.Discard(), Scheme: testscheme}
+	r.operatorConfig.UpdateGraph = settings
+	return r
+}
+
+func TestUpdateGraph_request(t *testing.T) {
+	var requests int32
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		assert.Equal(t, "application/json", req.Header.Get("Accept"))
+		fmt.Fprint(w, testUpdateGraph)
+	}))
+	defer server.Close()
+	caBundle := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "update-graph-ca", Namespace: "openshift-cluster-group-upgrades"},
+		Data: map[string]string{
+			updateGraphCABundleKey: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})),
+		},
+	}
+
+	// The upstream is not trusted without the CA bundle
+	r := getUpdateGraphTestReconciler(t, nil, caBundle)
+	_, err := r.getImageForVersionFromUpdateGraph(context.TODO(), server.URL, "stable-4.10", "4.10.20")
+	assert.Error(t, err)
+
+	r = getUpdateGraphTestReconciler(t, &ranv1alpha1.UpdateGraphSettings{
+		CABundle: &ranv1alpha1.ConfigMapReference{Name: caBundle.Name, Namespace: caBundle.Namespace},
+		Timeout:  &metav1.Duration{Duration: 5 * time.Second},
+	}, caBundle)
+	atomic.StoreInt32(&requests, 0)
+	image, err := r.getImageForVersionFromUpdateGraph(context.TODO(), server.URL, "stable-4.10", "4.10.20")
+	assert.NoError(t, err)
+	assert.Equal(t, "quay.io/openshift-release-dev/ocp-release@sha256:1020", image)
+
+	// The graph of the upstream and channel is cached
+	image, err = r.getImageForVersionFromUpdateGraph(context.TODO(), server.URL, "stable-4.10", "4.10.21")
+	assert.NoError(t, err)
+	assert.Equal(t, "quay.io/openshift-release-dev/ocp-release@sha256:1021", image)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+	_, err = r.getImageForVersionFromUpdateGraph(context.TODO(), server.URL, "fast-4.10", "4.10.21")
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+
+	_, err = r.getImageForVersionFromUpdateGraph(context.TODO(), server.URL, "stable-4.10", "4.11.0")
+	assert.IsType(t, &versionNotFoundError{}, err)
+
+	// The cache is disabled with a TTL of 0
+	r.operatorConfig.UpdateGraph.CacheTTL = &metav1.Duration{}
+	r.updateGraphCache.Delete(server.URL + "?channel=stable-4.10")
+	for i := 0; i < 2; i++ {
+		_, err = r.getImageForVersionFromUpdateGraph(context.TODO(), server.URL, "stable-4.10", "4.10.20")
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, int32(4), atomic.LoadInt32(&requests))
+}
+
+func TestUpdateGraph_malformed(t *testing.T) {
+	testcases := []struct {
+		name      string
+		status    int
+		body      string
+		malformed bool
+	}{
+		{name: "not json", body: "<html></html>", malformed: true},
+		{name: "no nodes", body: `{"edges": []}`, malformed: true},
+		{name: "unexpected nodes", body: `{"nodes": {"version": "4.10.20"}}`, malformed: true},
+		{name: "unexpected version", body: `{"nodes": [{"version": 4.10}]}`, malformed: true},
+		{name: "no payload", body: `{"nodes": [{"version": "4.10.20"}]}`, malformed: true},
+		{name: "server error", status: http.StatusServiceUnavailable, body: `{"nodes": []}`},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+				if tc.status != 0 {
+					w.WriteHeader(tc.status)
+				}
+				fmt.Fprint(w, tc.body)
+			}))
+			defer server.Close()
+
+			r := getUpdateGraphTestReconciler(t, nil)
+			_, err := r.getImageForVersionFromUpdateGraph(context.TODO(), server.URL, "stable-4.10", "4.10.20")
+			assert.Error(t, err)
+			_, malformed := err.(*malformedUpdateGraphError)
+			assert.Equal(t, tc.malformed, malformed)
+		})
+	}
+}
+
+func TestUpdateGraph_proxy(t *testing.T) {
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		if req.URL.Host != "update-graph.example.com" {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		fmt.Fprint(w, testUpdateGraph)
+	}))
+	defer proxy.Close()
+
+	r := getUpdateGraphTestReconciler(t, &ranv1alpha1.UpdateGraphSettings{Proxy: proxy.URL})
+	image, err := r.getImageForVersionFromUpdateGraph(context.TODO(), "http://update-graph.example.com/graph",
+		"stable-4.10", "4.10.20")
+	assert.NoError(t, err)
+	assert.Equal(t, "quay.io/openshift-release-dev/ocp-release@sha256:1020", image)
+}
+
+func TestUpdateGraph_offline(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		t.Error("the upstream must not be requested in offline mode")
+	}))
+	defer server.Close()
+	graphs := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "update-graphs", Namespace: "openshift-cluster-group-upgrades"},
+		Data:       map[string]string{"stable-4.10": testUpdateGraph, "fast-4.10": "{}"},
+	}
+	r := getUpdateGraphTestReconciler(t, &ranv1alpha1.UpdateGraphSettings{
+		Offline: &ranv1alpha1.ConfigMapReference{Name: graphs.Name, Namespace: graphs.Namespace},
+	}, graphs)
+
+	image, err := r.getImageForVersionFromUpdateGraph(context.TODO(), server.URL, "stable-4.10", "4.10.21")
+	assert.NoError(t, err)
+	assert.Equal(t, "quay.io/openshift-release-dev/ocp-release@sha256:1021", image)
+	_, err = r.getImageForVersionFromUpdateGraph(context.TODO(), server.URL, "fast-4.10", "4.10.21")
+	assert.IsType(t, &malformedUpdateGraphError{}, err)
+	_, err = r.getImageForVersionFromUpdateGraph(context.TODO(), server.URL, "candidate-4.10", "4.10.21")
+	assert.Error(t, err)
+}
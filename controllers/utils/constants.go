@@ -38,6 +38,7 @@ const (
 	CsvNamePrefix              = "cluster-group-upgrades-operator"
 	KubeconfigSecretSuffix     = "admin-kubeconfig"
 	OperatorConfigOverrides    = "cluster-group-upgrade-overrides"
+	OperatorConfigName         = "cluster"
 	PrecacheJobNamespace       = "openshift-talo-pre-cache"
 	PrecacheJobName            = "pre-cache"
 	PrecacheServiceAccountName = "pre-cache-agent"
@@ -100,6 +101,25 @@ const (
 	CannotStart = "UpgradeCannotStart"
 )
 
+// ClusterLockAnnotation is set on a ManagedCluster to the namespace/name of the CGU remediating it
+const ClusterLockAnnotation = CsvNamePrefix + "/locked-by"
+
+// PrecacheRetryAnnotation is set on a CGU to retry the pre-caching of its failed clusters, each time it gets
+// a new value
+const PrecacheRetryAnnotation = "ran.openshift.io/precache-retry"
+
+// CguOwnerAnnotation is set on the ManagedClusterViews and ManagedClusterActions created for a CGU to its
+// namespace/name, so that their updates can be mapped back to the CGU
+const CguOwnerAnnotation = CsvNamePrefix + "/owned-by"
+
+// Fleet-wide capacity conditions, one per kind of work, and their reason
+const (
+	RemediationCapacityAvailableCondition = "RemediationCapacityAvailable"
+	PrecachingCapacityAvailableCondition  = "PrecachingCapacityAvailable"
+	BackupCapacityAvailableCondition      = "BackupCapacityAvailable"
+	WaitingForCapacity                    = "WaitingForCapacity"
+)
+
 // ExcludeFromClusterBackup is a label to exclude object from cluster-backup-operator
 // https://github.com/stolostron/cluster-backup-operator#steps-to-identify-backup-data
 const ExcludeFromClusterBackup = "velero.io/exclude-from-backup"
@@ -0,0 +1,102 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package utils
+
+import (
+	"context"
+
+	policiesv1 "github.com/open-cluster-management/governance-policy-propagator/api/v1"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Field indexes of the manager cache
+const (
+	// ChildPolicyRootIndex indexes the child policies by the name of their root policy. The namespace of the
+	// root policy is the prefix of the child policy name.
+	ChildPolicyRootIndex = "childPolicy.rootPolicyName"
+	// ChildPolicyClusterIndex indexes the child policies by the cluster they are propagated to
+	ChildPolicyClusterIndex = "childPolicy.cluster"
+	// ManagedClusterLabelIndex indexes the ManagedClusters by each of their labels, as key=value
+	ManagedClusterLabelIndex = "managedCluster.label"
+)
+
+// FieldIndexer describes a field index of the manager cache
+type FieldIndexer struct {
+	Object  client.Object
+	Field   string
+	Extract client.IndexerFunc
+}
+
+// FieldIndexers are the field indexes the operator lists objects with
+var FieldIndexers = []FieldIndexer{
+	{Object: &policiesv1.Policy{}, Field: ChildPolicyRootIndex, Extract: childPolicyRootIndexer},
+	{Object: &policiesv1.Policy{}, Field: ChildPolicyClusterIndex, Extract: childPolicyClusterIndexer},
+	{Object: &clusterv1.ManagedCluster{}, Field: ManagedClusterLabelIndex, Extract: managedClusterLabelIndexer},
+}
+
+// SetupFieldIndexers registers the FieldIndexers on the manager cache
+func SetupFieldIndexers(ctx context.Context, indexer client.FieldIndexer) error {
+	for _, fieldIndexer := range FieldIndexers {
+		if err := indexer.IndexField(ctx, fieldIndexer.Object, fieldIndexer.Field, fieldIndexer.Extract); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// IsUserChildPolicy returns true for the child policies of the user policies, not the ones of the
+// policies copied by a CGU. Only these are indexed.
+func IsUserChildPolicy(obj client.Object) bool {
+	labels := obj.GetLabels()
+	if _, ok := labels["openshift-cluster-group-upgrades/clusterGroupUpgrade"]; ok {
+		return false
+	}
+	_, ok := labels[ChildPolicyLabel]
+	return ok
+}
+
+func childPolicyRootIndexer(obj client.Object) []string {
+	if !IsUserChildPolicy(obj) {
+		return nil
+	}
+	policyNameArr := GetParentPolicyNameAndNamespace(obj.GetName())
+	if len(policyNameArr) != 2 {
+		return nil
+	}
+	return []string{policyNameArr[1]}
+}
+
+func childPolicyClusterIndexer(obj client.Object) []string {
+	if !IsUserChildPolicy(obj) {
+		return nil
+	}
+	return []string{obj.GetNamespace()}
+}
+
+func managedClusterLabelIndexer(obj client.Object) []string {
+	var values []string
+	for key, value := range obj.GetLabels() {
+		values = append(values, LabelIndexValue(key, value))
+	}
+	return values
+}
+
+// LabelIndexValue returns the value of the ManagedClusterLabelIndex for a label
+func LabelIndexValue(key, value string) string {
+	return key + "=" + value
+}
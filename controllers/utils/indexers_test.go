@@ -0,0 +1,56 @@
+package utils
+
+import (
+	"testing"
+
+	policiesv1 "github.com/open-cluster-management/governance-policy-propagator/api/v1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+)
+
+func TestIndexers_childPolicyIndexers(t *testing.T) {
+	testcases := []struct {
+		name            string
+		policyName      string
+		labels          map[string]string
+		expectedRoot    []string
+		expectedCluster []string
+	}{
+		{
+			name:            "child policy",
+			policyName:      "policies.policy1",
+			labels:          map[string]string{ChildPolicyLabel: "policies.policy1"},
+			expectedRoot:    []string{"policy1"},
+			expectedCluster: []string{"spoke1"},
+		},
+		{
+			name:       "child policy of a copied policy",
+			policyName: "default.cgu-policy1",
+			labels: map[string]string{
+				ChildPolicyLabel: "default.cgu-policy1",
+				"openshift-cluster-group-upgrades/clusterGroupUpgrade": "cgu",
+			},
+		},
+		{
+			name:       "root policy",
+			policyName: "policy1",
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			policy := &policiesv1.Policy{
+				ObjectMeta: metav1.ObjectMeta{Name: tc.policyName, Namespace: "spoke1", Labels: tc.labels},
+			}
+			assert.Equal(t, tc.expectedRoot, childPolicyRootIndexer(policy))
+			assert.Equal(t, tc.expectedCluster, childPolicyClusterIndexer(policy))
+		})
+	}
+}
+
+func TestIndexers_managedClusterLabelIndexer(t *testing.T) {
+	cluster := &clusterv1.ManagedCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "spoke1", Labels: map[string]string{"upgrade": "true", "sno": ""}},
+	}
+	assert.ElementsMatch(t, []string{"upgrade=true", "sno="}, managedClusterLabelIndexer(cluster))
+}
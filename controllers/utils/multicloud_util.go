@@ -100,7 +100,8 @@ var EnsureInstallPlanIsApproved = func(
 	safeName := GetSafeResourceName(mcvForInstallPlanName, clusterGroupUpgrade, MaxObjectNameLength, 0)
 	mcvForInstallPlan, err := EnsureManagedClusterView(
 		ctx, c, safeName, mcvForInstallPlanName, clusterName, "InstallPlan", subscription.Status.InstallPlanRef.Name,
-		subscription.Status.InstallPlanRef.Namespace, clusterGroupUpgrade.Namespace+"-"+clusterGroupUpgrade.Name)
+		subscription.Status.InstallPlanRef.Namespace,
+		types.NamespacedName{Name: clusterGroupUpgrade.Name, Namespace: clusterGroupUpgrade.Namespace})
 	if err != nil {
 		return InstallPlanCannotBeApproved, err
 	}
@@ -144,7 +145,8 @@ var EnsureInstallPlanIsApproved = func(
 		// Create or update the managedClusterAction to approve the install plan.
 		mcaName := GetMultiCloudObjectName(clusterGroupUpgrade, "InstallPlan", installPlan.Name)
 		safeName := GetSafeResourceName(mcaName, clusterGroupUpgrade, MaxObjectNameLength, 0)
-		_, err := EnsureManagedClusterActionForInstallPlan(ctx, c, safeName, mcaName, clusterName, installPlan)
+		_, err := EnsureManagedClusterActionForInstallPlan(ctx, c, safeName, mcaName, clusterName, installPlan,
+			types.NamespacedName{Name: clusterGroupUpgrade.Name, Namespace: clusterGroupUpgrade.Namespace})
 		if err != nil {
 			return InstallPlanCannotBeApproved, err
 		}
@@ -155,11 +157,12 @@ var EnsureInstallPlanIsApproved = func(
 	return InstallPlanCannotBeApproved, nil
 }
 
-// EnsureManagedClusterView creates or updates a view.
+// EnsureManagedClusterView creates or updates a view for the CGU given as owner.
 func EnsureManagedClusterView(
 	ctx context.Context, c client.Client, safeName, name, namespace, resourceType,
-	resourceName, resourceNamespace, cguLabel string) (*viewv1beta1.ManagedClusterView, error) {
+	resourceName, resourceNamespace string, owner types.NamespacedName) (*viewv1beta1.ManagedClusterView, error) {
 
+	cguLabel := owner.Namespace + "-" + owner.Name
 	mcv := &viewv1beta1.ManagedClusterView{}
 	err := c.Get(ctx, types.NamespacedName{Name: safeName, Namespace: namespace}, mcv)
 
@@ -175,6 +178,7 @@ func EnsureManagedClusterView(
 				},
 				Annotations: map[string]string{
 					DesiredResourceName: name,
+					CguOwnerAnnotation:  owner.String(),
 				},
 			}
 			viewSpec := viewv1beta1.ViewSpec{
@@ -205,6 +209,12 @@ func EnsureManagedClusterView(
 		}
 		labels["openshift-cluster-group-upgrades/clusterGroupUpgrade"] = cguLabel
 		mcv.SetLabels(labels)
+		annotations := mcv.GetAnnotations()
+		if annotations == nil {
+			annotations = make(map[string]string)
+		}
+		annotations[CguOwnerAnnotation] = owner.String()
+		mcv.SetAnnotations(annotations)
 
 		viewSpec := viewv1beta1.ViewSpec{
 			Scope: viewv1beta1.ViewScope{
@@ -222,10 +232,11 @@ func EnsureManagedClusterView(
 	return mcv, nil
 }
 
-// EnsureManagedClusterActionForInstallPlan creates or updates an action for an InstallPlan.
+// EnsureManagedClusterActionForInstallPlan creates or updates an action for an InstallPlan on behalf of the CGU
+// given as owner.
 func EnsureManagedClusterActionForInstallPlan(
 	ctx context.Context, c client.Client, safeName, name, namespace string,
-	installPlan operatorsv1alpha1.InstallPlan) (*actionv1beta1.ManagedClusterAction, error) {
+	installPlan operatorsv1alpha1.InstallPlan, owner types.NamespacedName) (*actionv1beta1.ManagedClusterAction, error) {
 
 	mcaForInstallPlan := &actionv1beta1.ManagedClusterAction{}
 	if err := c.Get(ctx, types.NamespacedName{Name: safeName, Namespace: namespace}, mcaForInstallPlan); err != nil {
@@ -238,6 +249,7 @@ func EnsureManagedClusterActionForInstallPlan(
 				Namespace: namespace,
 				Annotations: map[string]string{
 					DesiredResourceName: name,
+					CguOwnerAnnotation:  owner.String(),
 				},
 			}
 			actionSpec, err := NewManagedClusterActionForInstallPlanSpec(installPlan)
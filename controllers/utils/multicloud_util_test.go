@@ -129,13 +129,15 @@ func TestEnsureManagedClusterActionForInstallPlan(t *testing.T) {
 				},
 			},
 			validateFunc: func(t *testing.T, runtimeClient client.Client, safeMcaName, mcaName, mcaNamespace string, installPlan operatorsv1alpha1.InstallPlan) {
-				mca, err := EnsureManagedClusterActionForInstallPlan(context.TODO(), runtimeClient, safeMcaName, mcaName, mcaNamespace, installPlan)
+				mca, err := EnsureManagedClusterActionForInstallPlan(context.TODO(), runtimeClient, safeMcaName, mcaName, mcaNamespace, installPlan,
+					types.NamespacedName{Name: "cgu", Namespace: "default"})
 				if err != nil {
 					t.Errorf("Error occurred and it wasn't expected")
 				}
 				assert.Equal(t, mca.ObjectMeta.Name, safeMcaName)
 				assert.Equal(t, mca.ObjectMeta.Namespace, mcaNamespace)
 				assert.Equal(t, mca.ObjectMeta.Annotations[DesiredResourceName], mcaName)
+				assert.Equal(t, "default/cgu", mca.ObjectMeta.Annotations[CguOwnerAnnotation])
 				assert.Equal(t, mca.Spec.ActionType, actionv1beta1.UpdateActionType)
 				assert.Equal(t, mca.Spec.KubeWork.Resource, "installplan")
 				assert.Equal(t, mca.Spec.KubeWork.Namespace, "installPlan-abcd-namespace")
@@ -169,7 +171,8 @@ func TestEnsureManagedClusterActionForInstallPlan(t *testing.T) {
 				},
 			},
 			validateFunc: func(t *testing.T, runtimeClient client.Client, safeMcaName, mcaName, mcaNamespace string, installPlan operatorsv1alpha1.InstallPlan) {
-				mca, err := EnsureManagedClusterActionForInstallPlan(context.TODO(), runtimeClient, safeMcaName, mcaName, mcaNamespace, installPlan)
+				mca, err := EnsureManagedClusterActionForInstallPlan(context.TODO(), runtimeClient, safeMcaName, mcaName, mcaNamespace, installPlan,
+					types.NamespacedName{Name: "cgu", Namespace: "default"})
 				if err != nil {
 					t.Errorf("Error occurred and it wasn't expected")
 				}
@@ -227,7 +230,7 @@ func TestEnsureManagedClusterView(t *testing.T) {
 			validateFunc: func(t *testing.T, runtimeClient client.Client, safeMcvName, mcvName, mcvNamespace,
 				resourceType, resourceName, resourceNamespace, label string) {
 				mcv, err := EnsureManagedClusterView(context.TODO(), runtimeClient, safeMcvName, mcvName, mcvNamespace,
-					resourceType, resourceName, resourceNamespace, label)
+					resourceType, resourceName, resourceNamespace, types.NamespacedName{Name: "cgu", Namespace: "default"})
 				if err != nil {
 					t.Errorf("Error occurred and it wasn't expected")
 				}
@@ -236,6 +239,7 @@ func TestEnsureManagedClusterView(t *testing.T) {
 				assert.Equal(t, mcv.ObjectMeta.Annotations[DesiredResourceName], mcvName)
 				assert.Equal(t, mcv.ObjectMeta.Labels,
 					map[string]string{"openshift-cluster-group-upgrades/clusterGroupUpgrade": label})
+				assert.Equal(t, "default/cgu", mcv.ObjectMeta.Annotations[CguOwnerAnnotation])
 				assert.Equal(t, mcv.Spec.Scope.Resource, resourceType)
 				assert.Equal(t, mcv.Spec.Scope.Name, resourceName)
 				assert.Equal(t, mcv.Spec.Scope.Namespace, resourceNamespace)
@@ -265,7 +269,7 @@ func TestEnsureManagedClusterView(t *testing.T) {
 			validateFunc: func(t *testing.T, runtimeClient client.Client, safeMcvName, mcvName, mcvNamespace,
 				resourceType, resourceName, resourceNamespace, label string) {
 				mcv, err := EnsureManagedClusterView(context.TODO(), runtimeClient, safeMcvName, mcvName, mcvNamespace,
-					resourceType, resourceName, resourceNamespace, label)
+					resourceType, resourceName, resourceNamespace, types.NamespacedName{Name: "cgu", Namespace: "default"})
 				if err != nil {
 					t.Errorf("Error occurred and it wasn't expected")
 				}
@@ -274,6 +278,7 @@ func TestEnsureManagedClusterView(t *testing.T) {
 				assert.Equal(t, mcv.ObjectMeta.Annotations[DesiredResourceName], mcvName)
 				assert.Equal(t, mcv.ObjectMeta.Labels,
 					map[string]string{"openshift-cluster-group-upgrades/clusterGroupUpgrade": label})
+				assert.Equal(t, "default/cgu", mcv.ObjectMeta.Annotations[CguOwnerAnnotation])
 				assert.Equal(t, mcv.Spec.Scope.Resource, resourceType)
 				assert.Equal(t, mcv.Spec.Scope.Name, resourceName)
 				assert.Equal(t, mcv.Spec.Scope.Namespace, resourceNamespace)
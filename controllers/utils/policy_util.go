@@ -17,21 +17,33 @@ func GetChildPolicies(ctx context.Context, c client.Client, clusters []string) (
 
 	for _, clusterName := range clusters {
 		policies := &policiesv1.PolicyList{}
-		if err := c.List(ctx, policies, client.InNamespace(clusterName)); err != nil {
+		if err := c.List(ctx, policies, client.MatchingFields{ChildPolicyClusterIndex: clusterName}); err != nil {
 			return nil, err
 		}
+		childPolicies = append(childPolicies, policies.Items...)
+	}
+
+	return childPolicies, nil
+}
+
+// GetChildPoliciesOfPolicies gets the child policies of the root policies with the given names, propagated
+// to any cluster of a list of clusters
+func GetChildPoliciesOfPolicies(
+	ctx context.Context, c client.Client, policyNames, clusters []string) ([]policiesv1.Policy, error) {
+
+	var childPolicies []policiesv1.Policy
+	isCluster := make(map[string]bool)
+	for _, clusterName := range clusters {
+		isCluster[clusterName] = true
+	}
 
+	for _, policyName := range policyNames {
+		policies := &policiesv1.PolicyList{}
+		if err := c.List(ctx, policies, client.MatchingFields{ChildPolicyRootIndex: policyName}); err != nil {
+			return nil, err
+		}
 		for _, policy := range policies.Items {
-			labels := policy.GetLabels()
-			if labels == nil {
-				continue
-			}
-			// Skip if it's the child policy of a copied policy.
-			if _, ok := labels["openshift-cluster-group-upgrades/clusterGroupUpgrade"]; ok {
-				continue
-			}
-			// If we can find the child policy specific label, add the child policy name to the list.
-			if _, ok := labels[ChildPolicyLabel]; ok {
+			if isCluster[policy.GetNamespace()] {
 				childPolicies = append(childPolicies, policy)
 			}
 		}
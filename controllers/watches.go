@@ -0,0 +1,193 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"strings"
+
+	actionv1beta1 "github.com/open-cluster-management/multicloud-operators-foundation/pkg/apis/action/v1beta1"
+	viewv1beta1 "github.com/open-cluster-management/multicloud-operators-foundation/pkg/apis/view/v1beta1"
+	ranv1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/api/v1alpha1"
+	utils "github.com/openshift-kni/cluster-group-upgrades-operator/controllers/utils"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+// multiCloudStatusPredicate passes the status updates of the ManagedClusterViews and ManagedClusterActions.
+// The view controller rewrites the views periodically, only a change of the viewed resource is relevant.
+var multiCloudStatusPredicate = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		switch oldObj := e.ObjectOld.(type) {
+		case *viewv1beta1.ManagedClusterView:
+			newObj, ok := e.ObjectNew.(*viewv1beta1.ManagedClusterView)
+			return !ok || !equality.Semantic.DeepEqual(oldObj.Status, newObj.Status)
+		case *actionv1beta1.ManagedClusterAction:
+			newObj, ok := e.ObjectNew.(*actionv1beta1.ManagedClusterAction)
+			return !ok || !equality.Semantic.DeepEqual(oldObj.Status, newObj.Status)
+		}
+		return true
+	},
+	CreateFunc:  func(ce event.CreateEvent) bool { return false },
+	GenericFunc: func(ge event.GenericEvent) bool { return false },
+	DeleteFunc:  func(de event.DeleteEvent) bool { return false },
+}
+
+// blockingCguPredicate passes the CGU events that can unblock the CGUs waiting on them
+var blockingCguPredicate = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldCgu, okOld := e.ObjectOld.(*ranv1alpha1.ClusterGroupUpgrade)
+		newCgu, okNew := e.ObjectNew.(*ranv1alpha1.ClusterGroupUpgrade)
+		if !okOld || !okNew {
+			return false
+		}
+		return isCguSucceeded(oldCgu) != isCguSucceeded(newCgu)
+	},
+	CreateFunc:  func(ce event.CreateEvent) bool { return true },
+	GenericFunc: func(ge event.GenericEvent) bool { return false },
+	DeleteFunc:  func(de event.DeleteEvent) bool { return true },
+}
+
+// clusterLockPredicate passes the ManagedCluster events that can release a cluster lock
+var clusterLockPredicate = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		return e.ObjectOld.GetAnnotations()[utils.ClusterLockAnnotation] !=
+			e.ObjectNew.GetAnnotations()[utils.ClusterLockAnnotation]
+	},
+	CreateFunc:  func(ce event.CreateEvent) bool { return false },
+	GenericFunc: func(ge event.GenericEvent) bool { return false },
+	DeleteFunc:  func(de event.DeleteEvent) bool { return true },
+}
+
+// managedPolicyPredicate passes the creation of the root policies and their status updates
+var managedPolicyPredicate = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		// Generation is only updated on spec changes (also on deletion),
+		// not metadata or status
+		return e.ObjectOld.GetGeneration() == e.ObjectNew.GetGeneration()
+	},
+	CreateFunc:  func(ce event.CreateEvent) bool { return true },
+	GenericFunc: func(ge event.GenericEvent) bool { return false },
+	DeleteFunc:  func(de event.DeleteEvent) bool { return false },
+}
+
+// mapToOwnerCgu maps a ManagedClusterView or ManagedClusterAction to the CGU it was created for
+// returns: []reconcile.Request the owner CGU, empty if the object wasn't created for a CGU
+func mapToOwnerCgu(obj client.Object) []reconcile.Request {
+	owner, ok := obj.GetAnnotations()[utils.CguOwnerAnnotation]
+	if !ok {
+		return nil
+	}
+	ownerNsName := strings.SplitN(owner, "/", 2)
+	if len(ownerNsName) != 2 || ownerNsName[0] == "" || ownerNsName[1] == "" {
+		return nil
+	}
+	return []reconcile.Request{
+		{NamespacedName: types.NamespacedName{Namespace: ownerNsName[0], Name: ownerNsName[1]}},
+	}
+}
+
+// mapManagedPolicyToCgus maps a root policy to the unfinished CGUs managing it. The copied policies
+// are handled through their owner reference and the child policies through their root policy.
+// returns: []reconcile.Request the CGUs listing the policy in spec.managedPolicies
+func (r *ClusterGroupUpgradeReconciler) mapManagedPolicyToCgus(obj client.Object) []reconcile.Request {
+	labels := obj.GetLabels()
+	if _, ok := labels[utils.ChildPolicyLabel]; ok {
+		return nil
+	}
+	if _, ok := labels["openshift-cluster-group-upgrades/clusterGroupUpgrade"]; ok {
+		return nil
+	}
+
+	cguList := &ranv1alpha1.ClusterGroupUpgradeList{}
+	if err := r.List(context.TODO(), cguList); err != nil {
+		r.Log.Error(err, "[mapManagedPolicyToCgus] Failed to list ClusterGroupUpgrades")
+		return nil
+	}
+	var requests []reconcile.Request
+	for i := range cguList.Items {
+		cgu := &cguList.Items[i]
+		if isCguFinished(cgu) {
+			continue
+		}
+		for _, managedPolicy := range cgu.Spec.ManagedPolicies {
+			if managedPolicy != obj.GetName() {
+				continue
+			}
+			// Once validated, the CGU only cares about the policy in the namespace it was found in
+			if namespace, ok := cgu.Status.ManagedPoliciesNs[managedPolicy]; ok && namespace != obj.GetNamespace() {
+				continue
+			}
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: cgu.Namespace, Name: cgu.Name}})
+			break
+		}
+	}
+	return requests
+}
+
+// mapBlockingCguToCgus maps a CGU to the unfinished CGUs listing it in their blockingCRs
+// returns: []reconcile.Request the blocked CGUs
+func (r *ClusterGroupUpgradeReconciler) mapBlockingCguToCgus(obj client.Object) []reconcile.Request {
+	cguList := &ranv1alpha1.ClusterGroupUpgradeList{}
+	if err := r.List(context.TODO(), cguList); err != nil {
+		r.Log.Error(err, "[mapBlockingCguToCgus] Failed to list ClusterGroupUpgrades")
+		return nil
+	}
+	var requests []reconcile.Request
+	for i := range cguList.Items {
+		cgu := &cguList.Items[i]
+		if isCguFinished(cgu) {
+			continue
+		}
+		for _, blockingCR := range cgu.Spec.BlockingCRs {
+			if blockingCR.Name == obj.GetName() && blockingCR.Namespace == obj.GetNamespace() {
+				requests = append(requests, reconcile.Request{
+					NamespacedName: types.NamespacedName{Namespace: cgu.Namespace, Name: cgu.Name}})
+				break
+			}
+		}
+	}
+	return requests
+}
+
+// mapLockedClusterToCgus maps a ManagedCluster to the unfinished CGUs waiting for its lock
+// returns: []reconcile.Request the CGUs with the cluster locked by another CGU in their current batch
+func (r *ClusterGroupUpgradeReconciler) mapLockedClusterToCgus(obj client.Object) []reconcile.Request {
+	cguList := &ranv1alpha1.ClusterGroupUpgradeList{}
+	if err := r.List(context.TODO(), cguList); err != nil {
+		r.Log.Error(err, "[mapLockedClusterToCgus] Failed to list ClusterGroupUpgrades")
+		return nil
+	}
+	var requests []reconcile.Request
+	for i := range cguList.Items {
+		cgu := &cguList.Items[i]
+		if isCguFinished(cgu) {
+			continue
+		}
+		progress := cgu.Status.Status.CurrentBatchRemediationProgress[obj.GetName()]
+		if progress != nil && progress.State == ranv1alpha1.NotStarted && progress.LockedBy != "" {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: cgu.Namespace, Name: cgu.Name}})
+		}
+	}
+	return requests
+}
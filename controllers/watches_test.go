@@ -0,0 +1,229 @@
+package controllers
+
+import (
+	"testing"
+
+	"github.com/go-logr/logr"
+	viewv1beta1 "github.com/open-cluster-management/multicloud-operators-foundation/pkg/apis/view/v1beta1"
+	ranv1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/api/v1alpha1"
+	"github.com/openshift-kni/cluster-group-upgrades-operator/controllers/utils"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestWatches_mapToOwnerCgu(t *testing.T) {
+	testcases := []struct {
+		name        string
+		annotations map[string]string
+		expected    []reconcile.Request
+	}{
+		{
+			name:        "owner annotation",
+			annotations: map[string]string{utils.CguOwnerAnnotation: "default/cgu"},
+			expected:    []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: "default", Name: "cgu"}}},
+		},
+		{
+			name: "no owner annotation",
+		},
+		{
+			name:        "invalid owner annotation",
+			annotations: map[string]string{utils.CguOwnerAnnotation: "cgu"},
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			mcv := &viewv1beta1.ManagedClusterView{
+				ObjectMeta: metav1.ObjectMeta{Name: "view-precache-job", Namespace: "spoke1", Annotations: tc.annotations},
+			}
+			assert.Equal(t, tc.expected, mapToOwnerCgu(mcv))
+		})
+	}
+}
+
+func TestWatches_mapManagedPolicyToCgus(t *testing.T) {
+	cgus := []client.Object{
+		&ranv1alpha1.ClusterGroupUpgrade{
+			ObjectMeta: metav1.ObjectMeta{Name: "not-validated", Namespace: "default"},
+			Spec:       ranv1alpha1.ClusterGroupUpgradeSpec{ManagedPolicies: []string{"policy1", "policy2"}},
+		},
+		&ranv1alpha1.ClusterGroupUpgrade{
+			ObjectMeta: metav1.ObjectMeta{Name: "validated", Namespace: "default"},
+			Spec:       ranv1alpha1.ClusterGroupUpgradeSpec{ManagedPolicies: []string{"policy1"}},
+			Status: ranv1alpha1.ClusterGroupUpgradeStatus{
+				ManagedPoliciesNs: map[string]string{"policy1": "policies"},
+			},
+		},
+		&ranv1alpha1.ClusterGroupUpgrade{
+			ObjectMeta: metav1.ObjectMeta{Name: "completed", Namespace: "default"},
+			Spec:       ranv1alpha1.ClusterGroupUpgradeSpec{ManagedPolicies: []string{"policy1"}},
+			Status: ranv1alpha1.ClusterGroupUpgradeStatus{
+				Conditions: []metav1.Condition{
+					{Type: "Ready", Status: metav1.ConditionTrue, Reason: "UpgradeCompleted"},
+				},
+			},
+		},
+	}
+
+	testcases := []struct {
+		name      string
+		policy    string
+		namespace string
+		labels    map[string]string
+		expected  []string
+	}{
+		{
+			name:      "root policy in the validated namespace",
+			policy:    "policy1",
+			namespace: "policies",
+			expected:  []string{"not-validated", "validated"},
+		},
+		{
+			name:      "root policy in another namespace",
+			policy:    "policy1",
+			namespace: "other",
+			expected:  []string{"not-validated"},
+		},
+		{
+			name:      "child policy",
+			policy:    "policies.policy1",
+			namespace: "spoke1",
+			labels:    map[string]string{utils.ChildPolicyLabel: "policies.policy1"},
+		},
+		{
+			name:      "copied policy",
+			policy:    "policy1",
+			namespace: "default",
+			labels:    map[string]string{"openshift-cluster-group-upgrades/clusterGroupUpgrade": "validated"},
+		},
+		{
+			name:      "policy not managed by any CGU",
+			policy:    "policy3",
+			namespace: "policies",
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			fakeClient, err := getFakeClientFromObjects(cgus...)
+			if err != nil {
+				t.Errorf("error in creating fake client")
+			}
+			r := &ClusterGroupUpgradeReconciler{Client: fakeClient, Log: logr.Discard(), Scheme: testscheme}
+
+			policy := &unstructured.Unstructured{}
+			policy.SetName(tc.policy)
+			policy.SetNamespace(tc.namespace)
+			policy.SetLabels(tc.labels)
+
+			var names []string
+			for _, request := range r.mapManagedPolicyToCgus(policy) {
+				assert.Equal(t, "default", request.Namespace)
+				names = append(names, request.Name)
+			}
+			assert.ElementsMatch(t, tc.expected, names)
+		})
+	}
+}
+
+func TestWatches_mapBlockingCguToCgus(t *testing.T) {
+	blocked := &ranv1alpha1.ClusterGroupUpgrade{
+		ObjectMeta: metav1.ObjectMeta{Name: "blocked", Namespace: "default"},
+		Spec: ranv1alpha1.ClusterGroupUpgradeSpec{
+			BlockingCRs: []ranv1alpha1.BlockingCR{{Name: "blocking", Namespace: "default"}},
+		},
+	}
+	other := &ranv1alpha1.ClusterGroupUpgrade{
+		ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "default"},
+		Spec: ranv1alpha1.ClusterGroupUpgradeSpec{
+			BlockingCRs: []ranv1alpha1.BlockingCR{{Name: "blocking", Namespace: "other"}},
+		},
+	}
+	fakeClient, err := getFakeClientFromObjects(blocked, other)
+	if err != nil {
+		t.Errorf("error in creating fake client")
+	}
+	r := &ClusterGroupUpgradeReconciler{Client: fakeClient, Log: logr.Discard(), Scheme: testscheme}
+
+	blocking := &ranv1alpha1.ClusterGroupUpgrade{ObjectMeta: metav1.ObjectMeta{Name: "blocking", Namespace: "default"}}
+	assert.Equal(t, []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: "default", Name: "blocked"}}},
+		r.mapBlockingCguToCgus(blocking))
+	assert.Empty(t, r.mapBlockingCguToCgus(blocked))
+}
+
+func TestWatches_mapLockedClusterToCgus(t *testing.T) {
+	waiting := &ranv1alpha1.ClusterGroupUpgrade{
+		ObjectMeta: metav1.ObjectMeta{Name: "waiting", Namespace: "default"},
+		Status: ranv1alpha1.ClusterGroupUpgradeStatus{
+			Status: ranv1alpha1.UpgradeStatus{
+				CurrentBatchRemediationProgress: map[string]*ranv1alpha1.ClusterRemediationProgress{
+					"spoke1": {State: ranv1alpha1.NotStarted, LockedBy: "default/holder"},
+					"spoke2": {State: ranv1alpha1.InProgress},
+				},
+			},
+		},
+	}
+	holder := &ranv1alpha1.ClusterGroupUpgrade{
+		ObjectMeta: metav1.ObjectMeta{Name: "holder", Namespace: "default"},
+		Status: ranv1alpha1.ClusterGroupUpgradeStatus{
+			Status: ranv1alpha1.UpgradeStatus{
+				CurrentBatchRemediationProgress: map[string]*ranv1alpha1.ClusterRemediationProgress{
+					"spoke1": {State: ranv1alpha1.InProgress},
+				},
+			},
+		},
+	}
+	fakeClient, err := getFakeClientFromObjects(waiting, holder)
+	if err != nil {
+		t.Errorf("error in creating fake client")
+	}
+	r := &ClusterGroupUpgradeReconciler{Client: fakeClient, Log: logr.Discard(), Scheme: testscheme}
+
+	spoke1 := &clusterv1.ManagedCluster{ObjectMeta: metav1.ObjectMeta{Name: "spoke1"}}
+	spoke2 := &clusterv1.ManagedCluster{ObjectMeta: metav1.ObjectMeta{Name: "spoke2"}}
+	assert.Equal(t, []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: "default", Name: "waiting"}}},
+		r.mapLockedClusterToCgus(spoke1))
+	assert.Empty(t, r.mapLockedClusterToCgus(spoke2))
+
+	locked := spoke1.DeepCopy()
+	locked.Annotations = map[string]string{utils.ClusterLockAnnotation: "default/holder"}
+	labeled := locked.DeepCopy()
+	labeled.Labels = map[string]string{"name": "spoke1"}
+	assert.True(t, clusterLockPredicate.Update(event.UpdateEvent{ObjectOld: locked, ObjectNew: spoke1}))
+	assert.False(t, clusterLockPredicate.Update(event.UpdateEvent{ObjectOld: locked, ObjectNew: labeled}))
+	assert.True(t, clusterLockPredicate.Delete(event.DeleteEvent{Object: locked}))
+	assert.False(t, clusterLockPredicate.Create(event.CreateEvent{Object: locked}))
+}
+
+func TestWatches_predicates(t *testing.T) {
+	inProgress := &ranv1alpha1.ClusterGroupUpgrade{
+		Status: ranv1alpha1.ClusterGroupUpgradeStatus{
+			Conditions: []metav1.Condition{
+				{Type: "Ready", Status: metav1.ConditionFalse, Reason: "UpgradeNotCompleted"},
+			},
+		},
+	}
+	progressed := inProgress.DeepCopy()
+	progressed.Status.Status.CurrentBatch = 2
+	completed := inProgress.DeepCopy()
+	completed.Status.Conditions = []metav1.Condition{
+		{Type: "Ready", Status: metav1.ConditionTrue, Reason: "UpgradeCompleted"},
+	}
+	assert.False(t, blockingCguPredicate.Update(event.UpdateEvent{ObjectOld: inProgress, ObjectNew: progressed}))
+	assert.True(t, blockingCguPredicate.Update(event.UpdateEvent{ObjectOld: inProgress, ObjectNew: completed}))
+
+	view := &viewv1beta1.ManagedClusterView{ObjectMeta: metav1.ObjectMeta{ResourceVersion: "1"}}
+	resynced := view.DeepCopy()
+	resynced.ResourceVersion = "2"
+	updated := view.DeepCopy()
+	updated.Status.Conditions = []metav1.Condition{
+		{Type: viewv1beta1.ConditionViewProcessing, Status: metav1.ConditionTrue, Reason: viewv1beta1.ReasonGetResource},
+	}
+	assert.False(t, multiCloudStatusPredicate.Update(event.UpdateEvent{ObjectOld: view, ObjectNew: resynced}))
+	assert.True(t, multiCloudStatusPredicate.Update(event.UpdateEvent{ObjectOld: view, ObjectNew: updated}))
+	assert.False(t, multiCloudStatusPredicate.Create(event.CreateEvent{Object: view}))
+}
@@ -0,0 +1,252 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"sort"
+	"strings"
+	"time"
+
+	policiesv1 "github.com/open-cluster-management/governance-policy-propagator/api/v1"
+	ranv1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/api/v1alpha1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// Defaults of the ZTP aggregation settings
+const (
+	defaultZtpSettlingTime           = time.Minute
+	defaultZtpAggregationConcurrency = 10
+)
+
+// ztpClusterGroup holds the ready clusters collected for a shared ZTP ClusterGroupUpgrade
+type ztpClusterGroup struct {
+	firstSeen       time.Time
+	managedPolicies []string
+	clusters        map[string]bool
+}
+
+// getZtpGroupKey returns the key of the group of a cluster: the clusters of a group share the managed policies
+// and the settings of their ClusterGroupUpgrade
+func getZtpGroupKey(managedPolicies []string, ztpSettings *ranv1alpha1.ZTPSettings) string {
+	return fmt.Sprintf("%s|%d|%s|%t|%t", strings.Join(managedPolicies, ","), ztpSettings.Timeout,
+		ztpSettings.BatchTimeoutAction, ztpSettings.Backup, ztpSettings.PreCaching)
+}
+
+// getZtpGroupName returns the name of the ClusterGroupUpgrade of a group, the same for every attempt to create it
+func getZtpGroupName(key string, group *ztpClusterGroup) string {
+	hash := fnv.New32a()
+	hash.Write([]byte(key))
+	return fmt.Sprintf("ztp-group-%08x-%d", hash.Sum32(), group.firstSeen.Unix())
+}
+
+/* isClusterInZtpGroup checks whether a cluster is already part of a shared ZTP ClusterGroupUpgrade. The clusters
+   of the groups created by this replica within the settling time are remembered, as the cache may not list their
+   ClusterGroupUpgrade yet.
+
+   returns: bool true if a ClusterGroupUpgrade of the ZTP namespace lists the cluster
+            error/nil
+*/
+func (r *ManagedClusterForCguReconciler) isClusterInZtpGroup(
+	ctx context.Context, cluster string, ztpSettings *ranv1alpha1.ZTPSettings) (bool, error) {
+
+	r.ztpGroupsLock.Lock()
+	groupedAt, grouped := r.ztpGroupedClusters[cluster]
+	if grouped && time.Since(groupedAt) > ztpSettings.Aggregation.SettlingTime.Duration {
+		delete(r.ztpGroupedClusters, cluster)
+		grouped = false
+	}
+	r.ztpGroupsLock.Unlock()
+	if grouped {
+		return true, nil
+	}
+
+	cguList := &ranv1alpha1.ClusterGroupUpgradeList{}
+	if err := r.List(ctx, cguList, client.InNamespace(ztpSettings.Namespace)); err != nil {
+		return false, err
+	}
+	for _, cgu := range cguList.Items {
+		for _, name := range cgu.Spec.Clusters {
+			if name == cluster {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+/* aggregateClusterGroupUpgrade adds a ready cluster to the group of the clusters with the same wave-ordered
+   policies and settings. The first cluster of a group starts its settling time, and the ClusterGroupUpgrade
+   of the group is created by the first reconcile of one of its clusters after the settling time elapsed.
+   The groups are kept in memory, the settling time of a group starts again when the operator restarts.
+
+   returns: ctrl.Result requeuing the cluster at the end of the settling time of its group
+            error/nil
+*/
+func (r *ManagedClusterForCguReconciler) aggregateClusterGroupUpgrade(ctx context.Context,
+	cluster *clusterv1.ManagedCluster, childPolicies []policiesv1.Policy,
+	ztpSettings *ranv1alpha1.ZTPSettings) (ctrl.Result, error) {
+
+	managedPolicies, err := r.getWaveOrderedPolicies(childPolicies)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if len(managedPolicies) == 0 {
+		r.Log.Info("No policies need to be managed by ClusterGroupUpgrade operator")
+		return ctrl.Result{}, nil
+	}
+
+	key := getZtpGroupKey(managedPolicies, ztpSettings)
+	r.ztpGroupsLock.Lock()
+	if r.ztpGroups == nil {
+		r.ztpGroups = make(map[string]*ztpClusterGroup)
+	}
+	group, found := r.ztpGroups[key]
+	if !found {
+		group = &ztpClusterGroup{
+			firstSeen:       time.Now(),
+			managedPolicies: managedPolicies,
+			clusters:        make(map[string]bool),
+		}
+		r.ztpGroups[key] = group
+	}
+	group.clusters[cluster.Name] = true
+	remaining := time.Until(group.firstSeen.Add(ztpSettings.Aggregation.SettlingTime.Duration))
+	if remaining > 0 {
+		r.ztpGroupsLock.Unlock()
+		r.Log.Info("Cluster waiting for the settling time of its ZTP group", "Name", cluster.Name,
+			"RequeueAfter:", remaining)
+		return ctrl.Result{RequeueAfter: remaining}, nil
+	}
+
+	// Take the group out before creating its CGU, the clusters ready in the meantime start a new group
+	delete(r.ztpGroups, key)
+	var clusters []string
+	for name := range group.clusters {
+		clusters = append(clusters, name)
+	}
+	sort.Strings(clusters)
+	if r.ztpGroupedClusters == nil {
+		r.ztpGroupedClusters = make(map[string]time.Time)
+	}
+	for _, name := range clusters {
+		r.ztpGroupedClusters[name] = time.Now()
+	}
+	r.ztpGroupsLock.Unlock()
+
+	if err := r.newGroupClusterGroupUpgrade(
+		ctx, getZtpGroupName(key, group), group.managedPolicies, clusters, ztpSettings); err != nil {
+		r.restoreZtpGroup(key, group, clusters)
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// restoreZtpGroup puts back a group whose CGU failed to be created, with the clusters of the group started since
+func (r *ManagedClusterForCguReconciler) restoreZtpGroup(key string, group *ztpClusterGroup, clusters []string) {
+	r.ztpGroupsLock.Lock()
+	defer r.ztpGroupsLock.Unlock()
+	for _, name := range clusters {
+		delete(r.ztpGroupedClusters, name)
+	}
+	if r.ztpGroups == nil {
+		r.ztpGroups = make(map[string]*ztpClusterGroup)
+	}
+	if newer, found := r.ztpGroups[key]; found {
+		for name := range newer.clusters {
+			group.clusters[name] = true
+		}
+	}
+	r.ztpGroups[key] = group
+}
+
+/* newGroupClusterGroupUpgrade creates the shared ClusterGroupUpgrade of the clusters of a group. The clusters deleted
+   or done in the meantime are left out. Each cluster owns the ClusterGroupUpgrade, so it is only deleted with the
+   last one, and gets the done label as soon as it completes its own remediation.
+
+   returns: error/nil
+*/
+func (r *ManagedClusterForCguReconciler) newGroupClusterGroupUpgrade(ctx context.Context, name string,
+	managedPolicies, clusters []string, ztpSettings *ranv1alpha1.ZTPSettings) error {
+
+	if err := r.ensureNamespace(ctx, ztpSettings.Namespace); err != nil {
+		return err
+	}
+
+	actions := getZtpActions(ztpSettings)
+	actions.AfterClusterCompletion.AddClusterLabels = map[string]string{ztpSettings.DoneLabel: ""}
+	actions.AfterClusterCompletion.DeleteClusterLabels = map[string]string{ztpSettings.RunningLabel: ""}
+	enable := true
+	clusterGroupUpgrade := &ranv1alpha1.ClusterGroupUpgrade{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: ztpSettings.Namespace,
+		},
+		Spec: ranv1alpha1.ClusterGroupUpgradeSpec{
+			Enable:          &enable,
+			ManagedPolicies: managedPolicies,
+			RemediationStrategy: &ranv1alpha1.RemediationStrategySpec{
+				MaxConcurrency: ztpSettings.Aggregation.MaxConcurrency,
+				Timeout:        ztpSettings.Timeout,
+				ParallelWaves:  ztpSettings.ParallelWaves,
+			},
+			Backup:             ztpSettings.Backup,
+			PreCaching:         ztpSettings.PreCaching,
+			BatchTimeoutAction: ztpSettings.BatchTimeoutAction,
+			Actions:            actions,
+		},
+	}
+
+	for _, name := range clusters {
+		cluster := &clusterv1.ManagedCluster{}
+		if err := r.Get(ctx, types.NamespacedName{Name: name}, cluster); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return err
+		}
+		if _, found := cluster.Labels[ztpSettings.DoneLabel]; found {
+			continue
+		}
+		if err := controllerutil.SetOwnerReference(cluster, clusterGroupUpgrade, r.Scheme); err != nil {
+			return err
+		}
+		clusterGroupUpgrade.Spec.Clusters = append(clusterGroupUpgrade.Spec.Clusters, name)
+	}
+	if len(clusterGroupUpgrade.Spec.Clusters) == 0 {
+		return nil
+	}
+
+	if err := r.Create(ctx, clusterGroupUpgrade); err != nil {
+		if errors.IsAlreadyExists(err) {
+			return nil
+		}
+		r.Log.Error(err, "Fail to create clusterGroupUpgrade", "name", name, "namespace", ztpSettings.Namespace)
+		return err
+	}
+	r.Log.Info("Created clusterGroupUpgrade for a group of ZTP clusters", "name", name,
+		"namespace", ztpSettings.Namespace, "clusters", clusterGroupUpgrade.Spec.Clusters)
+	return nil
+}
@@ -0,0 +1,133 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	policiesv1 "github.com/open-cluster-management/governance-policy-propagator/api/v1"
+	ranv1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/api/v1alpha1"
+	"github.com/openshift-kni/cluster-group-upgrades-operator/controllers/utils"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestZtpAggregation_Reconcile(t *testing.T) {
+	config := &ranv1alpha1.ClusterGroupUpgradeOperatorConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: utils.OperatorConfigName},
+		Spec: ranv1alpha1.ClusterGroupUpgradeOperatorConfigSpec{
+			OperatorSettings: ranv1alpha1.OperatorSettings{DefaultTimeout: 240},
+			ZTP: &ranv1alpha1.ZTPSettings{
+				Aggregation: &ranv1alpha1.ZTPAggregation{
+					SettlingTime:   &metav1.Duration{Duration: time.Hour},
+					MaxConcurrency: 50,
+				},
+			},
+		},
+	}
+	newCluster := func(name string, labels, annotations map[string]string) *clusterv1.ManagedCluster {
+		return &clusterv1.ManagedCluster{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels, Annotations: annotations},
+			Status: clusterv1.ManagedClusterStatus{
+				Conditions: []metav1.Condition{{Type: clusterv1.ManagedClusterConditionAvailable, Status: metav1.ConditionTrue}},
+			},
+		}
+	}
+	newPolicy := func(cluster string) *policiesv1.Policy {
+		return &policiesv1.Policy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "ztp-common.common-config-policy",
+				Namespace:   cluster,
+				Labels:      map[string]string{utils.ChildPolicyLabel: "ztp-common.common-config-policy"},
+				Annotations: map[string]string{ztpDeployWaveAnnotation: "1"},
+			},
+		}
+	}
+	objs := []client.Object{config,
+		newCluster("site1", nil, nil),
+		newCluster("site2", nil, nil),
+		// A cluster with other settings goes in another group
+		newCluster("site3", nil, map[string]string{ztpTimeoutAnnotation: "90"}),
+		newCluster("site4", map[string]string{ztpDoneLabel: ""}, nil),
+	}
+	for _, cluster := range []string{"site1", "site2", "site3", "site4"} {
+		objs = append(objs, newPolicy(cluster))
+	}
+	fakeClient, err := getFakeClientFromObjects(objs...)
+	if err != nil {
+		t.Errorf("error in creating fake client")
+	}
+	r := &ManagedClusterForCguReconciler{Client: fakeClient, Log: logr.Discard(), Scheme: fakeClient.Scheme()}
+	reconcileAll := func() {
+		for _, name := range []string{"site1", "site2", "site3", "site4"} {
+			_, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: name}})
+			assert.NoError(t, err)
+		}
+	}
+
+	// The clusters wait for the settling time of their group
+	result, err := r.Reconcile(context.TODO(), reconcile.Request{NamespacedName: types.NamespacedName{Name: "site1"}})
+	assert.NoError(t, err)
+	assert.True(t, result.RequeueAfter > 59*time.Minute)
+	reconcileAll()
+	clusterGroupUpgrades := &ranv1alpha1.ClusterGroupUpgradeList{}
+	assert.NoError(t, fakeClient.List(context.TODO(), clusterGroupUpgrades))
+	assert.Empty(t, clusterGroupUpgrades.Items)
+	assert.Len(t, r.ztpGroups, 2)
+
+	// Each group gets a single CGU once its settling time elapsed
+	for _, group := range r.ztpGroups {
+		group.firstSeen = group.firstSeen.Add(-2 * time.Hour)
+	}
+	reconcileAll()
+	reconcileAll()
+	assert.NoError(t, fakeClient.List(context.TODO(), clusterGroupUpgrades))
+	assert.Len(t, clusterGroupUpgrades.Items, 2)
+	assert.Empty(t, r.ztpGroups)
+	for _, clusterGroupUpgrade := range clusterGroupUpgrades.Items {
+		assert.Equal(t, ztpInstallNS, clusterGroupUpgrade.Namespace)
+		assert.Equal(t, 50, clusterGroupUpgrade.Spec.RemediationStrategy.MaxConcurrency)
+		assert.Equal(t, []string{"common-config-policy"}, clusterGroupUpgrade.Spec.ManagedPolicies)
+		assert.Equal(t, map[string]string{ztpDoneLabel: ""},
+			clusterGroupUpgrade.Spec.Actions.AfterClusterCompletion.AddClusterLabels)
+		assert.Equal(t, map[string]string{ztpRunningLabel: ""},
+			clusterGroupUpgrade.Spec.Actions.AfterClusterCompletion.DeleteClusterLabels)
+		assert.Len(t, clusterGroupUpgrade.OwnerReferences, len(clusterGroupUpgrade.Spec.Clusters))
+		if clusterGroupUpgrade.Spec.RemediationStrategy.Timeout == 90 {
+			assert.Equal(t, []string{"site3"}, clusterGroupUpgrade.Spec.Clusters)
+		} else {
+			assert.Equal(t, []string{"site1", "site2"}, clusterGroupUpgrade.Spec.Clusters)
+		}
+	}
+
+	// The grouped clusters are found in their CGU once they are no longer remembered
+	r.ztpGroupedClusters = nil
+	reconcileAll()
+	assert.NoError(t, fakeClient.List(context.TODO(), clusterGroupUpgrades))
+	assert.Len(t, clusterGroupUpgrades.Items, 2)
+	assert.Empty(t, r.ztpGroups)
+}
+
+func TestZtpAggregation_restoreZtpGroup(t *testing.T) {
+	firstSeen := time.Now().Add(-2 * time.Hour)
+	group := &ztpClusterGroup{firstSeen: firstSeen, clusters: map[string]bool{"site1": true, "site2": true}}
+	r := &ManagedClusterForCguReconciler{
+		Log: logr.Discard(),
+		// A cluster got ready while the CGU of the group was being created
+		ztpGroups: map[string]*ztpClusterGroup{
+			"key": {firstSeen: time.Now(), clusters: map[string]bool{"site3": true}},
+		},
+		ztpGroupedClusters: map[string]time.Time{"site1": time.Now(), "site2": time.Now()},
+	}
+
+	r.restoreZtpGroup("key", group, []string{"site1", "site2"})
+	assert.Empty(t, r.ztpGroupedClusters)
+	assert.Len(t, r.ztpGroups, 1)
+	assert.Equal(t, firstSeen, r.ztpGroups["key"].firstSeen)
+	assert.Equal(t, map[string]bool{"site1": true, "site2": true, "site3": true}, r.ztpGroups["key"].clusters)
+}
@@ -0,0 +1,137 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"fmt"
+	"strconv"
+
+	ranv1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/api/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+)
+
+// ManagedCluster annotations overriding the ZTP settings of a cluster
+const (
+	ztpTimeoutAnnotation            = "ran.openshift.io/ztp-timeout"
+	ztpBatchTimeoutActionAnnotation = "ran.openshift.io/ztp-batch-timeout-action"
+	ztpBackupAnnotation             = "ran.openshift.io/ztp-backup"
+	ztpPreCachingAnnotation         = "ran.openshift.io/ztp-precaching"
+)
+
+// getZtpSettings returns the ZTP settings of the operator configuration, with the defaults filled in.
+// The timeout and batch timeout action default to the settings of the ZTP namespace, and the aggregation
+// settings are only defaulted when the aggregation is enabled.
+func getZtpSettings(config *ranv1alpha1.ClusterGroupUpgradeOperatorConfigSpec) ranv1alpha1.ZTPSettings {
+	settings := ranv1alpha1.ZTPSettings{}
+	if config.ZTP != nil {
+		settings = *config.ZTP.DeepCopy()
+	}
+	if settings.Namespace == "" {
+		settings.Namespace = ztpInstallNS
+	}
+	if settings.RunningLabel == "" {
+		settings.RunningLabel = ztpRunningLabel
+	}
+	if settings.DoneLabel == "" {
+		settings.DoneLabel = ztpDoneLabel
+	}
+	namespaceSettings := getNamespaceSettings(config, settings.Namespace)
+	if settings.Timeout == 0 {
+		settings.Timeout = namespaceSettings.DefaultTimeout
+	}
+	if settings.BatchTimeoutAction == "" {
+		settings.BatchTimeoutAction = namespaceSettings.DefaultBatchTimeoutAction
+	}
+	if settings.Aggregation != nil {
+		if settings.Aggregation.SettlingTime == nil {
+			settings.Aggregation.SettlingTime = &metav1.Duration{Duration: defaultZtpSettlingTime}
+		}
+		if settings.Aggregation.MaxConcurrency == 0 {
+			settings.Aggregation.MaxConcurrency = defaultZtpAggregationConcurrency
+		}
+	}
+	return settings
+}
+
+// isZtpCluster returns true if the cluster is selected by the cluster selector of the ZTP settings
+func isZtpCluster(settings *ranv1alpha1.ZTPSettings, cluster *clusterv1.ManagedCluster) (bool, error) {
+	if settings.ClusterSelector == nil {
+		return true, nil
+	}
+	selector, err := metav1.LabelSelectorAsSelector(settings.ClusterSelector)
+	if err != nil {
+		return false, fmt.Errorf("invalid ZTP cluster selector: %w", err)
+	}
+	return selector.Matches(labels.Set(cluster.Labels)), nil
+}
+
+// applyZtpClusterOverrides applies the ZTP settings overridden by the annotations of a cluster
+// returns: error if an annotation has an invalid value
+func applyZtpClusterOverrides(settings *ranv1alpha1.ZTPSettings, cluster *clusterv1.ManagedCluster) error {
+	annotations := cluster.GetAnnotations()
+	if value, ok := annotations[ztpTimeoutAnnotation]; ok {
+		timeout, err := strconv.Atoi(value)
+		if err != nil || timeout < 1 {
+			return fmt.Errorf("%s of cluster %s must be a positive number of minutes, got %q",
+				ztpTimeoutAnnotation, cluster.Name, value)
+		}
+		settings.Timeout = timeout
+	}
+	if value, ok := annotations[ztpBatchTimeoutActionAnnotation]; ok {
+		if value != ranv1alpha1.BatchTimeoutAction.Continue && value != ranv1alpha1.BatchTimeoutAction.Abort {
+			return fmt.Errorf("%s of cluster %s must be %s or %s, got %q", ztpBatchTimeoutActionAnnotation,
+				cluster.Name, ranv1alpha1.BatchTimeoutAction.Continue, ranv1alpha1.BatchTimeoutAction.Abort, value)
+		}
+		settings.BatchTimeoutAction = value
+	}
+	for annotation, setting := range map[string]*bool{
+		ztpBackupAnnotation:     &settings.Backup,
+		ztpPreCachingAnnotation: &settings.PreCaching,
+	} {
+		if value, ok := annotations[annotation]; ok {
+			enabled, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("%s of cluster %s must be true or false, got %q", annotation, cluster.Name, value)
+			}
+			*setting = enabled
+		}
+	}
+	return nil
+}
+
+// getZtpActions returns the actions of a ZTP CGU: the ones of the settings, plus the running and done labels
+func getZtpActions(settings *ranv1alpha1.ZTPSettings) ranv1alpha1.Actions {
+	actions := ranv1alpha1.Actions{}
+	if settings.Actions != nil {
+		actions = *settings.Actions.DeepCopy()
+	}
+	if actions.BeforeEnable.AddClusterLabels == nil {
+		actions.BeforeEnable.AddClusterLabels = make(map[string]string)
+	}
+	actions.BeforeEnable.AddClusterLabels[settings.RunningLabel] = ""
+	if actions.AfterCompletion.AddClusterLabels == nil {
+		actions.AfterCompletion.AddClusterLabels = make(map[string]string)
+	}
+	actions.AfterCompletion.AddClusterLabels[settings.DoneLabel] = ""
+	if actions.AfterCompletion.DeleteClusterLabels == nil {
+		actions.AfterCompletion.DeleteClusterLabels = make(map[string]string)
+	}
+	actions.AfterCompletion.DeleteClusterLabels[settings.RunningLabel] = ""
+	return actions
+}
@@ -0,0 +1,159 @@
+package controllers
+
+import (
+	"testing"
+
+	ranv1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/api/v1alpha1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "open-cluster-management.io/api/cluster/v1"
+)
+
+func TestZtpSettings_getZtpSettings(t *testing.T) {
+	// Defaults
+	settings := getZtpSettings(&ranv1alpha1.ClusterGroupUpgradeOperatorConfigSpec{
+		OperatorSettings: ranv1alpha1.OperatorSettings{DefaultTimeout: 120},
+	})
+	assert.Equal(t, ranv1alpha1.ZTPSettings{
+		Namespace:    ztpInstallNS,
+		RunningLabel: ztpRunningLabel,
+		DoneLabel:    ztpDoneLabel,
+		Timeout:      120,
+	}, settings)
+
+	// The timeout and batch timeout action default to the settings of the ZTP namespace
+	config := &ranv1alpha1.ClusterGroupUpgradeOperatorConfigSpec{
+		OperatorSettings: ranv1alpha1.OperatorSettings{DefaultTimeout: 120},
+		NamespaceOverrides: []ranv1alpha1.NamespaceOverrides{{
+			Namespace: "ztp-sites",
+			OperatorSettings: ranv1alpha1.OperatorSettings{
+				DefaultTimeout: 60, DefaultBatchTimeoutAction: ranv1alpha1.BatchTimeoutAction.Abort},
+		}},
+		ZTP: &ranv1alpha1.ZTPSettings{Namespace: "ztp-sites", DoneLabel: "sites-done"},
+	}
+	settings = getZtpSettings(config)
+	assert.Equal(t, 60, settings.Timeout)
+	assert.Equal(t, ranv1alpha1.BatchTimeoutAction.Abort, settings.BatchTimeoutAction)
+	assert.Equal(t, "sites-done", settings.DoneLabel)
+	assert.Equal(t, ztpRunningLabel, settings.RunningLabel)
+
+	config.ZTP.Timeout = 30
+	settings = getZtpSettings(config)
+	assert.Equal(t, 30, settings.Timeout)
+
+	// The aggregation settings are defaulted once the aggregation is enabled
+	config.ZTP.Aggregation = &ranv1alpha1.ZTPAggregation{}
+	settings = getZtpSettings(config)
+	assert.Equal(t, &ranv1alpha1.ZTPAggregation{
+		SettlingTime:   &metav1.Duration{Duration: defaultZtpSettlingTime},
+		MaxConcurrency: defaultZtpAggregationConcurrency,
+	}, settings.Aggregation)
+	assert.Equal(t, &ranv1alpha1.ZTPAggregation{}, config.ZTP.Aggregation)
+}
+
+func TestZtpSettings_isZtpCluster(t *testing.T) {
+	cluster := &clusterv1.ManagedCluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "spoke1", Labels: map[string]string{"ztp": "true"}},
+	}
+	selected, err := isZtpCluster(&ranv1alpha1.ZTPSettings{}, cluster)
+	assert.NoError(t, err)
+	assert.True(t, selected)
+
+	selected, err = isZtpCluster(&ranv1alpha1.ZTPSettings{
+		ClusterSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"ztp": "true"}}}, cluster)
+	assert.NoError(t, err)
+	assert.True(t, selected)
+
+	selected, err = isZtpCluster(&ranv1alpha1.ZTPSettings{
+		ClusterSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"ztp": "false"}}}, cluster)
+	assert.NoError(t, err)
+	assert.False(t, selected)
+
+	_, err = isZtpCluster(&ranv1alpha1.ZTPSettings{
+		ClusterSelector: &metav1.LabelSelector{MatchExpressions: []metav1.LabelSelectorRequirement{
+			{Key: "ztp", Operator: "Bad"}}}}, cluster)
+	assert.Error(t, err)
+}
+
+func TestZtpSettings_applyZtpClusterOverrides(t *testing.T) {
+	testcases := []struct {
+		name        string
+		annotations map[string]string
+		expected    ranv1alpha1.ZTPSettings
+		expectedErr bool
+	}{
+		{
+			name:     "no annotations",
+			expected: ranv1alpha1.ZTPSettings{Timeout: 240, Backup: true},
+		},
+		{
+			name: "all annotations",
+			annotations: map[string]string{
+				ztpTimeoutAnnotation:            "60",
+				ztpBatchTimeoutActionAnnotation: ranv1alpha1.BatchTimeoutAction.Abort,
+				ztpBackupAnnotation:             "false",
+				ztpPreCachingAnnotation:         "true",
+			},
+			expected: ranv1alpha1.ZTPSettings{
+				Timeout: 60, BatchTimeoutAction: ranv1alpha1.BatchTimeoutAction.Abort, PreCaching: true},
+		},
+		{
+			name:        "invalid timeout",
+			annotations: map[string]string{ztpTimeoutAnnotation: "0"},
+			expectedErr: true,
+		},
+		{
+			name:        "invalid batch timeout action",
+			annotations: map[string]string{ztpBatchTimeoutActionAnnotation: "Retry"},
+			expectedErr: true,
+		},
+		{
+			name:        "invalid backup",
+			annotations: map[string]string{ztpBackupAnnotation: "yes please"},
+			expectedErr: true,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			settings := ranv1alpha1.ZTPSettings{Timeout: 240, Backup: true}
+			cluster := &clusterv1.ManagedCluster{
+				ObjectMeta: metav1.ObjectMeta{Name: "spoke1", Annotations: tc.annotations},
+			}
+			err := applyZtpClusterOverrides(&settings, cluster)
+			if tc.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, settings)
+		})
+	}
+}
+
+func TestZtpSettings_getZtpActions(t *testing.T) {
+	deleteObjects := false
+	settings := &ranv1alpha1.ZTPSettings{
+		RunningLabel: "running",
+		DoneLabel:    "done",
+		Actions: &ranv1alpha1.Actions{
+			BeforeEnable: ranv1alpha1.BeforeEnable{DeleteClusterLabels: map[string]string{"ready": ""}},
+			AfterCompletion: ranv1alpha1.AfterCompletion{
+				AddClusterLabels: map[string]string{"ready": ""},
+				DeleteObjects:    &deleteObjects,
+			},
+		},
+	}
+	assert.Equal(t, ranv1alpha1.Actions{
+		BeforeEnable: ranv1alpha1.BeforeEnable{
+			AddClusterLabels:    map[string]string{"running": ""},
+			DeleteClusterLabels: map[string]string{"ready": ""},
+		},
+		AfterCompletion: ranv1alpha1.AfterCompletion{
+			AddClusterLabels:    map[string]string{"ready": "", "done": ""},
+			DeleteClusterLabels: map[string]string{"running": ""},
+			DeleteObjects:       &deleteObjects,
+		},
+	}, getZtpActions(settings))
+	// The settings are left as is
+	assert.Equal(t, map[string]string{"ready": ""}, settings.Actions.AfterCompletion.AddClusterLabels)
+}
@@ -0,0 +1,67 @@
+#!/bin/bash
+# Generates the typed clientset, listers, informers and apply configurations under pkg/client
+# for the ClusterGroupUpgrade API.
+
+set -o errexit
+set -o nounset
+set -o pipefail
+
+MODULE=github.com/openshift-kni/cluster-group-upgrades-operator
+OUTPUT_PACKAGE="${MODULE}/pkg/client"
+BIN_DIR=${BIN_DIR:-$(pwd)/bin}
+HEADER_FILE=$(pwd)/hack/boilerplate.go.txt
+
+# The generators expect the API packages under <group>/<version> and treat a group named "api"
+# as the core Kubernetes group, so the packages are exposed as api/ran/<version> while generating
+ln -sfn . api/ran
+API_BASE="${MODULE}/api"
+APIS="${API_BASE}/ran/v1alpha1,${API_BASE}/ran/v1beta1"
+
+# The generators write the packages under <output-base>/<import path>
+OUTPUT_BASE=$(mktemp -d)
+trap 'rm -rf "${OUTPUT_BASE}" api/ran' EXIT
+
+echo "Generating apply configurations"
+"${BIN_DIR}/applyconfiguration-gen" \
+    --input-dirs "${APIS}" \
+    --output-package "${OUTPUT_PACKAGE}/applyconfiguration" \
+    --output-base "${OUTPUT_BASE}" \
+    --go-header-file "${HEADER_FILE}"
+
+echo "Generating clientset"
+"${BIN_DIR}/client-gen" \
+    --clientset-name versioned \
+    --input-base "${API_BASE}" \
+    --input "ran/v1alpha1,ran/v1beta1" \
+    --output-package "${OUTPUT_PACKAGE}/clientset" \
+    --output-base "${OUTPUT_BASE}" \
+    --go-header-file "${HEADER_FILE}"
+
+echo "Generating listers"
+"${BIN_DIR}/lister-gen" \
+    --input-dirs "${APIS}" \
+    --output-package "${OUTPUT_PACKAGE}/listers" \
+    --output-base "${OUTPUT_BASE}" \
+    --go-header-file "${HEADER_FILE}"
+
+echo "Generating informers"
+"${BIN_DIR}/informer-gen" \
+    --input-dirs "${APIS}" \
+    --versioned-clientset-package "${OUTPUT_PACKAGE}/clientset/versioned" \
+    --listers-package "${OUTPUT_PACKAGE}/listers" \
+    --output-package "${OUTPUT_PACKAGE}/informers" \
+    --output-base "${OUTPUT_BASE}" \
+    --go-header-file "${HEADER_FILE}"
+
+# applyconfiguration-gen v0.21 doesn't know about the client-go apply configuration of the owner
+# references embedded in ObjectMetaApplyConfiguration
+find "${OUTPUT_BASE}/${OUTPUT_PACKAGE}/applyconfiguration" -name '*.go' -exec sed -i \
+    -e 's|WithOwnerReferences(values \.\.\.metav1\.OwnerReference)|WithOwnerReferences(values ...*v1.OwnerReferenceApplyConfiguration)|' \
+    -e 's|b\.OwnerReferences = append(b\.OwnerReferences, values\[i\])|b.OwnerReferences = append(b.OwnerReferences, *values[i])|' {} +
+
+# Point the generated code back to the real API packages
+find "${OUTPUT_BASE}" -name '*.go' -exec sed -i "s|${API_BASE}/ran/|${API_BASE}/|g" {} +
+
+rm -rf pkg/client/applyconfiguration pkg/client/clientset pkg/client/listers pkg/client/informers
+mkdir -p pkg/client
+cp -r "${OUTPUT_BASE}/${OUTPUT_PACKAGE}"/* pkg/client/
@@ -17,8 +17,12 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"flag"
+	"fmt"
+	"io/ioutil"
 	"os"
+	"strings"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
@@ -26,14 +30,19 @@ import (
 
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/apimachinery/pkg/util/uuid"
+	"k8s.io/client-go/kubernetes"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 
 	policiesv1 "github.com/open-cluster-management/governance-policy-propagator/api/v1"
 	ranv1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/api/v1alpha1"
+	ranv1beta1 "github.com/openshift-kni/cluster-group-upgrades-operator/api/v1beta1"
 	"github.com/openshift-kni/cluster-group-upgrades-operator/controllers"
+	"github.com/openshift-kni/cluster-group-upgrades-operator/controllers/utils"
 
 	actionv1beta1 "github.com/open-cluster-management/multicloud-operators-foundation/pkg/apis/action/v1beta1"
 	viewv1beta1 "github.com/open-cluster-management/multicloud-operators-foundation/pkg/apis/view/v1beta1"
@@ -43,6 +52,11 @@ import (
 	//+kubebuilder:scaffold:imports
 )
 
+const (
+	leaderElectionID       = "9a2365a3.openshift.io"
+	inClusterNamespacePath = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+)
+
 var (
 	scheme   = runtime.NewScheme()
 	setupLog = ctrl.Log.WithName("setup")
@@ -54,6 +68,7 @@ func init() {
 	utilruntime.Must(clusterv1.AddToScheme(scheme))
 	utilruntime.Must(policiesv1.AddToScheme(scheme))
 	utilruntime.Must(ranv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(ranv1beta1.AddToScheme(scheme))
 	utilruntime.Must(viewv1beta1.AddToScheme(scheme))
 	utilruntime.Must(actionv1beta1.AddToScheme(scheme))
 	utilruntime.Must(operatorsv1alpha1.AddToScheme(scheme))
@@ -64,11 +79,33 @@ func main() {
 	var metricsAddr string
 	var enableLeaderElection bool
 	var probeAddr string
+	var maxConcurrentRemediations, maxConcurrentPrecaching, maxConcurrentBackups int
+	var maxConcurrentReconciles, shards int
+	var shardBy, shardLeaseNamespace string
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.IntVar(&maxConcurrentRemediations, "max-concurrent-remediations", 0,
+		"The maximum number of clusters being remediated at once across all ClusterGroupUpgrades. "+
+			"0 means no limit.")
+	flag.IntVar(&maxConcurrentPrecaching, "max-concurrent-precaching", 0,
+		"The maximum number of pre-caching jobs running at once across all ClusterGroupUpgrades. "+
+			"0 means no limit.")
+	flag.IntVar(&maxConcurrentBackups, "max-concurrent-backups", 0,
+		"The maximum number of backup jobs running at once across all ClusterGroupUpgrades. "+
+			"0 means no limit.")
+	flag.IntVar(&maxConcurrentReconciles, "max-concurrent-reconciles", 4,
+		"The number of ClusterGroupUpgrades reconciled at once. "+
+			"A ClusterGroupUpgrade is never reconciled by two workers at the same time.")
+	flag.IntVar(&shards, "shards", 0,
+		"The number of shards the ClusterGroupUpgrades are split into between the replicas of the operator. "+
+			"Each replica reconciles the shards it holds the lease of. Replaces --leader-elect. 0 disables sharding.")
+	flag.StringVar(&shardBy, "shard-by", controllers.ShardByName,
+		"The key assigning a ClusterGroupUpgrade to a shard: "+controllers.ShardByName+" or "+controllers.ShardByNamespace+".")
+	flag.StringVar(&shardLeaseNamespace, "shard-lease-namespace", "",
+		"The namespace of the shard leases. Defaults to the namespace of the operator.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -77,33 +114,73 @@ func main() {
 
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
-	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
+	if shards > 0 && enableLeaderElection {
+		setupLog.Info("leader election is replaced by the shard leases", "shards", shards)
+		enableLeaderElection = false
+	}
+
+	config := ctrl.GetConfigOrDie()
+	mgr, err := ctrl.NewManager(config, ctrl.Options{
 		Scheme:                 scheme,
 		MetricsBindAddress:     metricsAddr,
 		Port:                   9443,
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
-		LeaderElectionID:       "9a2365a3.openshift.io",
+		LeaderElectionID:       leaderElectionID,
 	})
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
 		os.Exit(1)
 	}
 
+	var shardLeases *controllers.ShardLeases
+	if shards > 0 {
+		shardLeases, err = newShardLeases(config, shards, shardBy, shardLeaseNamespace)
+		if err != nil {
+			setupLog.Error(err, "unable to set up the shard leases")
+			os.Exit(1)
+		}
+		if err = mgr.Add(shardLeases); err != nil {
+			setupLog.Error(err, "unable to add the shard leases")
+			os.Exit(1)
+		}
+	}
+
+	if err = utils.SetupFieldIndexers(context.Background(), mgr.GetFieldIndexer()); err != nil {
+		setupLog.Error(err, "unable to set up the cache indexes")
+		os.Exit(1)
+	}
+
 	if err = (&controllers.ClusterGroupUpgradeReconciler{
-		Client: mgr.GetClient(),
-		Log:    ctrl.Log.WithName("controllers").WithName("ClusterGroupUpgrade"),
-		Scheme: mgr.GetScheme(),
+		Client:    mgr.GetClient(),
+		APIReader: mgr.GetAPIReader(),
+		Log:       ctrl.Log.WithName("controllers").WithName("ClusterGroupUpgrade"),
+		Scheme:    mgr.GetScheme(),
+
+		MaxConcurrentRemediations: maxConcurrentRemediations,
+		MaxConcurrentPrecaching:   maxConcurrentPrecaching,
+		MaxConcurrentBackups:      maxConcurrentBackups,
+		MaxConcurrentReconciles:   maxConcurrentReconciles,
+		Shards:                    shardLeases,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ClusterGroupUpgrade")
 		os.Exit(1)
 	}
+	// The conversion webhook serves the v1beta1 ClusterGroupUpgrade API. It can be disabled when
+	// running the operator locally, without serving certificates.
+	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+		if err = (&ranv1alpha1.ClusterGroupUpgrade{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "ClusterGroupUpgrade")
+			os.Exit(1)
+		}
+	}
 	//+kubebuilder:scaffold:builder
 
 	if err = (&controllers.ManagedClusterForCguReconciler{
 		Client: mgr.GetClient(),
 		Log:    ctrl.Log.WithName("controllers").WithName("ManagedClusterForCGU"),
 		Scheme: mgr.GetScheme(),
+		Shards: shardLeases,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "ManagedClusterForCGU")
 		os.Exit(1)
@@ -124,3 +201,37 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+// newShardLeases returns the shard leases of this replica, identified like the manager leader election
+func newShardLeases(config *rest.Config, shards int, shardBy string,
+	namespace string) (*controllers.ShardLeases, error) {
+
+	if shardBy != controllers.ShardByName && shardBy != controllers.ShardByNamespace {
+		return nil, fmt.Errorf("invalid --shard-by %q, must be %s or %s",
+			shardBy, controllers.ShardByName, controllers.ShardByNamespace)
+	}
+	if namespace == "" {
+		data, err := ioutil.ReadFile(inClusterNamespacePath)
+		if err != nil {
+			return nil, fmt.Errorf("not running in-cluster, please specify --shard-lease-namespace: %w", err)
+		}
+		namespace = strings.TrimSpace(string(data))
+	}
+	hostname, err := os.Hostname()
+	if err != nil {
+		return nil, err
+	}
+	clientset, err := kubernetes.NewForConfig(rest.AddUserAgent(config, "shard-leases"))
+	if err != nil {
+		return nil, err
+	}
+	return &controllers.ShardLeases{
+		Leases:    clientset.CoordinationV1(),
+		Namespace: namespace,
+		Name:      leaderElectionID,
+		Identity:  hostname + "_" + string(uuid.NewUUID()),
+		Count:     shards,
+		By:        shardBy,
+		Log:       ctrl.Log.WithName("shards"),
+	}, nil
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cguutil provides helpers to follow the progress of ClusterGroupUpgrades
+// without having to interpret their status by hand.
+package cguutil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	ranv1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/api/v1alpha1"
+	"github.com/openshift-kni/cluster-group-upgrades-operator/pkg/client/clientset/versioned"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// Ready condition reasons set by the operator versions that predate the dedicated conditions
+const (
+	legacyReasonCompleted = "UpgradeCompleted"
+	legacyReasonTimedOut  = "UpgradeTimedOut"
+)
+
+// PollInterval is the interval between two reads of the ClusterGroupUpgrade in WaitForCondition
+var PollInterval = 5 * time.Second
+
+// ErrTerminal is returned by WaitForCondition when the ClusterGroupUpgrade finished without
+// the expected condition being set
+var ErrTerminal = errors.New("the ClusterGroupUpgrade finished")
+
+// BatchProgress describes the progress of the current batch of a ClusterGroupUpgrade
+type BatchProgress struct {
+	// CurrentBatch is the 1-based index of the batch being remediated, 0 if the upgrade hasn't started
+	CurrentBatch int
+	// TotalBatches is the number of batches of the remediation plan
+	TotalBatches int
+	// StartedAt is the time the current batch started, nil if it hasn't started
+	StartedAt *metav1.Time
+	// Clusters holds the number of clusters of the current batch in each remediation state:
+	// NotStarted, InProgress, Completed and Skipped
+	Clusters map[string]int
+	// PolicyIndex holds the index of the policy being remediated on each cluster of the current batch
+	PolicyIndex map[string]int
+}
+
+// IsSucceeded returns true if the ClusterGroupUpgrade completed successfully.
+// ClusterGroupUpgrades last updated by an older operator version only have the Ready condition.
+func IsSucceeded(cgu *ranv1alpha1.ClusterGroupUpgrade) bool {
+	if meta.FindStatusCondition(cgu.Status.Conditions, ranv1alpha1.ConditionTypes.Succeeded) != nil {
+		return meta.IsStatusConditionTrue(cgu.Status.Conditions, ranv1alpha1.ConditionTypes.Succeeded)
+	}
+	readyCondition := meta.FindStatusCondition(cgu.Status.Conditions, ranv1alpha1.ConditionTypes.Ready)
+	return readyCondition != nil && readyCondition.Reason == legacyReasonCompleted
+}
+
+// IsTerminal returns true if the ClusterGroupUpgrade either succeeded or failed. The operator
+// doesn't act on a ClusterGroupUpgrade in a terminal state anymore.
+func IsTerminal(cgu *ranv1alpha1.ClusterGroupUpgrade) bool {
+	if IsSucceeded(cgu) || meta.IsStatusConditionTrue(cgu.Status.Conditions, ranv1alpha1.ConditionTypes.Failed) {
+		return true
+	}
+	readyCondition := meta.FindStatusCondition(cgu.Status.Conditions, ranv1alpha1.ConditionTypes.Ready)
+	return readyCondition != nil && readyCondition.Reason == legacyReasonTimedOut
+}
+
+// GetBatchProgress returns the progress of the current batch of the ClusterGroupUpgrade
+func GetBatchProgress(cgu *ranv1alpha1.ClusterGroupUpgrade) BatchProgress {
+	progress := BatchProgress{
+		CurrentBatch: cgu.Status.Status.CurrentBatch,
+		TotalBatches: len(cgu.Status.RemediationPlan),
+		Clusters:     make(map[string]int),
+		PolicyIndex:  make(map[string]int),
+	}
+	// The remediation plan is left out of the status when the per-cluster state is stored in ConfigMaps
+	if cgu.Status.ClusterStates != nil && progress.TotalBatches == 0 {
+		progress.TotalBatches = cgu.Status.ClusterStates.Batches
+	}
+	if !cgu.Status.Status.CurrentBatchStartedAt.IsZero() {
+		startedAt := cgu.Status.Status.CurrentBatchStartedAt
+		progress.StartedAt = &startedAt
+	}
+	for cluster, clusterProgress := range cgu.Status.Status.CurrentBatchRemediationProgress {
+		if clusterProgress == nil {
+			continue
+		}
+		progress.Clusters[clusterProgress.State]++
+		if clusterProgress.PolicyIndex != nil {
+			progress.PolicyIndex[cluster] = *clusterProgress.PolicyIndex
+		}
+	}
+	return progress
+}
+
+// WaitForCondition waits until the condition of the given type of the ClusterGroupUpgrade has the
+// given status, and returns the ClusterGroupUpgrade. It returns ErrTerminal if the ClusterGroupUpgrade
+// reaches a terminal state without the condition having the expected status, and the context error
+// if the context is done first.
+func WaitForCondition(ctx context.Context, c versioned.Interface, namespace, name, conditionType string,
+	status metav1.ConditionStatus) (*ranv1alpha1.ClusterGroupUpgrade, error) {
+
+	var cgu *ranv1alpha1.ClusterGroupUpgrade
+	err := wait.PollImmediateUntil(PollInterval, func() (bool, error) {
+		var err error
+		cgu, err = c.RanV1alpha1().ClusterGroupUpgrades(namespace).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return false, err
+		}
+		condition := meta.FindStatusCondition(cgu.Status.Conditions, conditionType)
+		if condition != nil && condition.Status == status {
+			return true, nil
+		}
+		if IsTerminal(cgu) {
+			return false, fmt.Errorf("%w without condition %s=%s", ErrTerminal, conditionType, status)
+		}
+		return false, nil
+	}, ctx.Done())
+
+	if errors.Is(err, wait.ErrWaitTimeout) && ctx.Err() != nil {
+		return cgu, ctx.Err()
+	}
+	return cgu, err
+}
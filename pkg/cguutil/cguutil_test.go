@@ -0,0 +1,159 @@
+package cguutil
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	ranv1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/api/v1alpha1"
+	"github.com/openshift-kni/cluster-group-upgrades-operator/pkg/client/clientset/versioned/fake"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestCguutil_IsTerminal(t *testing.T) {
+	testcases := []struct {
+		name              string
+		conditions        []metav1.Condition
+		expectedSucceeded bool
+		expectedTerminal  bool
+	}{
+		{
+			name: "in progress",
+			conditions: []metav1.Condition{
+				{Type: "Progressing", Status: metav1.ConditionTrue, Reason: "InProgress"},
+				{Type: "Ready", Status: metav1.ConditionFalse, Reason: "UpgradeNotCompleted"},
+			},
+		},
+		{
+			name: "succeeded",
+			conditions: []metav1.Condition{
+				{Type: "Succeeded", Status: metav1.ConditionTrue, Reason: "Completed"},
+			},
+			expectedSucceeded: true,
+			expectedTerminal:  true,
+		},
+		{
+			name: "failed",
+			conditions: []metav1.Condition{
+				{Type: "Failed", Status: metav1.ConditionTrue, Reason: "TimedOut"},
+			},
+			expectedTerminal: true,
+		},
+		{
+			name: "legacy completed",
+			conditions: []metav1.Condition{
+				{Type: "Ready", Status: metav1.ConditionTrue, Reason: "UpgradeCompleted"},
+			},
+			expectedSucceeded: true,
+			expectedTerminal:  true,
+		},
+		{
+			name: "legacy timed out",
+			conditions: []metav1.Condition{
+				{Type: "Ready", Status: metav1.ConditionFalse, Reason: "UpgradeTimedOut"},
+			},
+			expectedTerminal: true,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			cgu := &ranv1alpha1.ClusterGroupUpgrade{
+				Status: ranv1alpha1.ClusterGroupUpgradeStatus{Conditions: tc.conditions},
+			}
+			assert.Equal(t, tc.expectedSucceeded, IsSucceeded(cgu))
+			assert.Equal(t, tc.expectedTerminal, IsTerminal(cgu))
+		})
+	}
+}
+
+func TestCguutil_GetBatchProgress(t *testing.T) {
+	policyIndex := 2
+	startedAt := metav1.NewTime(time.Date(2022, 6, 1, 10, 0, 0, 0, time.UTC))
+	cgu := &ranv1alpha1.ClusterGroupUpgrade{
+		Status: ranv1alpha1.ClusterGroupUpgradeStatus{
+			RemediationPlan: [][]string{{"spoke1", "spoke2", "spoke3"}, {"spoke4"}},
+			Status: ranv1alpha1.UpgradeStatus{
+				CurrentBatch:          1,
+				CurrentBatchStartedAt: startedAt,
+				CurrentBatchRemediationProgress: map[string]*ranv1alpha1.ClusterRemediationProgress{
+					"spoke1": {State: ranv1alpha1.Completed},
+					"spoke2": {State: ranv1alpha1.InProgress, PolicyIndex: &policyIndex},
+					"spoke3": {State: ranv1alpha1.InProgress},
+				},
+			},
+		},
+	}
+
+	progress := GetBatchProgress(cgu)
+	assert.Equal(t, 1, progress.CurrentBatch)
+	assert.Equal(t, 2, progress.TotalBatches)
+	assert.Equal(t, &startedAt, progress.StartedAt)
+	assert.Equal(t, map[string]int{ranv1alpha1.Completed: 1, ranv1alpha1.InProgress: 2}, progress.Clusters)
+	assert.Equal(t, map[string]int{"spoke2": 2}, progress.PolicyIndex)
+
+	// The per-cluster state is stored in ConfigMaps
+	cgu.Status.RemediationPlan = nil
+	cgu.Status.ClusterStates = &ranv1alpha1.ClusterStatesStatus{Clusters: 4, Batches: 2}
+	assert.Equal(t, 2, GetBatchProgress(cgu).TotalBatches)
+
+	progress = GetBatchProgress(&ranv1alpha1.ClusterGroupUpgrade{})
+	assert.Equal(t, 0, progress.CurrentBatch)
+	assert.Nil(t, progress.StartedAt)
+	assert.Empty(t, progress.Clusters)
+}
+
+func TestCguutil_WaitForCondition(t *testing.T) {
+	PollInterval = 10 * time.Millisecond
+
+	testcases := []struct {
+		name          string
+		conditions    []metav1.Condition
+		conditionType string
+		expectedError error
+	}{
+		{
+			name: "condition set",
+			conditions: []metav1.Condition{
+				{Type: "Succeeded", Status: metav1.ConditionTrue, Reason: "Completed"},
+			},
+			conditionType: "Succeeded",
+		},
+		{
+			name: "terminal without the condition",
+			conditions: []metav1.Condition{
+				{Type: "Failed", Status: metav1.ConditionTrue, Reason: "TimedOut"},
+			},
+			conditionType: "Succeeded",
+			expectedError: ErrTerminal,
+		},
+		{
+			name: "timeout",
+			conditions: []metav1.Condition{
+				{Type: "Progressing", Status: metav1.ConditionTrue, Reason: "InProgress"},
+			},
+			conditionType: "Succeeded",
+			expectedError: context.DeadlineExceeded,
+		},
+	}
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			client := fake.NewSimpleClientset(&ranv1alpha1.ClusterGroupUpgrade{
+				ObjectMeta: metav1.ObjectMeta{Name: "cgu", Namespace: "default"},
+				Status:     ranv1alpha1.ClusterGroupUpgradeStatus{Conditions: tc.conditions},
+			})
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+
+			cgu, err := WaitForCondition(ctx, client, "default", "cgu", tc.conditionType, metav1.ConditionTrue)
+			if tc.expectedError == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.True(t, errors.Is(err, tc.expectedError), "unexpected error %v", err)
+			}
+			assert.NotNil(t, cgu)
+			assert.Equal(t, "cgu", cgu.Name)
+		})
+	}
+}
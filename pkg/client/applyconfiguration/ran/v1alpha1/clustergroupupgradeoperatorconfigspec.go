@@ -0,0 +1,178 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// ClusterGroupUpgradeOperatorConfigSpecApplyConfiguration represents an declarative configuration of the ClusterGroupUpgradeOperatorConfigSpec type for use
+// with apply.
+type ClusterGroupUpgradeOperatorConfigSpecApplyConfiguration struct {
+	OperatorSettingsApplyConfiguration `json:",inline"`
+	RequeueIntervals                   *RequeueIntervalsApplyConfiguration    `json:"requeueIntervals,omitempty"`
+	Concurrency                        *ConcurrencyLimitsApplyConfiguration   `json:"concurrency,omitempty"`
+	NamespaceOverrides                 []NamespaceOverridesApplyConfiguration `json:"namespaceOverrides,omitempty"`
+	ClusterStateStorage                *string                                `json:"clusterStateStorage,omitempty"`
+	ZTP                                *ZTPSettingsApplyConfiguration         `json:"ztp,omitempty"`
+	UpdateGraph                        *UpdateGraphSettingsApplyConfiguration `json:"updateGraph,omitempty"`
+}
+
+// ClusterGroupUpgradeOperatorConfigSpecApplyConfiguration constructs an declarative configuration of the ClusterGroupUpgradeOperatorConfigSpec type for use with
+// apply.
+func ClusterGroupUpgradeOperatorConfigSpec() *ClusterGroupUpgradeOperatorConfigSpecApplyConfiguration {
+	return &ClusterGroupUpgradeOperatorConfigSpecApplyConfiguration{}
+}
+
+// WithPrecacheImage sets the PrecacheImage field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PrecacheImage field is set to the value of the last call.
+func (b *ClusterGroupUpgradeOperatorConfigSpecApplyConfiguration) WithPrecacheImage(value string) *ClusterGroupUpgradeOperatorConfigSpecApplyConfiguration {
+	b.PrecacheImage = &value
+	return b
+}
+
+// WithRecoveryImage sets the RecoveryImage field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RecoveryImage field is set to the value of the last call.
+func (b *ClusterGroupUpgradeOperatorConfigSpecApplyConfiguration) WithRecoveryImage(value string) *ClusterGroupUpgradeOperatorConfigSpecApplyConfiguration {
+	b.RecoveryImage = &value
+	return b
+}
+
+// WithPlatformImage sets the PlatformImage field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PlatformImage field is set to the value of the last call.
+func (b *ClusterGroupUpgradeOperatorConfigSpecApplyConfiguration) WithPlatformImage(value string) *ClusterGroupUpgradeOperatorConfigSpecApplyConfiguration {
+	b.PlatformImage = &value
+	return b
+}
+
+// WithOperatorsIndexes adds the given value to the OperatorsIndexes field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the OperatorsIndexes field.
+func (b *ClusterGroupUpgradeOperatorConfigSpecApplyConfiguration) WithOperatorsIndexes(values ...string) *ClusterGroupUpgradeOperatorConfigSpecApplyConfiguration {
+	for i := range values {
+		b.OperatorsIndexes = append(b.OperatorsIndexes, values[i])
+	}
+	return b
+}
+
+// WithOperatorsPackagesAndChannels adds the given value to the OperatorsPackagesAndChannels field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the OperatorsPackagesAndChannels field.
+func (b *ClusterGroupUpgradeOperatorConfigSpecApplyConfiguration) WithOperatorsPackagesAndChannels(values ...string) *ClusterGroupUpgradeOperatorConfigSpecApplyConfiguration {
+	for i := range values {
+		b.OperatorsPackagesAndChannels = append(b.OperatorsPackagesAndChannels, values[i])
+	}
+	return b
+}
+
+// WithDefaultTimeout sets the DefaultTimeout field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DefaultTimeout field is set to the value of the last call.
+func (b *ClusterGroupUpgradeOperatorConfigSpecApplyConfiguration) WithDefaultTimeout(value int) *ClusterGroupUpgradeOperatorConfigSpecApplyConfiguration {
+	b.DefaultTimeout = &value
+	return b
+}
+
+// WithDefaultBatchTimeoutAction sets the DefaultBatchTimeoutAction field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DefaultBatchTimeoutAction field is set to the value of the last call.
+func (b *ClusterGroupUpgradeOperatorConfigSpecApplyConfiguration) WithDefaultBatchTimeoutAction(value string) *ClusterGroupUpgradeOperatorConfigSpecApplyConfiguration {
+	b.DefaultBatchTimeoutAction = &value
+	return b
+}
+
+// WithPrecacheJob sets the PrecacheJob field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PrecacheJob field is set to the value of the last call.
+func (b *ClusterGroupUpgradeOperatorConfigSpecApplyConfiguration) WithPrecacheJob(value *JobSettingsApplyConfiguration) *ClusterGroupUpgradeOperatorConfigSpecApplyConfiguration {
+	b.PrecacheJob = value
+	return b
+}
+
+// WithBackupJob sets the BackupJob field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the BackupJob field is set to the value of the last call.
+func (b *ClusterGroupUpgradeOperatorConfigSpecApplyConfiguration) WithBackupJob(value *JobSettingsApplyConfiguration) *ClusterGroupUpgradeOperatorConfigSpecApplyConfiguration {
+	b.BackupJob = value
+	return b
+}
+
+// WithNotificationSinks adds the given value to the NotificationSinks field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the NotificationSinks field.
+func (b *ClusterGroupUpgradeOperatorConfigSpecApplyConfiguration) WithNotificationSinks(values ...*NotificationSinkApplyConfiguration) *ClusterGroupUpgradeOperatorConfigSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithNotificationSinks")
+		}
+		b.NotificationSinks = append(b.NotificationSinks, *values[i])
+	}
+	return b
+}
+
+// WithRequeueIntervals sets the RequeueIntervals field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RequeueIntervals field is set to the value of the last call.
+func (b *ClusterGroupUpgradeOperatorConfigSpecApplyConfiguration) WithRequeueIntervals(value *RequeueIntervalsApplyConfiguration) *ClusterGroupUpgradeOperatorConfigSpecApplyConfiguration {
+	b.RequeueIntervals = value
+	return b
+}
+
+// WithConcurrency sets the Concurrency field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Concurrency field is set to the value of the last call.
+func (b *ClusterGroupUpgradeOperatorConfigSpecApplyConfiguration) WithConcurrency(value *ConcurrencyLimitsApplyConfiguration) *ClusterGroupUpgradeOperatorConfigSpecApplyConfiguration {
+	b.Concurrency = value
+	return b
+}
+
+// WithNamespaceOverrides adds the given value to the NamespaceOverrides field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the NamespaceOverrides field.
+func (b *ClusterGroupUpgradeOperatorConfigSpecApplyConfiguration) WithNamespaceOverrides(values ...*NamespaceOverridesApplyConfiguration) *ClusterGroupUpgradeOperatorConfigSpecApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithNamespaceOverrides")
+		}
+		b.NamespaceOverrides = append(b.NamespaceOverrides, *values[i])
+	}
+	return b
+}
+
+// WithClusterStateStorage sets the ClusterStateStorage field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ClusterStateStorage field is set to the value of the last call.
+func (b *ClusterGroupUpgradeOperatorConfigSpecApplyConfiguration) WithClusterStateStorage(value string) *ClusterGroupUpgradeOperatorConfigSpecApplyConfiguration {
+	b.ClusterStateStorage = &value
+	return b
+}
+
+// WithZTP sets the ZTP field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ZTP field is set to the value of the last call.
+func (b *ClusterGroupUpgradeOperatorConfigSpecApplyConfiguration) WithZTP(value *ZTPSettingsApplyConfiguration) *ClusterGroupUpgradeOperatorConfigSpecApplyConfiguration {
+	b.ZTP = value
+	return b
+}
+
+// WithUpdateGraph sets the UpdateGraph field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the UpdateGraph field is set to the value of the last call.
+func (b *ClusterGroupUpgradeOperatorConfigSpecApplyConfiguration) WithUpdateGraph(value *UpdateGraphSettingsApplyConfiguration) *ClusterGroupUpgradeOperatorConfigSpecApplyConfiguration {
+	b.UpdateGraph = value
+	return b
+}
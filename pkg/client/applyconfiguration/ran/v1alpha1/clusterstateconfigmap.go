@@ -0,0 +1,47 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// ClusterStateConfigMapApplyConfiguration represents an declarative configuration of the ClusterStateConfigMap type for use
+// with apply.
+type ClusterStateConfigMapApplyConfiguration struct {
+	Name            *string `json:"name,omitempty"`
+	ResourceVersion *string `json:"resourceVersion,omitempty"`
+}
+
+// ClusterStateConfigMapApplyConfiguration constructs an declarative configuration of the ClusterStateConfigMap type for use with
+// apply.
+func ClusterStateConfigMap() *ClusterStateConfigMapApplyConfiguration {
+	return &ClusterStateConfigMapApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *ClusterStateConfigMapApplyConfiguration) WithName(value string) *ClusterStateConfigMapApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithResourceVersion sets the ResourceVersion field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ResourceVersion field is set to the value of the last call.
+func (b *ClusterStateConfigMapApplyConfiguration) WithResourceVersion(value string) *ClusterStateConfigMapApplyConfiguration {
+	b.ResourceVersion = &value
+	return b
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// ConcurrencyLimitsApplyConfiguration represents an declarative configuration of the ConcurrencyLimits type for use
+// with apply.
+type ConcurrencyLimitsApplyConfiguration struct {
+	MaxConcurrentRemediations *int `json:"maxConcurrentRemediations,omitempty"`
+	MaxConcurrentPrecaching   *int `json:"maxConcurrentPrecaching,omitempty"`
+	MaxConcurrentBackups      *int `json:"maxConcurrentBackups,omitempty"`
+}
+
+// ConcurrencyLimitsApplyConfiguration constructs an declarative configuration of the ConcurrencyLimits type for use with
+// apply.
+func ConcurrencyLimits() *ConcurrencyLimitsApplyConfiguration {
+	return &ConcurrencyLimitsApplyConfiguration{}
+}
+
+// WithMaxConcurrentRemediations sets the MaxConcurrentRemediations field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MaxConcurrentRemediations field is set to the value of the last call.
+func (b *ConcurrencyLimitsApplyConfiguration) WithMaxConcurrentRemediations(value int) *ConcurrencyLimitsApplyConfiguration {
+	b.MaxConcurrentRemediations = &value
+	return b
+}
+
+// WithMaxConcurrentPrecaching sets the MaxConcurrentPrecaching field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MaxConcurrentPrecaching field is set to the value of the last call.
+func (b *ConcurrencyLimitsApplyConfiguration) WithMaxConcurrentPrecaching(value int) *ConcurrencyLimitsApplyConfiguration {
+	b.MaxConcurrentPrecaching = &value
+	return b
+}
+
+// WithMaxConcurrentBackups sets the MaxConcurrentBackups field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MaxConcurrentBackups field is set to the value of the last call.
+func (b *ConcurrencyLimitsApplyConfiguration) WithMaxConcurrentBackups(value int) *ConcurrencyLimitsApplyConfiguration {
+	b.MaxConcurrentBackups = &value
+	return b
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// NamespaceOverridesApplyConfiguration represents an declarative configuration of the NamespaceOverrides type for use
+// with apply.
+type NamespaceOverridesApplyConfiguration struct {
+	Namespace                          *string `json:"namespace,omitempty"`
+	OperatorSettingsApplyConfiguration `json:",inline"`
+}
+
+// NamespaceOverridesApplyConfiguration constructs an declarative configuration of the NamespaceOverrides type for use with
+// apply.
+func NamespaceOverrides() *NamespaceOverridesApplyConfiguration {
+	return &NamespaceOverridesApplyConfiguration{}
+}
+
+// WithNamespace sets the Namespace field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Namespace field is set to the value of the last call.
+func (b *NamespaceOverridesApplyConfiguration) WithNamespace(value string) *NamespaceOverridesApplyConfiguration {
+	b.Namespace = &value
+	return b
+}
+
+// WithPrecacheImage sets the PrecacheImage field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PrecacheImage field is set to the value of the last call.
+func (b *NamespaceOverridesApplyConfiguration) WithPrecacheImage(value string) *NamespaceOverridesApplyConfiguration {
+	b.PrecacheImage = &value
+	return b
+}
+
+// WithRecoveryImage sets the RecoveryImage field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RecoveryImage field is set to the value of the last call.
+func (b *NamespaceOverridesApplyConfiguration) WithRecoveryImage(value string) *NamespaceOverridesApplyConfiguration {
+	b.RecoveryImage = &value
+	return b
+}
+
+// WithPlatformImage sets the PlatformImage field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PlatformImage field is set to the value of the last call.
+func (b *NamespaceOverridesApplyConfiguration) WithPlatformImage(value string) *NamespaceOverridesApplyConfiguration {
+	b.PlatformImage = &value
+	return b
+}
+
+// WithOperatorsIndexes adds the given value to the OperatorsIndexes field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the OperatorsIndexes field.
+func (b *NamespaceOverridesApplyConfiguration) WithOperatorsIndexes(values ...string) *NamespaceOverridesApplyConfiguration {
+	for i := range values {
+		b.OperatorsIndexes = append(b.OperatorsIndexes, values[i])
+	}
+	return b
+}
+
+// WithOperatorsPackagesAndChannels adds the given value to the OperatorsPackagesAndChannels field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the OperatorsPackagesAndChannels field.
+func (b *NamespaceOverridesApplyConfiguration) WithOperatorsPackagesAndChannels(values ...string) *NamespaceOverridesApplyConfiguration {
+	for i := range values {
+		b.OperatorsPackagesAndChannels = append(b.OperatorsPackagesAndChannels, values[i])
+	}
+	return b
+}
+
+// WithDefaultTimeout sets the DefaultTimeout field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DefaultTimeout field is set to the value of the last call.
+func (b *NamespaceOverridesApplyConfiguration) WithDefaultTimeout(value int) *NamespaceOverridesApplyConfiguration {
+	b.DefaultTimeout = &value
+	return b
+}
+
+// WithDefaultBatchTimeoutAction sets the DefaultBatchTimeoutAction field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DefaultBatchTimeoutAction field is set to the value of the last call.
+func (b *NamespaceOverridesApplyConfiguration) WithDefaultBatchTimeoutAction(value string) *NamespaceOverridesApplyConfiguration {
+	b.DefaultBatchTimeoutAction = &value
+	return b
+}
+
+// WithPrecacheJob sets the PrecacheJob field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PrecacheJob field is set to the value of the last call.
+func (b *NamespaceOverridesApplyConfiguration) WithPrecacheJob(value *JobSettingsApplyConfiguration) *NamespaceOverridesApplyConfiguration {
+	b.PrecacheJob = value
+	return b
+}
+
+// WithBackupJob sets the BackupJob field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the BackupJob field is set to the value of the last call.
+func (b *NamespaceOverridesApplyConfiguration) WithBackupJob(value *JobSettingsApplyConfiguration) *NamespaceOverridesApplyConfiguration {
+	b.BackupJob = value
+	return b
+}
+
+// WithNotificationSinks adds the given value to the NotificationSinks field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the NotificationSinks field.
+func (b *NamespaceOverridesApplyConfiguration) WithNotificationSinks(values ...*NotificationSinkApplyConfiguration) *NamespaceOverridesApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithNotificationSinks")
+		}
+		b.NotificationSinks = append(b.NotificationSinks, *values[i])
+	}
+	return b
+}
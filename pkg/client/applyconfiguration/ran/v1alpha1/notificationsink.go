@@ -0,0 +1,58 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// NotificationSinkApplyConfiguration represents an declarative configuration of the NotificationSink type for use
+// with apply.
+type NotificationSinkApplyConfiguration struct {
+	Name    *string  `json:"name,omitempty"`
+	URL     *string  `json:"url,omitempty"`
+	Reasons []string `json:"reasons,omitempty"`
+}
+
+// NotificationSinkApplyConfiguration constructs an declarative configuration of the NotificationSink type for use with
+// apply.
+func NotificationSink() *NotificationSinkApplyConfiguration {
+	return &NotificationSinkApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *NotificationSinkApplyConfiguration) WithName(value string) *NotificationSinkApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithURL sets the URL field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the URL field is set to the value of the last call.
+func (b *NotificationSinkApplyConfiguration) WithURL(value string) *NotificationSinkApplyConfiguration {
+	b.URL = &value
+	return b
+}
+
+// WithReasons adds the given value to the Reasons field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Reasons field.
+func (b *NotificationSinkApplyConfiguration) WithReasons(values ...string) *NotificationSinkApplyConfiguration {
+	for i := range values {
+		b.Reasons = append(b.Reasons, values[i])
+	}
+	return b
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// OperatorSettingsApplyConfiguration represents an declarative configuration of the OperatorSettings type for use
+// with apply.
+type OperatorSettingsApplyConfiguration struct {
+	PrecacheImage                *string                              `json:"precacheImage,omitempty"`
+	RecoveryImage                *string                              `json:"recoveryImage,omitempty"`
+	PlatformImage                *string                              `json:"platformImage,omitempty"`
+	OperatorsIndexes             []string                             `json:"operatorsIndexes,omitempty"`
+	OperatorsPackagesAndChannels []string                             `json:"operatorsPackagesAndChannels,omitempty"`
+	DefaultTimeout               *int                                 `json:"defaultTimeout,omitempty"`
+	DefaultBatchTimeoutAction    *string                              `json:"defaultBatchTimeoutAction,omitempty"`
+	PrecacheJob                  *JobSettingsApplyConfiguration       `json:"precacheJob,omitempty"`
+	BackupJob                    *JobSettingsApplyConfiguration       `json:"backupJob,omitempty"`
+	NotificationSinks            []NotificationSinkApplyConfiguration `json:"notificationSinks,omitempty"`
+}
+
+// OperatorSettingsApplyConfiguration constructs an declarative configuration of the OperatorSettings type for use with
+// apply.
+func OperatorSettings() *OperatorSettingsApplyConfiguration {
+	return &OperatorSettingsApplyConfiguration{}
+}
+
+// WithPrecacheImage sets the PrecacheImage field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PrecacheImage field is set to the value of the last call.
+func (b *OperatorSettingsApplyConfiguration) WithPrecacheImage(value string) *OperatorSettingsApplyConfiguration {
+	b.PrecacheImage = &value
+	return b
+}
+
+// WithRecoveryImage sets the RecoveryImage field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RecoveryImage field is set to the value of the last call.
+func (b *OperatorSettingsApplyConfiguration) WithRecoveryImage(value string) *OperatorSettingsApplyConfiguration {
+	b.RecoveryImage = &value
+	return b
+}
+
+// WithPlatformImage sets the PlatformImage field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PlatformImage field is set to the value of the last call.
+func (b *OperatorSettingsApplyConfiguration) WithPlatformImage(value string) *OperatorSettingsApplyConfiguration {
+	b.PlatformImage = &value
+	return b
+}
+
+// WithOperatorsIndexes adds the given value to the OperatorsIndexes field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the OperatorsIndexes field.
+func (b *OperatorSettingsApplyConfiguration) WithOperatorsIndexes(values ...string) *OperatorSettingsApplyConfiguration {
+	for i := range values {
+		b.OperatorsIndexes = append(b.OperatorsIndexes, values[i])
+	}
+	return b
+}
+
+// WithOperatorsPackagesAndChannels adds the given value to the OperatorsPackagesAndChannels field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the OperatorsPackagesAndChannels field.
+func (b *OperatorSettingsApplyConfiguration) WithOperatorsPackagesAndChannels(values ...string) *OperatorSettingsApplyConfiguration {
+	for i := range values {
+		b.OperatorsPackagesAndChannels = append(b.OperatorsPackagesAndChannels, values[i])
+	}
+	return b
+}
+
+// WithDefaultTimeout sets the DefaultTimeout field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DefaultTimeout field is set to the value of the last call.
+func (b *OperatorSettingsApplyConfiguration) WithDefaultTimeout(value int) *OperatorSettingsApplyConfiguration {
+	b.DefaultTimeout = &value
+	return b
+}
+
+// WithDefaultBatchTimeoutAction sets the DefaultBatchTimeoutAction field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the DefaultBatchTimeoutAction field is set to the value of the last call.
+func (b *OperatorSettingsApplyConfiguration) WithDefaultBatchTimeoutAction(value string) *OperatorSettingsApplyConfiguration {
+	b.DefaultBatchTimeoutAction = &value
+	return b
+}
+
+// WithPrecacheJob sets the PrecacheJob field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PrecacheJob field is set to the value of the last call.
+func (b *OperatorSettingsApplyConfiguration) WithPrecacheJob(value *JobSettingsApplyConfiguration) *OperatorSettingsApplyConfiguration {
+	b.PrecacheJob = value
+	return b
+}
+
+// WithBackupJob sets the BackupJob field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the BackupJob field is set to the value of the last call.
+func (b *OperatorSettingsApplyConfiguration) WithBackupJob(value *JobSettingsApplyConfiguration) *OperatorSettingsApplyConfiguration {
+	b.BackupJob = value
+	return b
+}
+
+// WithNotificationSinks adds the given value to the NotificationSinks field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the NotificationSinks field.
+func (b *OperatorSettingsApplyConfiguration) WithNotificationSinks(values ...*NotificationSinkApplyConfiguration) *OperatorSettingsApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithNotificationSinks")
+		}
+		b.NotificationSinks = append(b.NotificationSinks, *values[i])
+	}
+	return b
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	ranv1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/api/v1alpha1"
+)
+
+// PrecachingStatusApplyConfiguration represents an declarative configuration of the PrecachingStatus type for use
+// with apply.
+type PrecachingStatusApplyConfiguration struct {
+	Spec         *PrecachingSpecApplyConfiguration          `json:"spec,omitempty"`
+	Status       map[string]string                          `json:"status,omitempty"`
+	Clusters     []string                                   `json:"clusters,omitempty"`
+	Counts       map[string]int                             `json:"counts,omitempty"`
+	Attempts     map[string]*ranv1alpha1.PrecachingAttempts `json:"attempts,omitempty"`
+	RetryTrigger *string                                    `json:"retryTrigger,omitempty"`
+	Summaries    map[string]*ranv1alpha1.PrecachingSummary  `json:"summaries,omitempty"`
+	Specs        map[string]*ranv1alpha1.PrecachingSpec     `json:"specs,omitempty"`
+	ClusterSpecs map[string]string                          `json:"clusterSpecs,omitempty"`
+}
+
+// PrecachingStatusApplyConfiguration constructs an declarative configuration of the PrecachingStatus type for use with
+// apply.
+func PrecachingStatus() *PrecachingStatusApplyConfiguration {
+	return &PrecachingStatusApplyConfiguration{}
+}
+
+// WithSpec sets the Spec field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Spec field is set to the value of the last call.
+func (b *PrecachingStatusApplyConfiguration) WithSpec(value *PrecachingSpecApplyConfiguration) *PrecachingStatusApplyConfiguration {
+	b.Spec = value
+	return b
+}
+
+// WithStatus puts the entries into the Status field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the Status field,
+// overwriting an existing map entries in Status field with the same key.
+func (b *PrecachingStatusApplyConfiguration) WithStatus(entries map[string]string) *PrecachingStatusApplyConfiguration {
+	if b.Status == nil && len(entries) > 0 {
+		b.Status = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.Status[k] = v
+	}
+	return b
+}
+
+// WithClusters adds the given value to the Clusters field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Clusters field.
+func (b *PrecachingStatusApplyConfiguration) WithClusters(values ...string) *PrecachingStatusApplyConfiguration {
+	for i := range values {
+		b.Clusters = append(b.Clusters, values[i])
+	}
+	return b
+}
+
+// WithCounts puts the entries into the Counts field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the Counts field,
+// overwriting an existing map entries in Counts field with the same key.
+func (b *PrecachingStatusApplyConfiguration) WithCounts(entries map[string]int) *PrecachingStatusApplyConfiguration {
+	if b.Counts == nil && len(entries) > 0 {
+		b.Counts = make(map[string]int, len(entries))
+	}
+	for k, v := range entries {
+		b.Counts[k] = v
+	}
+	return b
+}
+
+// WithAttempts puts the entries into the Attempts field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the Attempts field,
+// overwriting an existing map entries in Attempts field with the same key.
+func (b *PrecachingStatusApplyConfiguration) WithAttempts(entries map[string]*ranv1alpha1.PrecachingAttempts) *PrecachingStatusApplyConfiguration {
+	if b.Attempts == nil && len(entries) > 0 {
+		b.Attempts = make(map[string]*ranv1alpha1.PrecachingAttempts, len(entries))
+	}
+	for k, v := range entries {
+		b.Attempts[k] = v
+	}
+	return b
+}
+
+// WithRetryTrigger sets the RetryTrigger field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RetryTrigger field is set to the value of the last call.
+func (b *PrecachingStatusApplyConfiguration) WithRetryTrigger(value string) *PrecachingStatusApplyConfiguration {
+	b.RetryTrigger = &value
+	return b
+}
+
+// WithSummaries puts the entries into the Summaries field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the Summaries field,
+// overwriting an existing map entries in Summaries field with the same key.
+func (b *PrecachingStatusApplyConfiguration) WithSummaries(entries map[string]*ranv1alpha1.PrecachingSummary) *PrecachingStatusApplyConfiguration {
+	if b.Summaries == nil && len(entries) > 0 {
+		b.Summaries = make(map[string]*ranv1alpha1.PrecachingSummary, len(entries))
+	}
+	for k, v := range entries {
+		b.Summaries[k] = v
+	}
+	return b
+}
+
+// WithSpecs puts the entries into the Specs field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the Specs field,
+// overwriting an existing map entries in Specs field with the same key.
+func (b *PrecachingStatusApplyConfiguration) WithSpecs(entries map[string]*ranv1alpha1.PrecachingSpec) *PrecachingStatusApplyConfiguration {
+	if b.Specs == nil && len(entries) > 0 {
+		b.Specs = make(map[string]*ranv1alpha1.PrecachingSpec, len(entries))
+	}
+	for k, v := range entries {
+		b.Specs[k] = v
+	}
+	return b
+}
+
+// WithClusterSpecs puts the entries into the ClusterSpecs field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the ClusterSpecs field,
+// overwriting an existing map entries in ClusterSpecs field with the same key.
+func (b *PrecachingStatusApplyConfiguration) WithClusterSpecs(entries map[string]string) *PrecachingStatusApplyConfiguration {
+	if b.ClusterSpecs == nil && len(entries) > 0 {
+		b.ClusterSpecs = make(map[string]string, len(entries))
+	}
+	for k, v := range entries {
+		b.ClusterSpecs[k] = v
+	}
+	return b
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+// RemediationStrategySpecApplyConfiguration represents an declarative configuration of the RemediationStrategySpec type for use
+// with apply.
+type RemediationStrategySpecApplyConfiguration struct {
+	Canaries       []string `json:"canaries,omitempty"`
+	MaxConcurrency *int     `json:"maxConcurrency,omitempty"`
+	Timeout        *int     `json:"timeout,omitempty"`
+	ParallelWaves  *bool    `json:"parallelWaves,omitempty"`
+}
+
+// RemediationStrategySpecApplyConfiguration constructs an declarative configuration of the RemediationStrategySpec type for use with
+// apply.
+func RemediationStrategySpec() *RemediationStrategySpecApplyConfiguration {
+	return &RemediationStrategySpecApplyConfiguration{}
+}
+
+// WithCanaries adds the given value to the Canaries field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Canaries field.
+func (b *RemediationStrategySpecApplyConfiguration) WithCanaries(values ...string) *RemediationStrategySpecApplyConfiguration {
+	for i := range values {
+		b.Canaries = append(b.Canaries, values[i])
+	}
+	return b
+}
+
+// WithMaxConcurrency sets the MaxConcurrency field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MaxConcurrency field is set to the value of the last call.
+func (b *RemediationStrategySpecApplyConfiguration) WithMaxConcurrency(value int) *RemediationStrategySpecApplyConfiguration {
+	b.MaxConcurrency = &value
+	return b
+}
+
+// WithTimeout sets the Timeout field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Timeout field is set to the value of the last call.
+func (b *RemediationStrategySpecApplyConfiguration) WithTimeout(value int) *RemediationStrategySpecApplyConfiguration {
+	b.Timeout = &value
+	return b
+}
+
+// WithParallelWaves sets the ParallelWaves field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the ParallelWaves field is set to the value of the last call.
+func (b *RemediationStrategySpecApplyConfiguration) WithParallelWaves(value bool) *RemediationStrategySpecApplyConfiguration {
+	b.ParallelWaves = &value
+	return b
+}
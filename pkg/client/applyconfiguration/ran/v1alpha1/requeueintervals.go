@@ -0,0 +1,60 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// RequeueIntervalsApplyConfiguration represents an declarative configuration of the RequeueIntervals type for use
+// with apply.
+type RequeueIntervalsApplyConfiguration struct {
+	Short  *v1.Duration `json:"short,omitempty"`
+	Medium *v1.Duration `json:"medium,omitempty"`
+	Long   *v1.Duration `json:"long,omitempty"`
+}
+
+// RequeueIntervalsApplyConfiguration constructs an declarative configuration of the RequeueIntervals type for use with
+// apply.
+func RequeueIntervals() *RequeueIntervalsApplyConfiguration {
+	return &RequeueIntervalsApplyConfiguration{}
+}
+
+// WithShort sets the Short field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Short field is set to the value of the last call.
+func (b *RequeueIntervalsApplyConfiguration) WithShort(value v1.Duration) *RequeueIntervalsApplyConfiguration {
+	b.Short = &value
+	return b
+}
+
+// WithMedium sets the Medium field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Medium field is set to the value of the last call.
+func (b *RequeueIntervalsApplyConfiguration) WithMedium(value v1.Duration) *RequeueIntervalsApplyConfiguration {
+	b.Medium = &value
+	return b
+}
+
+// WithLong sets the Long field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Long field is set to the value of the last call.
+func (b *RequeueIntervalsApplyConfiguration) WithLong(value v1.Duration) *RequeueIntervalsApplyConfiguration {
+	b.Long = &value
+	return b
+}
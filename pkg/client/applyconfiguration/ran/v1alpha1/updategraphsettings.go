@@ -0,0 +1,78 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// UpdateGraphSettingsApplyConfiguration represents an declarative configuration of the UpdateGraphSettings type for use
+// with apply.
+type UpdateGraphSettingsApplyConfiguration struct {
+	CABundle *ConfigMapReferenceApplyConfiguration `json:"caBundle,omitempty"`
+	Proxy    *string                               `json:"proxy,omitempty"`
+	Timeout  *v1.Duration                          `json:"timeout,omitempty"`
+	CacheTTL *v1.Duration                          `json:"cacheTTL,omitempty"`
+	Offline  *ConfigMapReferenceApplyConfiguration `json:"offline,omitempty"`
+}
+
+// UpdateGraphSettingsApplyConfiguration constructs an declarative configuration of the UpdateGraphSettings type for use with
+// apply.
+func UpdateGraphSettings() *UpdateGraphSettingsApplyConfiguration {
+	return &UpdateGraphSettingsApplyConfiguration{}
+}
+
+// WithCABundle sets the CABundle field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CABundle field is set to the value of the last call.
+func (b *UpdateGraphSettingsApplyConfiguration) WithCABundle(value *ConfigMapReferenceApplyConfiguration) *UpdateGraphSettingsApplyConfiguration {
+	b.CABundle = value
+	return b
+}
+
+// WithProxy sets the Proxy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Proxy field is set to the value of the last call.
+func (b *UpdateGraphSettingsApplyConfiguration) WithProxy(value string) *UpdateGraphSettingsApplyConfiguration {
+	b.Proxy = &value
+	return b
+}
+
+// WithTimeout sets the Timeout field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Timeout field is set to the value of the last call.
+func (b *UpdateGraphSettingsApplyConfiguration) WithTimeout(value v1.Duration) *UpdateGraphSettingsApplyConfiguration {
+	b.Timeout = &value
+	return b
+}
+
+// WithCacheTTL sets the CacheTTL field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the CacheTTL field is set to the value of the last call.
+func (b *UpdateGraphSettingsApplyConfiguration) WithCacheTTL(value v1.Duration) *UpdateGraphSettingsApplyConfiguration {
+	b.CacheTTL = &value
+	return b
+}
+
+// WithOffline sets the Offline field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Offline field is set to the value of the last call.
+func (b *UpdateGraphSettingsApplyConfiguration) WithOffline(value *ConfigMapReferenceApplyConfiguration) *UpdateGraphSettingsApplyConfiguration {
+	b.Offline = value
+	return b
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+// ActionsApplyConfiguration represents an declarative configuration of the Actions type for use
+// with apply.
+type ActionsApplyConfiguration struct {
+	BeforeEnable           *ClusterLabelActionsApplyConfiguration `json:"beforeEnable,omitempty"`
+	AfterClusterCompletion *ClusterLabelActionsApplyConfiguration `json:"afterClusterCompletion,omitempty"`
+	AfterCompletion        *AfterCompletionApplyConfiguration     `json:"afterCompletion,omitempty"`
+}
+
+// ActionsApplyConfiguration constructs an declarative configuration of the Actions type for use with
+// apply.
+func Actions() *ActionsApplyConfiguration {
+	return &ActionsApplyConfiguration{}
+}
+
+// WithBeforeEnable sets the BeforeEnable field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the BeforeEnable field is set to the value of the last call.
+func (b *ActionsApplyConfiguration) WithBeforeEnable(value *ClusterLabelActionsApplyConfiguration) *ActionsApplyConfiguration {
+	b.BeforeEnable = value
+	return b
+}
+
+// WithAfterClusterCompletion sets the AfterClusterCompletion field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the AfterClusterCompletion field is set to the value of the last call.
+func (b *ActionsApplyConfiguration) WithAfterClusterCompletion(value *ClusterLabelActionsApplyConfiguration) *ActionsApplyConfiguration {
+	b.AfterClusterCompletion = value
+	return b
+}
+
+// WithAfterCompletion sets the AfterCompletion field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the AfterCompletion field is set to the value of the last call.
+func (b *ActionsApplyConfiguration) WithAfterCompletion(value *AfterCompletionApplyConfiguration) *ActionsApplyConfiguration {
+	b.AfterCompletion = value
+	return b
+}
@@ -0,0 +1,54 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+// BackupStatusApplyConfiguration represents an declarative configuration of the BackupStatus type for use
+// with apply.
+type BackupStatusApplyConfiguration struct {
+	Status   []ClusterStateApplyConfiguration `json:"status,omitempty"`
+	Clusters []string                         `json:"clusters,omitempty"`
+}
+
+// BackupStatusApplyConfiguration constructs an declarative configuration of the BackupStatus type for use with
+// apply.
+func BackupStatus() *BackupStatusApplyConfiguration {
+	return &BackupStatusApplyConfiguration{}
+}
+
+// WithStatus adds the given value to the Status field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Status field.
+func (b *BackupStatusApplyConfiguration) WithStatus(values ...*ClusterStateApplyConfiguration) *BackupStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithStatus")
+		}
+		b.Status = append(b.Status, *values[i])
+	}
+	return b
+}
+
+// WithClusters adds the given value to the Clusters field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Clusters field.
+func (b *BackupStatusApplyConfiguration) WithClusters(values ...string) *BackupStatusApplyConfiguration {
+	for i := range values {
+		b.Clusters = append(b.Clusters, values[i])
+	}
+	return b
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+// ClusterRemediationProgressApplyConfiguration represents an declarative configuration of the ClusterRemediationProgress type for use
+// with apply.
+type ClusterRemediationProgressApplyConfiguration struct {
+	Name        *string `json:"name,omitempty"`
+	State       *string `json:"state,omitempty"`
+	PolicyIndex *int    `json:"policyIndex,omitempty"`
+	LockedBy    *string `json:"lockedBy,omitempty"`
+}
+
+// ClusterRemediationProgressApplyConfiguration constructs an declarative configuration of the ClusterRemediationProgress type for use with
+// apply.
+func ClusterRemediationProgress() *ClusterRemediationProgressApplyConfiguration {
+	return &ClusterRemediationProgressApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *ClusterRemediationProgressApplyConfiguration) WithName(value string) *ClusterRemediationProgressApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithState sets the State field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the State field is set to the value of the last call.
+func (b *ClusterRemediationProgressApplyConfiguration) WithState(value string) *ClusterRemediationProgressApplyConfiguration {
+	b.State = &value
+	return b
+}
+
+// WithPolicyIndex sets the PolicyIndex field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PolicyIndex field is set to the value of the last call.
+func (b *ClusterRemediationProgressApplyConfiguration) WithPolicyIndex(value int) *ClusterRemediationProgressApplyConfiguration {
+	b.PolicyIndex = &value
+	return b
+}
+
+// WithLockedBy sets the LockedBy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LockedBy field is set to the value of the last call.
+func (b *ClusterRemediationProgressApplyConfiguration) WithLockedBy(value string) *ClusterRemediationProgressApplyConfiguration {
+	b.LockedBy = &value
+	return b
+}
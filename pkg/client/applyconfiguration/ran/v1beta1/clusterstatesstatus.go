@@ -0,0 +1,91 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+// ClusterStatesStatusApplyConfiguration represents an declarative configuration of the ClusterStatesStatus type for use
+// with apply.
+type ClusterStatesStatusApplyConfiguration struct {
+	ConfigMaps []ClusterStateConfigMapApplyConfiguration `json:"configMaps,omitempty"`
+	Clusters   *int                                      `json:"clusters,omitempty"`
+	Batches    *int                                      `json:"batches,omitempty"`
+	Precaching map[string]int                            `json:"precaching,omitempty"`
+	Backup     map[string]int                            `json:"backup,omitempty"`
+}
+
+// ClusterStatesStatusApplyConfiguration constructs an declarative configuration of the ClusterStatesStatus type for use with
+// apply.
+func ClusterStatesStatus() *ClusterStatesStatusApplyConfiguration {
+	return &ClusterStatesStatusApplyConfiguration{}
+}
+
+// WithConfigMaps adds the given value to the ConfigMaps field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ConfigMaps field.
+func (b *ClusterStatesStatusApplyConfiguration) WithConfigMaps(values ...*ClusterStateConfigMapApplyConfiguration) *ClusterStatesStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithConfigMaps")
+		}
+		b.ConfigMaps = append(b.ConfigMaps, *values[i])
+	}
+	return b
+}
+
+// WithClusters sets the Clusters field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Clusters field is set to the value of the last call.
+func (b *ClusterStatesStatusApplyConfiguration) WithClusters(value int) *ClusterStatesStatusApplyConfiguration {
+	b.Clusters = &value
+	return b
+}
+
+// WithBatches sets the Batches field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Batches field is set to the value of the last call.
+func (b *ClusterStatesStatusApplyConfiguration) WithBatches(value int) *ClusterStatesStatusApplyConfiguration {
+	b.Batches = &value
+	return b
+}
+
+// WithPrecaching puts the entries into the Precaching field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the Precaching field,
+// overwriting an existing map entries in Precaching field with the same key.
+func (b *ClusterStatesStatusApplyConfiguration) WithPrecaching(entries map[string]int) *ClusterStatesStatusApplyConfiguration {
+	if b.Precaching == nil && len(entries) > 0 {
+		b.Precaching = make(map[string]int, len(entries))
+	}
+	for k, v := range entries {
+		b.Precaching[k] = v
+	}
+	return b
+}
+
+// WithBackup puts the entries into the Backup field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the Backup field,
+// overwriting an existing map entries in Backup field with the same key.
+func (b *ClusterStatesStatusApplyConfiguration) WithBackup(entries map[string]int) *ClusterStatesStatusApplyConfiguration {
+	if b.Backup == nil && len(entries) > 0 {
+		b.Backup = make(map[string]int, len(entries))
+	}
+	for k, v := range entries {
+		b.Backup[k] = v
+	}
+	return b
+}
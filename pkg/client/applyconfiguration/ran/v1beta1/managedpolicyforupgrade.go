@@ -0,0 +1,55 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+// ManagedPolicyForUpgradeApplyConfiguration represents an declarative configuration of the ManagedPolicyForUpgrade type for use
+// with apply.
+type ManagedPolicyForUpgradeApplyConfiguration struct {
+	PolicyReferenceApplyConfiguration `json:",inline"`
+	Wave                              *int `json:"wave,omitempty"`
+}
+
+// ManagedPolicyForUpgradeApplyConfiguration constructs an declarative configuration of the ManagedPolicyForUpgrade type for use with
+// apply.
+func ManagedPolicyForUpgrade() *ManagedPolicyForUpgradeApplyConfiguration {
+	return &ManagedPolicyForUpgradeApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *ManagedPolicyForUpgradeApplyConfiguration) WithName(value string) *ManagedPolicyForUpgradeApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithNamespace sets the Namespace field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Namespace field is set to the value of the last call.
+func (b *ManagedPolicyForUpgradeApplyConfiguration) WithNamespace(value string) *ManagedPolicyForUpgradeApplyConfiguration {
+	b.Namespace = &value
+	return b
+}
+
+// WithWave sets the Wave field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Wave field is set to the value of the last call.
+func (b *ManagedPolicyForUpgradeApplyConfiguration) WithWave(value int) *ManagedPolicyForUpgradeApplyConfiguration {
+	b.Wave = &value
+	return b
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+// ManagedPolicyStatusApplyConfiguration represents an declarative configuration of the ManagedPolicyStatus type for use
+// with apply.
+type ManagedPolicyStatusApplyConfiguration struct {
+	PolicyReferenceApplyConfiguration `json:",inline"`
+	Content                           []PolicyContentApplyConfiguration `json:"content,omitempty"`
+}
+
+// ManagedPolicyStatusApplyConfiguration constructs an declarative configuration of the ManagedPolicyStatus type for use with
+// apply.
+func ManagedPolicyStatus() *ManagedPolicyStatusApplyConfiguration {
+	return &ManagedPolicyStatusApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *ManagedPolicyStatusApplyConfiguration) WithName(value string) *ManagedPolicyStatusApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithNamespace sets the Namespace field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Namespace field is set to the value of the last call.
+func (b *ManagedPolicyStatusApplyConfiguration) WithNamespace(value string) *ManagedPolicyStatusApplyConfiguration {
+	b.Namespace = &value
+	return b
+}
+
+// WithContent adds the given value to the Content field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Content field.
+func (b *ManagedPolicyStatusApplyConfiguration) WithContent(values ...*PolicyContentApplyConfiguration) *ManagedPolicyStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithContent")
+		}
+		b.Content = append(b.Content, *values[i])
+	}
+	return b
+}
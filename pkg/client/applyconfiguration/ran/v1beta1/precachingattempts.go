@@ -0,0 +1,60 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+import (
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// PrecachingAttemptsApplyConfiguration represents an declarative configuration of the PrecachingAttempts type for use
+// with apply.
+type PrecachingAttemptsApplyConfiguration struct {
+	Name            *string  `json:"name,omitempty"`
+	Count           *int     `json:"count,omitempty"`
+	LastFailureTime *v1.Time `json:"lastFailureTime,omitempty"`
+}
+
+// PrecachingAttemptsApplyConfiguration constructs an declarative configuration of the PrecachingAttempts type for use with
+// apply.
+func PrecachingAttempts() *PrecachingAttemptsApplyConfiguration {
+	return &PrecachingAttemptsApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *PrecachingAttemptsApplyConfiguration) WithName(value string) *PrecachingAttemptsApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithCount sets the Count field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Count field is set to the value of the last call.
+func (b *PrecachingAttemptsApplyConfiguration) WithCount(value int) *PrecachingAttemptsApplyConfiguration {
+	b.Count = &value
+	return b
+}
+
+// WithLastFailureTime sets the LastFailureTime field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the LastFailureTime field is set to the value of the last call.
+func (b *PrecachingAttemptsApplyConfiguration) WithLastFailureTime(value v1.Time) *PrecachingAttemptsApplyConfiguration {
+	b.LastFailureTime = &value
+	return b
+}
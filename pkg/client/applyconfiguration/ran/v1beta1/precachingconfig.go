@@ -0,0 +1,136 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+// PreCachingConfigApplyConfiguration represents an declarative configuration of the PreCachingConfig type for use
+// with apply.
+type PreCachingConfigApplyConfiguration struct {
+	MaxConcurrency               *int                                     `json:"maxConcurrency,omitempty"`
+	PullRateLimit                *int                                     `json:"pullRateLimit,omitempty"`
+	Retry                        *PreCachingRetryPolicyApplyConfiguration `json:"retry,omitempty"`
+	Standalone                   *bool                                    `json:"standalone,omitempty"`
+	PlatformImage                *string                                  `json:"platformImage,omitempty"`
+	OperatorsIndexes             []string                                 `json:"operatorsIndexes,omitempty"`
+	OperatorsPackagesAndChannels []string                                 `json:"operatorsPackagesAndChannels,omitempty"`
+	AdditionalImages             []string                                 `json:"additionalImages,omitempty"`
+	ExcludePrecachePatterns      []string                                 `json:"excludePrecachePatterns,omitempty"`
+	SpaceRequired                *string                                  `json:"spaceRequired,omitempty"`
+	PrecachedBy                  *string                                  `json:"precachedBy,omitempty"`
+}
+
+// PreCachingConfigApplyConfiguration constructs an declarative configuration of the PreCachingConfig type for use with
+// apply.
+func PreCachingConfig() *PreCachingConfigApplyConfiguration {
+	return &PreCachingConfigApplyConfiguration{}
+}
+
+// WithMaxConcurrency sets the MaxConcurrency field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the MaxConcurrency field is set to the value of the last call.
+func (b *PreCachingConfigApplyConfiguration) WithMaxConcurrency(value int) *PreCachingConfigApplyConfiguration {
+	b.MaxConcurrency = &value
+	return b
+}
+
+// WithPullRateLimit sets the PullRateLimit field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PullRateLimit field is set to the value of the last call.
+func (b *PreCachingConfigApplyConfiguration) WithPullRateLimit(value int) *PreCachingConfigApplyConfiguration {
+	b.PullRateLimit = &value
+	return b
+}
+
+// WithRetry sets the Retry field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Retry field is set to the value of the last call.
+func (b *PreCachingConfigApplyConfiguration) WithRetry(value *PreCachingRetryPolicyApplyConfiguration) *PreCachingConfigApplyConfiguration {
+	b.Retry = value
+	return b
+}
+
+// WithStandalone sets the Standalone field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Standalone field is set to the value of the last call.
+func (b *PreCachingConfigApplyConfiguration) WithStandalone(value bool) *PreCachingConfigApplyConfiguration {
+	b.Standalone = &value
+	return b
+}
+
+// WithPlatformImage sets the PlatformImage field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PlatformImage field is set to the value of the last call.
+func (b *PreCachingConfigApplyConfiguration) WithPlatformImage(value string) *PreCachingConfigApplyConfiguration {
+	b.PlatformImage = &value
+	return b
+}
+
+// WithOperatorsIndexes adds the given value to the OperatorsIndexes field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the OperatorsIndexes field.
+func (b *PreCachingConfigApplyConfiguration) WithOperatorsIndexes(values ...string) *PreCachingConfigApplyConfiguration {
+	for i := range values {
+		b.OperatorsIndexes = append(b.OperatorsIndexes, values[i])
+	}
+	return b
+}
+
+// WithOperatorsPackagesAndChannels adds the given value to the OperatorsPackagesAndChannels field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the OperatorsPackagesAndChannels field.
+func (b *PreCachingConfigApplyConfiguration) WithOperatorsPackagesAndChannels(values ...string) *PreCachingConfigApplyConfiguration {
+	for i := range values {
+		b.OperatorsPackagesAndChannels = append(b.OperatorsPackagesAndChannels, values[i])
+	}
+	return b
+}
+
+// WithAdditionalImages adds the given value to the AdditionalImages field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the AdditionalImages field.
+func (b *PreCachingConfigApplyConfiguration) WithAdditionalImages(values ...string) *PreCachingConfigApplyConfiguration {
+	for i := range values {
+		b.AdditionalImages = append(b.AdditionalImages, values[i])
+	}
+	return b
+}
+
+// WithExcludePrecachePatterns adds the given value to the ExcludePrecachePatterns field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ExcludePrecachePatterns field.
+func (b *PreCachingConfigApplyConfiguration) WithExcludePrecachePatterns(values ...string) *PreCachingConfigApplyConfiguration {
+	for i := range values {
+		b.ExcludePrecachePatterns = append(b.ExcludePrecachePatterns, values[i])
+	}
+	return b
+}
+
+// WithSpaceRequired sets the SpaceRequired field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SpaceRequired field is set to the value of the last call.
+func (b *PreCachingConfigApplyConfiguration) WithSpaceRequired(value string) *PreCachingConfigApplyConfiguration {
+	b.SpaceRequired = &value
+	return b
+}
+
+// WithPrecachedBy sets the PrecachedBy field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the PrecachedBy field is set to the value of the last call.
+func (b *PreCachingConfigApplyConfiguration) WithPrecachedBy(value string) *PreCachingConfigApplyConfiguration {
+	b.PrecachedBy = &value
+	return b
+}
@@ -0,0 +1,47 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+// PrecachingImageFailureApplyConfiguration represents an declarative configuration of the PrecachingImageFailure type for use
+// with apply.
+type PrecachingImageFailureApplyConfiguration struct {
+	Image  *string `json:"image,omitempty"`
+	Reason *string `json:"reason,omitempty"`
+}
+
+// PrecachingImageFailureApplyConfiguration constructs an declarative configuration of the PrecachingImageFailure type for use with
+// apply.
+func PrecachingImageFailure() *PrecachingImageFailureApplyConfiguration {
+	return &PrecachingImageFailureApplyConfiguration{}
+}
+
+// WithImage sets the Image field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Image field is set to the value of the last call.
+func (b *PrecachingImageFailureApplyConfiguration) WithImage(value string) *PrecachingImageFailureApplyConfiguration {
+	b.Image = &value
+	return b
+}
+
+// WithReason sets the Reason field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Reason field is set to the value of the last call.
+func (b *PrecachingImageFailureApplyConfiguration) WithReason(value string) *PrecachingImageFailureApplyConfiguration {
+	b.Reason = &value
+	return b
+}
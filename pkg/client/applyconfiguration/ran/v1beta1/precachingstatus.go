@@ -0,0 +1,143 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+// PrecachingStatusApplyConfiguration represents an declarative configuration of the PrecachingStatus type for use
+// with apply.
+type PrecachingStatusApplyConfiguration struct {
+	Spec         *PrecachingSpecApplyConfiguration         `json:"spec,omitempty"`
+	Status       []ClusterStateApplyConfiguration          `json:"status,omitempty"`
+	Clusters     []string                                  `json:"clusters,omitempty"`
+	Counts       map[string]int                            `json:"counts,omitempty"`
+	Attempts     []PrecachingAttemptsApplyConfiguration    `json:"attempts,omitempty"`
+	RetryTrigger *string                                   `json:"retryTrigger,omitempty"`
+	Summaries    []PrecachingSummaryApplyConfiguration     `json:"summaries,omitempty"`
+	Specs        []NamedPrecachingSpecApplyConfiguration   `json:"specs,omitempty"`
+	ClusterSpecs []ClusterPrecachingSpecApplyConfiguration `json:"clusterSpecs,omitempty"`
+}
+
+// PrecachingStatusApplyConfiguration constructs an declarative configuration of the PrecachingStatus type for use with
+// apply.
+func PrecachingStatus() *PrecachingStatusApplyConfiguration {
+	return &PrecachingStatusApplyConfiguration{}
+}
+
+// WithSpec sets the Spec field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Spec field is set to the value of the last call.
+func (b *PrecachingStatusApplyConfiguration) WithSpec(value *PrecachingSpecApplyConfiguration) *PrecachingStatusApplyConfiguration {
+	b.Spec = value
+	return b
+}
+
+// WithStatus adds the given value to the Status field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Status field.
+func (b *PrecachingStatusApplyConfiguration) WithStatus(values ...*ClusterStateApplyConfiguration) *PrecachingStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithStatus")
+		}
+		b.Status = append(b.Status, *values[i])
+	}
+	return b
+}
+
+// WithClusters adds the given value to the Clusters field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Clusters field.
+func (b *PrecachingStatusApplyConfiguration) WithClusters(values ...string) *PrecachingStatusApplyConfiguration {
+	for i := range values {
+		b.Clusters = append(b.Clusters, values[i])
+	}
+	return b
+}
+
+// WithCounts puts the entries into the Counts field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, the entries provided by each call will be put on the Counts field,
+// overwriting an existing map entries in Counts field with the same key.
+func (b *PrecachingStatusApplyConfiguration) WithCounts(entries map[string]int) *PrecachingStatusApplyConfiguration {
+	if b.Counts == nil && len(entries) > 0 {
+		b.Counts = make(map[string]int, len(entries))
+	}
+	for k, v := range entries {
+		b.Counts[k] = v
+	}
+	return b
+}
+
+// WithAttempts adds the given value to the Attempts field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Attempts field.
+func (b *PrecachingStatusApplyConfiguration) WithAttempts(values ...*PrecachingAttemptsApplyConfiguration) *PrecachingStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithAttempts")
+		}
+		b.Attempts = append(b.Attempts, *values[i])
+	}
+	return b
+}
+
+// WithRetryTrigger sets the RetryTrigger field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the RetryTrigger field is set to the value of the last call.
+func (b *PrecachingStatusApplyConfiguration) WithRetryTrigger(value string) *PrecachingStatusApplyConfiguration {
+	b.RetryTrigger = &value
+	return b
+}
+
+// WithSummaries adds the given value to the Summaries field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Summaries field.
+func (b *PrecachingStatusApplyConfiguration) WithSummaries(values ...*PrecachingSummaryApplyConfiguration) *PrecachingStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithSummaries")
+		}
+		b.Summaries = append(b.Summaries, *values[i])
+	}
+	return b
+}
+
+// WithSpecs adds the given value to the Specs field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Specs field.
+func (b *PrecachingStatusApplyConfiguration) WithSpecs(values ...*NamedPrecachingSpecApplyConfiguration) *PrecachingStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithSpecs")
+		}
+		b.Specs = append(b.Specs, *values[i])
+	}
+	return b
+}
+
+// WithClusterSpecs adds the given value to the ClusterSpecs field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the ClusterSpecs field.
+func (b *PrecachingStatusApplyConfiguration) WithClusterSpecs(values ...*ClusterPrecachingSpecApplyConfiguration) *PrecachingStatusApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithClusterSpecs")
+		}
+		b.ClusterSpecs = append(b.ClusterSpecs, *values[i])
+	}
+	return b
+}
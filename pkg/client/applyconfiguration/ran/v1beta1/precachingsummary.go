@@ -0,0 +1,124 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+// PrecachingSummaryApplyConfiguration represents an declarative configuration of the PrecachingSummary type for use
+// with apply.
+type PrecachingSummaryApplyConfiguration struct {
+	Name           *string                                    `json:"name,omitempty"`
+	Total          *int                                       `json:"total,omitempty"`
+	Pulled         *int                                       `json:"pulled,omitempty"`
+	Skipped        *int                                       `json:"skipped,omitempty"`
+	Failed         *int                                       `json:"failed,omitempty"`
+	Bytes          *int64                                     `json:"bytes,omitempty"`
+	Failures       []PrecachingImageFailureApplyConfiguration `json:"failures,omitempty"`
+	Error          *string                                    `json:"error,omitempty"`
+	SpaceAvailable *int64                                     `json:"spaceAvailable,omitempty"`
+	SpaceRequired  *int64                                     `json:"spaceRequired,omitempty"`
+}
+
+// PrecachingSummaryApplyConfiguration constructs an declarative configuration of the PrecachingSummary type for use with
+// apply.
+func PrecachingSummary() *PrecachingSummaryApplyConfiguration {
+	return &PrecachingSummaryApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *PrecachingSummaryApplyConfiguration) WithName(value string) *PrecachingSummaryApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithTotal sets the Total field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Total field is set to the value of the last call.
+func (b *PrecachingSummaryApplyConfiguration) WithTotal(value int) *PrecachingSummaryApplyConfiguration {
+	b.Total = &value
+	return b
+}
+
+// WithPulled sets the Pulled field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Pulled field is set to the value of the last call.
+func (b *PrecachingSummaryApplyConfiguration) WithPulled(value int) *PrecachingSummaryApplyConfiguration {
+	b.Pulled = &value
+	return b
+}
+
+// WithSkipped sets the Skipped field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Skipped field is set to the value of the last call.
+func (b *PrecachingSummaryApplyConfiguration) WithSkipped(value int) *PrecachingSummaryApplyConfiguration {
+	b.Skipped = &value
+	return b
+}
+
+// WithFailed sets the Failed field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Failed field is set to the value of the last call.
+func (b *PrecachingSummaryApplyConfiguration) WithFailed(value int) *PrecachingSummaryApplyConfiguration {
+	b.Failed = &value
+	return b
+}
+
+// WithBytes sets the Bytes field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Bytes field is set to the value of the last call.
+func (b *PrecachingSummaryApplyConfiguration) WithBytes(value int64) *PrecachingSummaryApplyConfiguration {
+	b.Bytes = &value
+	return b
+}
+
+// WithFailures adds the given value to the Failures field in the declarative configuration
+// and returns the receiver, so that objects can be build by chaining "With" function invocations.
+// If called multiple times, values provided by each call will be appended to the Failures field.
+func (b *PrecachingSummaryApplyConfiguration) WithFailures(values ...*PrecachingImageFailureApplyConfiguration) *PrecachingSummaryApplyConfiguration {
+	for i := range values {
+		if values[i] == nil {
+			panic("nil value passed to WithFailures")
+		}
+		b.Failures = append(b.Failures, *values[i])
+	}
+	return b
+}
+
+// WithError sets the Error field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Error field is set to the value of the last call.
+func (b *PrecachingSummaryApplyConfiguration) WithError(value string) *PrecachingSummaryApplyConfiguration {
+	b.Error = &value
+	return b
+}
+
+// WithSpaceAvailable sets the SpaceAvailable field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SpaceAvailable field is set to the value of the last call.
+func (b *PrecachingSummaryApplyConfiguration) WithSpaceAvailable(value int64) *PrecachingSummaryApplyConfiguration {
+	b.SpaceAvailable = &value
+	return b
+}
+
+// WithSpaceRequired sets the SpaceRequired field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SpaceRequired field is set to the value of the last call.
+func (b *PrecachingSummaryApplyConfiguration) WithSpaceRequired(value int64) *PrecachingSummaryApplyConfiguration {
+	b.SpaceRequired = &value
+	return b
+}
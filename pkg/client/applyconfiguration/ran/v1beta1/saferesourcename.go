@@ -0,0 +1,47 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package v1beta1
+
+// SafeResourceNameApplyConfiguration represents an declarative configuration of the SafeResourceName type for use
+// with apply.
+type SafeResourceNameApplyConfiguration struct {
+	Name     *string `json:"name,omitempty"`
+	SafeName *string `json:"safeName,omitempty"`
+}
+
+// SafeResourceNameApplyConfiguration constructs an declarative configuration of the SafeResourceName type for use with
+// apply.
+func SafeResourceName() *SafeResourceNameApplyConfiguration {
+	return &SafeResourceNameApplyConfiguration{}
+}
+
+// WithName sets the Name field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the Name field is set to the value of the last call.
+func (b *SafeResourceNameApplyConfiguration) WithName(value string) *SafeResourceNameApplyConfiguration {
+	b.Name = &value
+	return b
+}
+
+// WithSafeName sets the SafeName field in the declarative configuration to the given value
+// and returns the receiver, so that objects can be built by chaining "With" function invocations.
+// If called multiple times, the SafeName field is set to the value of the last call.
+func (b *SafeResourceNameApplyConfiguration) WithSafeName(value string) *SafeResourceNameApplyConfiguration {
+	b.SafeName = &value
+	return b
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by applyconfiguration-gen. DO NOT EDIT.
+
+package applyconfiguration
+
+import (
+	v1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/api/v1alpha1"
+	v1beta1 "github.com/openshift-kni/cluster-group-upgrades-operator/api/v1beta1"
+	ranv1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/pkg/client/applyconfiguration/ran/v1alpha1"
+	ranv1beta1 "github.com/openshift-kni/cluster-group-upgrades-operator/pkg/client/applyconfiguration/ran/v1beta1"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+// ForKind returns an apply configuration type for the given GroupVersionKind, or nil if no
+// apply configuration type exists for the given GroupVersionKind.
+func ForKind(kind schema.GroupVersionKind) interface{} {
+	switch kind {
+	// Group=ran.openshift.io, Version=v1alpha1
+	case v1alpha1.SchemeGroupVersion.WithKind("Actions"):
+		return &ranv1alpha1.ActionsApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("AfterClusterCompletion"):
+		return &ranv1alpha1.AfterClusterCompletionApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("AfterCompletion"):
+		return &ranv1alpha1.AfterCompletionApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("BackupStatus"):
+		return &ranv1alpha1.BackupStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("BeforeEnable"):
+		return &ranv1alpha1.BeforeEnableApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("BlockingCR"):
+		return &ranv1alpha1.BlockingCRApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ClusterGroupUpgrade"):
+		return &ranv1alpha1.ClusterGroupUpgradeApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ClusterGroupUpgradeOperatorConfig"):
+		return &ranv1alpha1.ClusterGroupUpgradeOperatorConfigApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ClusterGroupUpgradeOperatorConfigSpec"):
+		return &ranv1alpha1.ClusterGroupUpgradeOperatorConfigSpecApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ClusterGroupUpgradeSpec"):
+		return &ranv1alpha1.ClusterGroupUpgradeSpecApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ClusterGroupUpgradeStatus"):
+		return &ranv1alpha1.ClusterGroupUpgradeStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ClusterRemediationProgress"):
+		return &ranv1alpha1.ClusterRemediationProgressApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ClusterStateConfigMap"):
+		return &ranv1alpha1.ClusterStateConfigMapApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ClusterStatesStatus"):
+		return &ranv1alpha1.ClusterStatesStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ConcurrencyLimits"):
+		return &ranv1alpha1.ConcurrencyLimitsApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ConfigMapReference"):
+		return &ranv1alpha1.ConfigMapReferenceApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("JobSettings"):
+		return &ranv1alpha1.JobSettingsApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ManagedPolicyForUpgrade"):
+		return &ranv1alpha1.ManagedPolicyForUpgradeApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("NamespaceOverrides"):
+		return &ranv1alpha1.NamespaceOverridesApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("NotificationSink"):
+		return &ranv1alpha1.NotificationSinkApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("OperatorSettings"):
+		return &ranv1alpha1.OperatorSettingsApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("PrecachingAttempts"):
+		return &ranv1alpha1.PrecachingAttemptsApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("PreCachingConfig"):
+		return &ranv1alpha1.PreCachingConfigApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("PrecachingImageFailure"):
+		return &ranv1alpha1.PrecachingImageFailureApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("PreCachingRetryPolicy"):
+		return &ranv1alpha1.PreCachingRetryPolicyApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("PrecachingSpec"):
+		return &ranv1alpha1.PrecachingSpecApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("PrecachingStatus"):
+		return &ranv1alpha1.PrecachingStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("PrecachingSummary"):
+		return &ranv1alpha1.PrecachingSummaryApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("RemediationStrategySpec"):
+		return &ranv1alpha1.RemediationStrategySpecApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("RequeueIntervals"):
+		return &ranv1alpha1.RequeueIntervalsApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("UpdateGraphSettings"):
+		return &ranv1alpha1.UpdateGraphSettingsApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("UpgradeStatus"):
+		return &ranv1alpha1.UpgradeStatusApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ZTPAggregation"):
+		return &ranv1alpha1.ZTPAggregationApplyConfiguration{}
+	case v1alpha1.SchemeGroupVersion.WithKind("ZTPSettings"):
+		return &ranv1alpha1.ZTPSettingsApplyConfiguration{}
+
+		// Group=ran.openshift.io, Version=v1beta1
+	case v1beta1.SchemeGroupVersion.WithKind("Actions"):
+		return &ranv1beta1.ActionsApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("AfterCompletion"):
+		return &ranv1beta1.AfterCompletionApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("BackupStatus"):
+		return &ranv1beta1.BackupStatusApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("BlockingCR"):
+		return &ranv1beta1.BlockingCRApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("ClusterGroupUpgrade"):
+		return &ranv1beta1.ClusterGroupUpgradeApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("ClusterGroupUpgradeSpec"):
+		return &ranv1beta1.ClusterGroupUpgradeSpecApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("ClusterGroupUpgradeStatus"):
+		return &ranv1beta1.ClusterGroupUpgradeStatusApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("ClusterLabelActions"):
+		return &ranv1beta1.ClusterLabelActionsApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("ClusterPrecachingSpec"):
+		return &ranv1beta1.ClusterPrecachingSpecApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("ClusterRemediationProgress"):
+		return &ranv1beta1.ClusterRemediationProgressApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("ClusterState"):
+		return &ranv1beta1.ClusterStateApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("ClusterStateConfigMap"):
+		return &ranv1beta1.ClusterStateConfigMapApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("ClusterStatesStatus"):
+		return &ranv1beta1.ClusterStatesStatusApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("ManagedPolicyForUpgrade"):
+		return &ranv1beta1.ManagedPolicyForUpgradeApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("ManagedPolicyStatus"):
+		return &ranv1beta1.ManagedPolicyStatusApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("NamedPrecachingSpec"):
+		return &ranv1beta1.NamedPrecachingSpecApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("PolicyContent"):
+		return &ranv1beta1.PolicyContentApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("PolicyReference"):
+		return &ranv1beta1.PolicyReferenceApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("PrecachingAttempts"):
+		return &ranv1beta1.PrecachingAttemptsApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("PreCachingConfig"):
+		return &ranv1beta1.PreCachingConfigApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("PrecachingImageFailure"):
+		return &ranv1beta1.PrecachingImageFailureApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("PreCachingRetryPolicy"):
+		return &ranv1beta1.PreCachingRetryPolicyApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("PrecachingSpec"):
+		return &ranv1beta1.PrecachingSpecApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("PrecachingStatus"):
+		return &ranv1beta1.PrecachingStatusApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("PrecachingSummary"):
+		return &ranv1beta1.PrecachingSummaryApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("RemediationStrategySpec"):
+		return &ranv1beta1.RemediationStrategySpecApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("SafeResourceName"):
+		return &ranv1beta1.SafeResourceNameApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("SkippedCluster"):
+		return &ranv1beta1.SkippedClusterApplyConfiguration{}
+	case v1beta1.SchemeGroupVersion.WithKind("UpgradeStatus"):
+		return &ranv1beta1.UpgradeStatusApplyConfiguration{}
+
+	}
+	return nil
+}
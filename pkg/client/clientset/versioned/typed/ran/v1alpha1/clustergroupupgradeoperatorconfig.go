@@ -0,0 +1,167 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	"time"
+
+	v1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/api/v1alpha1"
+	scheme "github.com/openshift-kni/cluster-group-upgrades-operator/pkg/client/clientset/versioned/scheme"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// ClusterGroupUpgradeOperatorConfigsGetter has a method to return a ClusterGroupUpgradeOperatorConfigInterface.
+// A group's client should implement this interface.
+type ClusterGroupUpgradeOperatorConfigsGetter interface {
+	ClusterGroupUpgradeOperatorConfigs() ClusterGroupUpgradeOperatorConfigInterface
+}
+
+// ClusterGroupUpgradeOperatorConfigInterface has methods to work with ClusterGroupUpgradeOperatorConfig resources.
+type ClusterGroupUpgradeOperatorConfigInterface interface {
+	Create(ctx context.Context, clusterGroupUpgradeOperatorConfig *v1alpha1.ClusterGroupUpgradeOperatorConfig, opts v1.CreateOptions) (*v1alpha1.ClusterGroupUpgradeOperatorConfig, error)
+	Update(ctx context.Context, clusterGroupUpgradeOperatorConfig *v1alpha1.ClusterGroupUpgradeOperatorConfig, opts v1.UpdateOptions) (*v1alpha1.ClusterGroupUpgradeOperatorConfig, error)
+	Delete(ctx context.Context, name string, opts v1.DeleteOptions) error
+	DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error
+	Get(ctx context.Context, name string, opts v1.GetOptions) (*v1alpha1.ClusterGroupUpgradeOperatorConfig, error)
+	List(ctx context.Context, opts v1.ListOptions) (*v1alpha1.ClusterGroupUpgradeOperatorConfigList, error)
+	Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error)
+	Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.ClusterGroupUpgradeOperatorConfig, err error)
+	ClusterGroupUpgradeOperatorConfigExpansion
+}
+
+// clusterGroupUpgradeOperatorConfigs implements ClusterGroupUpgradeOperatorConfigInterface
+type clusterGroupUpgradeOperatorConfigs struct {
+	client rest.Interface
+}
+
+// newClusterGroupUpgradeOperatorConfigs returns a ClusterGroupUpgradeOperatorConfigs
+func newClusterGroupUpgradeOperatorConfigs(c *RanV1alpha1Client) *clusterGroupUpgradeOperatorConfigs {
+	return &clusterGroupUpgradeOperatorConfigs{
+		client: c.RESTClient(),
+	}
+}
+
+// Get takes name of the clusterGroupUpgradeOperatorConfig, and returns the corresponding clusterGroupUpgradeOperatorConfig object, and an error if there is any.
+func (c *clusterGroupUpgradeOperatorConfigs) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.ClusterGroupUpgradeOperatorConfig, err error) {
+	result = &v1alpha1.ClusterGroupUpgradeOperatorConfig{}
+	err = c.client.Get().
+		Resource("clustergroupupgradeoperatorconfigs").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of ClusterGroupUpgradeOperatorConfigs that match those selectors.
+func (c *clusterGroupUpgradeOperatorConfigs) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.ClusterGroupUpgradeOperatorConfigList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1alpha1.ClusterGroupUpgradeOperatorConfigList{}
+	err = c.client.Get().
+		Resource("clustergroupupgradeoperatorconfigs").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested clusterGroupUpgradeOperatorConfigs.
+func (c *clusterGroupUpgradeOperatorConfigs) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Resource("clustergroupupgradeoperatorconfigs").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch(ctx)
+}
+
+// Create takes the representation of a clusterGroupUpgradeOperatorConfig and creates it.  Returns the server's representation of the clusterGroupUpgradeOperatorConfig, and an error, if there is any.
+func (c *clusterGroupUpgradeOperatorConfigs) Create(ctx context.Context, clusterGroupUpgradeOperatorConfig *v1alpha1.ClusterGroupUpgradeOperatorConfig, opts v1.CreateOptions) (result *v1alpha1.ClusterGroupUpgradeOperatorConfig, err error) {
+	result = &v1alpha1.ClusterGroupUpgradeOperatorConfig{}
+	err = c.client.Post().
+		Resource("clustergroupupgradeoperatorconfigs").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(clusterGroupUpgradeOperatorConfig).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Update takes the representation of a clusterGroupUpgradeOperatorConfig and updates it. Returns the server's representation of the clusterGroupUpgradeOperatorConfig, and an error, if there is any.
+func (c *clusterGroupUpgradeOperatorConfigs) Update(ctx context.Context, clusterGroupUpgradeOperatorConfig *v1alpha1.ClusterGroupUpgradeOperatorConfig, opts v1.UpdateOptions) (result *v1alpha1.ClusterGroupUpgradeOperatorConfig, err error) {
+	result = &v1alpha1.ClusterGroupUpgradeOperatorConfig{}
+	err = c.client.Put().
+		Resource("clustergroupupgradeoperatorconfigs").
+		Name(clusterGroupUpgradeOperatorConfig.Name).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(clusterGroupUpgradeOperatorConfig).
+		Do(ctx).
+		Into(result)
+	return
+}
+
+// Delete takes name of the clusterGroupUpgradeOperatorConfig and deletes it. Returns an error if one occurs.
+func (c *clusterGroupUpgradeOperatorConfigs) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	return c.client.Delete().
+		Resource("clustergroupupgradeoperatorconfigs").
+		Name(name).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *clusterGroupUpgradeOperatorConfigs) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	var timeout time.Duration
+	if listOpts.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOpts.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Resource("clustergroupupgradeoperatorconfigs").
+		VersionedParams(&listOpts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(&opts).
+		Do(ctx).
+		Error()
+}
+
+// Patch applies the patch and returns the patched clusterGroupUpgradeOperatorConfig.
+func (c *clusterGroupUpgradeOperatorConfigs) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.ClusterGroupUpgradeOperatorConfig, err error) {
+	result = &v1alpha1.ClusterGroupUpgradeOperatorConfig{}
+	err = c.client.Patch(pt).
+		Resource("clustergroupupgradeoperatorconfigs").
+		Name(name).
+		SubResource(subresources...).
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Body(data).
+		Do(ctx).
+		Into(result)
+	return
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/api/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeClusterGroupUpgrades implements ClusterGroupUpgradeInterface
+type FakeClusterGroupUpgrades struct {
+	Fake *FakeRanV1alpha1
+	ns   string
+}
+
+var clustergroupupgradesResource = schema.GroupVersionResource{Group: "ran.openshift.io", Version: "v1alpha1", Resource: "clustergroupupgrades"}
+
+var clustergroupupgradesKind = schema.GroupVersionKind{Group: "ran.openshift.io", Version: "v1alpha1", Kind: "ClusterGroupUpgrade"}
+
+// Get takes name of the clusterGroupUpgrade, and returns the corresponding clusterGroupUpgrade object, and an error if there is any.
+func (c *FakeClusterGroupUpgrades) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.ClusterGroupUpgrade, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(clustergroupupgradesResource, c.ns, name), &v1alpha1.ClusterGroupUpgrade{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ClusterGroupUpgrade), err
+}
+
+// List takes label and field selectors, and returns the list of ClusterGroupUpgrades that match those selectors.
+func (c *FakeClusterGroupUpgrades) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.ClusterGroupUpgradeList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(clustergroupupgradesResource, clustergroupupgradesKind, c.ns, opts), &v1alpha1.ClusterGroupUpgradeList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.ClusterGroupUpgradeList{ListMeta: obj.(*v1alpha1.ClusterGroupUpgradeList).ListMeta}
+	for _, item := range obj.(*v1alpha1.ClusterGroupUpgradeList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested clusterGroupUpgrades.
+func (c *FakeClusterGroupUpgrades) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(clustergroupupgradesResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a clusterGroupUpgrade and creates it.  Returns the server's representation of the clusterGroupUpgrade, and an error, if there is any.
+func (c *FakeClusterGroupUpgrades) Create(ctx context.Context, clusterGroupUpgrade *v1alpha1.ClusterGroupUpgrade, opts v1.CreateOptions) (result *v1alpha1.ClusterGroupUpgrade, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(clustergroupupgradesResource, c.ns, clusterGroupUpgrade), &v1alpha1.ClusterGroupUpgrade{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ClusterGroupUpgrade), err
+}
+
+// Update takes the representation of a clusterGroupUpgrade and updates it. Returns the server's representation of the clusterGroupUpgrade, and an error, if there is any.
+func (c *FakeClusterGroupUpgrades) Update(ctx context.Context, clusterGroupUpgrade *v1alpha1.ClusterGroupUpgrade, opts v1.UpdateOptions) (result *v1alpha1.ClusterGroupUpgrade, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(clustergroupupgradesResource, c.ns, clusterGroupUpgrade), &v1alpha1.ClusterGroupUpgrade{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ClusterGroupUpgrade), err
+}
+
+// UpdateStatus was generated because the type contains a Status member.
+// Add a +genclient:noStatus comment above the type to avoid generating UpdateStatus().
+func (c *FakeClusterGroupUpgrades) UpdateStatus(ctx context.Context, clusterGroupUpgrade *v1alpha1.ClusterGroupUpgrade, opts v1.UpdateOptions) (*v1alpha1.ClusterGroupUpgrade, error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateSubresourceAction(clustergroupupgradesResource, "status", c.ns, clusterGroupUpgrade), &v1alpha1.ClusterGroupUpgrade{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ClusterGroupUpgrade), err
+}
+
+// Delete takes name of the clusterGroupUpgrade and deletes it. Returns an error if one occurs.
+func (c *FakeClusterGroupUpgrades) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteAction(clustergroupupgradesResource, c.ns, name), &v1alpha1.ClusterGroupUpgrade{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeClusterGroupUpgrades) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(clustergroupupgradesResource, c.ns, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.ClusterGroupUpgradeList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched clusterGroupUpgrade.
+func (c *FakeClusterGroupUpgrades) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.ClusterGroupUpgrade, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(clustergroupupgradesResource, c.ns, name, pt, data, subresources...), &v1alpha1.ClusterGroupUpgrade{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ClusterGroupUpgrade), err
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	"context"
+
+	v1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/api/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeClusterGroupUpgradeOperatorConfigs implements ClusterGroupUpgradeOperatorConfigInterface
+type FakeClusterGroupUpgradeOperatorConfigs struct {
+	Fake *FakeRanV1alpha1
+}
+
+var clustergroupupgradeoperatorconfigsResource = schema.GroupVersionResource{Group: "ran.openshift.io", Version: "v1alpha1", Resource: "clustergroupupgradeoperatorconfigs"}
+
+var clustergroupupgradeoperatorconfigsKind = schema.GroupVersionKind{Group: "ran.openshift.io", Version: "v1alpha1", Kind: "ClusterGroupUpgradeOperatorConfig"}
+
+// Get takes name of the clusterGroupUpgradeOperatorConfig, and returns the corresponding clusterGroupUpgradeOperatorConfig object, and an error if there is any.
+func (c *FakeClusterGroupUpgradeOperatorConfigs) Get(ctx context.Context, name string, options v1.GetOptions) (result *v1alpha1.ClusterGroupUpgradeOperatorConfig, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootGetAction(clustergroupupgradeoperatorconfigsResource, name), &v1alpha1.ClusterGroupUpgradeOperatorConfig{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ClusterGroupUpgradeOperatorConfig), err
+}
+
+// List takes label and field selectors, and returns the list of ClusterGroupUpgradeOperatorConfigs that match those selectors.
+func (c *FakeClusterGroupUpgradeOperatorConfigs) List(ctx context.Context, opts v1.ListOptions) (result *v1alpha1.ClusterGroupUpgradeOperatorConfigList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootListAction(clustergroupupgradeoperatorconfigsResource, clustergroupupgradeoperatorconfigsKind, opts), &v1alpha1.ClusterGroupUpgradeOperatorConfigList{})
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &v1alpha1.ClusterGroupUpgradeOperatorConfigList{ListMeta: obj.(*v1alpha1.ClusterGroupUpgradeOperatorConfigList).ListMeta}
+	for _, item := range obj.(*v1alpha1.ClusterGroupUpgradeOperatorConfigList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested clusterGroupUpgradeOperatorConfigs.
+func (c *FakeClusterGroupUpgradeOperatorConfigs) Watch(ctx context.Context, opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewRootWatchAction(clustergroupupgradeoperatorconfigsResource, opts))
+}
+
+// Create takes the representation of a clusterGroupUpgradeOperatorConfig and creates it.  Returns the server's representation of the clusterGroupUpgradeOperatorConfig, and an error, if there is any.
+func (c *FakeClusterGroupUpgradeOperatorConfigs) Create(ctx context.Context, clusterGroupUpgradeOperatorConfig *v1alpha1.ClusterGroupUpgradeOperatorConfig, opts v1.CreateOptions) (result *v1alpha1.ClusterGroupUpgradeOperatorConfig, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootCreateAction(clustergroupupgradeoperatorconfigsResource, clusterGroupUpgradeOperatorConfig), &v1alpha1.ClusterGroupUpgradeOperatorConfig{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ClusterGroupUpgradeOperatorConfig), err
+}
+
+// Update takes the representation of a clusterGroupUpgradeOperatorConfig and updates it. Returns the server's representation of the clusterGroupUpgradeOperatorConfig, and an error, if there is any.
+func (c *FakeClusterGroupUpgradeOperatorConfigs) Update(ctx context.Context, clusterGroupUpgradeOperatorConfig *v1alpha1.ClusterGroupUpgradeOperatorConfig, opts v1.UpdateOptions) (result *v1alpha1.ClusterGroupUpgradeOperatorConfig, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootUpdateAction(clustergroupupgradeoperatorconfigsResource, clusterGroupUpgradeOperatorConfig), &v1alpha1.ClusterGroupUpgradeOperatorConfig{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ClusterGroupUpgradeOperatorConfig), err
+}
+
+// Delete takes name of the clusterGroupUpgradeOperatorConfig and deletes it. Returns an error if one occurs.
+func (c *FakeClusterGroupUpgradeOperatorConfigs) Delete(ctx context.Context, name string, opts v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewRootDeleteAction(clustergroupupgradeoperatorconfigsResource, name), &v1alpha1.ClusterGroupUpgradeOperatorConfig{})
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeClusterGroupUpgradeOperatorConfigs) DeleteCollection(ctx context.Context, opts v1.DeleteOptions, listOpts v1.ListOptions) error {
+	action := testing.NewRootDeleteCollectionAction(clustergroupupgradeoperatorconfigsResource, listOpts)
+
+	_, err := c.Fake.Invokes(action, &v1alpha1.ClusterGroupUpgradeOperatorConfigList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched clusterGroupUpgradeOperatorConfig.
+func (c *FakeClusterGroupUpgradeOperatorConfigs) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, opts v1.PatchOptions, subresources ...string) (result *v1alpha1.ClusterGroupUpgradeOperatorConfig, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewRootPatchSubresourceAction(clustergroupupgradeoperatorconfigsResource, name, pt, data, subresources...), &v1alpha1.ClusterGroupUpgradeOperatorConfig{})
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*v1alpha1.ClusterGroupUpgradeOperatorConfig), err
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2021.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1alpha1
+
+import (
+	"context"
+	time "time"
+
+	ranv1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/api/v1alpha1"
+	versioned "github.com/openshift-kni/cluster-group-upgrades-operator/pkg/client/clientset/versioned"
+	internalinterfaces "github.com/openshift-kni/cluster-group-upgrades-operator/pkg/client/informers/externalversions/internalinterfaces"
+	v1alpha1 "github.com/openshift-kni/cluster-group-upgrades-operator/pkg/client/listers/ran/v1alpha1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// ClusterGroupUpgradeOperatorConfigInformer provides access to a shared informer and lister for
+// ClusterGroupUpgradeOperatorConfigs.
+type ClusterGroupUpgradeOperatorConfigInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() v1alpha1.ClusterGroupUpgradeOperatorConfigLister
+}
+
+type clusterGroupUpgradeOperatorConfigInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+}
+
+// NewClusterGroupUpgradeOperatorConfigInformer constructs a new informer for ClusterGroupUpgradeOperatorConfig type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewClusterGroupUpgradeOperatorConfigInformer(client versioned.Interface, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredClusterGroupUpgradeOperatorConfigInformer(client, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredClusterGroupUpgradeOperatorConfigInformer constructs a new informer for ClusterGroupUpgradeOperatorConfig type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredClusterGroupUpgradeOperatorConfigInformer(client versioned.Interface, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options v1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.RanV1alpha1().ClusterGroupUpgradeOperatorConfigs().List(context.TODO(), options)
+			},
+			WatchFunc: func(options v1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.RanV1alpha1().ClusterGroupUpgradeOperatorConfigs().Watch(context.TODO(), options)
+			},
+		},
+		&ranv1alpha1.ClusterGroupUpgradeOperatorConfig{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *clusterGroupUpgradeOperatorConfigInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredClusterGroupUpgradeOperatorConfigInformer(client, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *clusterGroupUpgradeOperatorConfigInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&ranv1alpha1.ClusterGroupUpgradeOperatorConfig{}, f.defaultInformer)
+}
+
+func (f *clusterGroupUpgradeOperatorConfigInformer) Lister() v1alpha1.ClusterGroupUpgradeOperatorConfigLister {
+	return v1alpha1.NewClusterGroupUpgradeOperatorConfigLister(f.Informer().GetIndexer())
+}
@@ -1,3 +1,4 @@
+import os
 import sys
 import json
 import argparse
@@ -18,6 +19,10 @@ def parse_args():
     parser.add_argument('img_list_file', nargs='?',
                         type=argparse.FileType('a'),
                         help="Path to the image list file (appended).")
+    parser.add_argument('--installed-csvs', dest='installed_csvs_file',
+                        help="Path to the list of installed CSVs file, \
+                            where each line contains \
+                            <package>:<csv> record")
 
     args = parser.parse_args()
     if len(sys.argv) < 3:
@@ -26,18 +31,76 @@ def parse_args():
     return args
 
 
+def read_installed_csvs(path):
+    """ Read the CSVs installed on the cluster, by package """
+    installed = {}
+    if not path or not os.path.exists(path):
+        return installed
+    with open(path, 'r') as f:
+        for line in f.read().splitlines():
+            item = [i.strip() for i in line.split(":")]
+            if len(item) != 2 or not item[0] or not item[1]:
+                continue
+            installed[item[0]] = item[1]
+    return installed
+
+
+def upgrade_path(entries, installed):
+    """ Find the bundles OLM upgrades through from the installed CSV to the channel head
+
+    Input parameters:
+    entries: the entries of the channel, with the latest bundle last
+    installed: name of the installed CSV, None if the operator is not installed
+
+    Processing:
+    1. Walk the replaces chain back from the head to rank the bundles by
+        their distance to the head
+    2. From the installed CSV, repeatedly move to the bundle replacing or
+        skipping the current one that is the closest to the head, as OLM
+        does, until the head is reached
+
+    Returns: list of bundle names, the head only if the installed CSV is
+        not found in the channel
+    """
+    head = entries[-1].get("name")
+    by_name = {entry.get("name"): entry for entry in entries}
+    rank = {}
+    current = head
+    while current in by_name and current not in rank:
+        rank[current] = len(rank)
+        current = by_name[current].get("replaces")
+
+    if installed is None or installed == head or installed not in by_name:
+        return [head]
+    path, visited = [], {installed}
+    current = installed
+    while current != head:
+        candidates = [entry.get("name") for entry in entries
+                      if entry.get("replaces") == current or current in (entry.get("skips") or [])]
+        candidates = [c for c in candidates if c not in visited]
+        if not candidates:
+            print(f"no upgrade from {current} in the channel, pre-caching the channel head")
+            return [head]
+        current = min(candidates, key=lambda c: rank.get(c, len(by_name)))
+        visited.add(current)
+        path.append(current)
+    return path
+
+
 def extract_images(args, objects):
     """ Extract related images from the rendered index
     
     Input parameters:
-    args: command line arguments, contain operators_spec_file.name
+    args: command line arguments, contain operators_spec_file.name and
+        installed_csvs_file
     objects: a list of parsed index objects that comply to OLM schema
 
     Processing:
     1. Create a structure of packages and channels from the pre-caching
         spec (mounted in the container as args.operators_spec_file.name)
-    2. Create a list of bundles for the given packages by taking the latest
-        bundle in the channel
+    2. Create a list of bundles for the given packages by taking the bundles
+        on the upgrade path from the installed CSV to the latest bundle in
+        the channel, or the latest bundle if the operator is not installed
     3. For the selected bundles, extract the related images and return them
         as a list
 
@@ -45,6 +108,7 @@ def extract_images(args, objects):
     """
     bundles, packages, images = [], [], []
     channels = {}
+    installed = read_installed_csvs(args.installed_csvs_file)
     # 1. Form the operators packages and channels structure
     with open(args.operators_spec_file.name, 'r') as p:
         # "records" is a list of list: [package, channel] items
@@ -58,12 +122,13 @@ def extract_images(args, objects):
         channels[package] = channel
         packages.append(package)
         print(f"will process package {package} channel {channel}")
-    # 2. Find the right channels for our packages and get the latest bundle
+    # 2. Find the right channels for our packages and get the bundles up to the latest one
     for item in objects:
         if item.get("schema") == "olm.channel":
             if item.get("package") in packages and item.get("name") == channels[item.get("package")]:
-                latest = item.get("entries")[-1].get("name")
-                bundles.append(latest)
+                path = upgrade_path(item.get("entries"), installed.get(item.get("package")))
+                print(f"package {item.get('package')} bundles to pre-cache: {path}")
+                bundles.extend(path)
     # 3. extract related images from our bundles
     for item in objects:
         if item.get("schema") == "olm.bundle":
@@ -2,13 +2,48 @@
 
 set -e
 
+cwd="${cwd:-/opt/precache}"
+. $cwd/common
+
+# The pull script runs on the host, the summary files are read from the container under /host
+host_summary_dir=/host$summary_dir
+
+# Publish the pre-caching summary in the pre-cache-summary configmap, read by the hub through a view
+publish_summary() {
+    /host/usr/libexec/platform-python /opt/precache/publish_summary.py $host_summary_dir "$@" || \
+        log_debug "Failed to publish the pre-caching summary"
+}
+
+fail() {
+    log_debug "[FAIL] $1"
+    echo "$1" > $host_summary_dir/error
+    publish_summary --done
+    exit 1
+}
+
+rm -rf $host_summary_dir
+mkdir -p $host_summary_dir
+
 # Pull spec extraction is done in the container due to opm<->selinux issues
 /opt/precache/copy-env.sh
-/opt/precache/release
-/opt/precache/olm
+/opt/precache/release || fail "Failed to extract the release images"
+/opt/precache/olm || fail "Failed to extract the operator images"
+# The additional images are pulled as they are listed
+grep -v '^[[:space:]]*$' $config_volume_path/additional.images 2>/dev/null | tr -d " " >> $pull_spec_file
+exclude_images < $pull_spec_file > $pull_spec_file.included
+mv $pull_spec_file.included $pull_spec_file
 
 # Image pull is done on the host using "chroot /host"
 cp /tmp/images.txt /host/tmp/
 rm -rf /host/tmp/precache
 cp -a /opt/precache /host/tmp/
-chroot /host /tmp/precache/pull
+# The progress is published periodically while the images are pulled
+(while true; do sleep $summary_interval; publish_summary; done) &
+publisher=$!
+rc=0
+# The space required is checked by the pull script before pulling, estimated from the images if not set
+space_required=$(cat $config_volume_path/space.required 2>/dev/null || true)
+SPACE_REQUIRED=${space_required:-0} chroot /host /tmp/precache/pull || rc=$?
+kill $publisher || true
+publish_summary --done
+exit $rc
@@ -0,0 +1,122 @@
+import os
+import ssl
+import sys
+import json
+import argparse
+import traceback
+import urllib.error
+import urllib.request
+
+SERVICE_ACCOUNT_PATH = "/var/run/secrets/kubernetes.io/serviceaccount"
+NAMESPACE = "openshift-talo-pre-cache"
+CONFIGMAP_NAME = "pre-cache-summary"
+MAX_FAILURES = 10  # Keep the summary small, it ends up in the ClusterGroupUpgrade status
+MAX_REASON_LENGTH = 256
+
+
+def parse_args():
+    """ Parse the command line arguments """
+    parser = argparse.ArgumentParser(
+        description='Publish the pre-caching summary in the pre-cache-summary configmap.')
+    parser.add_argument('summary_dir',
+                        help="Path to the files counting the images pulled, skipped and failed")
+    parser.add_argument('--done', action='store_true',
+                        help="The job is done, this is the last summary")
+    return parser.parse_args()
+
+
+def read_lines(path):
+    if not os.path.exists(path):
+        return []
+    with open(path) as f:
+        return [line.rstrip('\n') for line in f if line.strip()]
+
+
+def get_summary(summary_dir, done):
+    """ Build the summary from the files written by the pull script
+
+    Input parameters:
+    summary_dir: directory of the files
+        total: number of images to pre-cache
+        pulled: one "<image> <size>" line per image pulled
+        skipped: one line per image already present
+        failed: one "<image>\t<reason>" line per image that could not be pulled
+        error: reason the job failed before pulling the images
+        space: "<available> <required>" disk space in bytes, checked before pulling the images
+    done: the job is done
+    """
+    total = read_lines(os.path.join(summary_dir, "total"))
+    pulled = read_lines(os.path.join(summary_dir, "pulled"))
+    failed = read_lines(os.path.join(summary_dir, "failed"))
+    error = read_lines(os.path.join(summary_dir, "error"))
+    summary = {
+        "total": int(total[0]) if total else 0,
+        "pulled": len(pulled),
+        "skipped": len(read_lines(os.path.join(summary_dir, "skipped"))),
+        "failed": len(failed),
+        "bytes": 0,
+        "done": done,
+    }
+    for line in pulled:
+        size = line.rsplit(' ', 1)[-1]
+        if size.isdigit():
+            summary["bytes"] += int(size)
+    failures = []
+    for line in failed[:MAX_FAILURES]:
+        image, _, reason = line.partition('\t')
+        failures.append({"image": image, "reason": reason[:MAX_REASON_LENGTH]})
+    if failures:
+        summary["failures"] = failures
+    if error:
+        summary["error"] = error[0][:MAX_REASON_LENGTH]
+    space = read_lines(os.path.join(summary_dir, "space"))
+    if space:
+        available, _, required = space[0].partition(' ')
+        if available.isdigit() and required.isdigit():
+            summary["spaceAvailable"] = int(available)
+            summary["spaceRequired"] = int(required)
+    return summary
+
+
+def api_request(method, path, body):
+    host = os.environ["KUBERNETES_SERVICE_HOST"]
+    if ':' in host:
+        host = "[%s]" % host
+    url = "https://%s:%s%s" % (host, os.environ.get("KUBERNETES_SERVICE_PORT", "443"), path)
+    with open(os.path.join(SERVICE_ACCOUNT_PATH, "token")) as f:
+        token = f.read().strip()
+    context = ssl.create_default_context(cafile=os.path.join(SERVICE_ACCOUNT_PATH, "ca.crt"))
+    request = urllib.request.Request(url, data=json.dumps(body).encode(), method=method, headers={
+        "Authorization": "Bearer %s" % token,
+        "Content-Type": "application/json",
+    })
+    with urllib.request.urlopen(request, context=context, timeout=30):
+        pass
+
+
+def publish(summary):
+    """ Create or replace the pre-cache-summary configmap """
+    path = "/api/v1/namespaces/%s/configmaps" % NAMESPACE
+    configmap = {
+        "apiVersion": "v1",
+        "kind": "ConfigMap",
+        "metadata": {"name": CONFIGMAP_NAME, "namespace": NAMESPACE},
+        "data": {"summary": json.dumps(summary)},
+    }
+    try:
+        api_request("POST", path, configmap)
+    except urllib.error.HTTPError as e:
+        if e.code != 409:
+            raise
+        api_request("PUT", "%s/%s" % (path, CONFIGMAP_NAME), configmap)
+
+
+if __name__ == "__main__":
+    try:
+        args = parse_args()
+        publish(get_summary(args.summary_dir, args.done))
+        sys.exit(0)
+    except Exception as e:
+        print(e)
+        traceback.print_exc(file=sys.stdout)
+        sys.exit(1)
@@ -75,5 +75,42 @@ result=$(extract_pull_spec "/tmp")
 [[ $(cat $pull_spec_file) == "\"test\"" ]] || fatal "release pull spec extract failure"
 echo " release extract_pull_spec pass"
 
+# Test parse_index
+echo "Testing parse_index unit:"
+upgrade_path() {
+    python3 -c "
+import sys, json
+sys.path.insert(0, '$cwd')
+from parse_index import upgrade_path
+print(','.join(upgrade_path(json.loads(sys.argv[1]), sys.argv[2] or None)))" "$1" "$2"
+}
+chain='[{"name": "op.v1"}, {"name": "op.v2", "replaces": "op.v1"}, {"name": "op.v3", "replaces": "op.v2"}]'
+result=$(upgrade_path "$chain" "op.v1")
+[[ $result == "op.v2,op.v3" ]] || fatal "upgrade_path replaces chain failure: $result"
+echo " upgrade_path replaces chain pass"
+
+skips='[{"name": "op.v1"}, {"name": "op.v2", "replaces": "op.v1"},
+    {"name": "op.v3", "replaces": "op.v2", "skips": ["op.v1"]}, {"name": "op.v4", "replaces": "op.v3"}]'
+result=$(upgrade_path "$skips" "op.v1")
+[[ $result == "op.v3,op.v4" ]] || fatal "upgrade_path skips failure: $result"
+echo " upgrade_path skips pass"
+
+result=$(upgrade_path "$chain" "op.v0")
+[[ $result == "op.v3" ]] || fatal "upgrade_path installed CSV not in the channel failure: $result"
+echo " upgrade_path installed CSV not in the channel pass"
+
+result=$(upgrade_path "$chain" "")
+[[ $result == "op.v3" ]] || fatal "upgrade_path without installed CSV failure: $result"
+echo " upgrade_path without installed CSV pass"
+
+printf "op:op.v1\nother:\n" > /tmp/operators.installedCSVs
+result=$(python3 -c "
+import sys
+sys.path.insert(0, '$cwd')
+from parse_index import read_installed_csvs
+print(read_installed_csvs('/tmp/operators.installedCSVs'))")
+[[ $result == "{'op': 'op.v1'}" ]] || fatal "read_installed_csvs missing installed CSV failure: $result"
+echo " read_installed_csvs missing installed CSV pass"
+
 # Clean
-rm -rf /tmp/operators.indexes /tmp/release-manifests $pull_spec_file /tmp/operators.packagesAndChannels
+rm -rf /tmp/operators.installedCSVs /tmp/operators.indexes /tmp/release-manifests $pull_spec_file /tmp/operators.packagesAndChannels